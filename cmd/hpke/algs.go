@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudflare/circl/hpke"
+)
+
+var kemNames = []struct {
+	name string
+	id   hpke.KEM
+}{
+	{"P256_HKDF_SHA256", hpke.KEM_P256_HKDF_SHA256},
+	{"P384_HKDF_SHA384", hpke.KEM_P384_HKDF_SHA384},
+	{"P521_HKDF_SHA512", hpke.KEM_P521_HKDF_SHA512},
+	{"X25519_HKDF_SHA256", hpke.KEM_X25519_HKDF_SHA256},
+	{"X448_HKDF_SHA512", hpke.KEM_X448_HKDF_SHA512},
+	{"K256_HKDF_SHA256", hpke.KEM_K256_HKDF_SHA256},
+}
+
+var kdfNames = []struct {
+	name string
+	id   hpke.KDF
+}{
+	{"HKDF_SHA256", hpke.KDF_HKDF_SHA256},
+	{"HKDF_SHA384", hpke.KDF_HKDF_SHA384},
+	{"HKDF_SHA512", hpke.KDF_HKDF_SHA512},
+}
+
+var aeadNames = []struct {
+	name string
+	id   hpke.AEAD
+}{
+	{"AES128GCM", hpke.AEAD_AES128GCM},
+	{"AES256GCM", hpke.AEAD_AES256GCM},
+	{"ChaCha20Poly1305", hpke.AEAD_ChaCha20Poly1305},
+}
+
+// parseID accepts either a decimal or 0x-prefixed hexadecimal codepoint.
+func parseID(s string) (uint16, bool) {
+	v, err := strconv.ParseUint(s, 0, 16)
+	return uint16(v), err == nil
+}
+
+func parseKEM(s string) (hpke.KEM, error) {
+	for _, k := range kemNames {
+		if strings.EqualFold(s, k.name) {
+			return k.id, nil
+		}
+	}
+	if v, ok := parseID(s); ok && hpke.KEM(v).IsValid() {
+		return hpke.KEM(v), nil
+	}
+	return 0, fmt.Errorf("unknown KEM %q", s)
+}
+
+func parseKDF(s string) (hpke.KDF, error) {
+	for _, k := range kdfNames {
+		if strings.EqualFold(s, k.name) {
+			return k.id, nil
+		}
+	}
+	if v, ok := parseID(s); ok && hpke.KDF(v).IsValid() {
+		return hpke.KDF(v), nil
+	}
+	return 0, fmt.Errorf("unknown KDF %q", s)
+}
+
+func parseAEAD(s string) (hpke.AEAD, error) {
+	for _, a := range aeadNames {
+		if strings.EqualFold(s, a.name) {
+			return a.id, nil
+		}
+	}
+	if v, ok := parseID(s); ok && hpke.AEAD(v).IsValid() {
+		return hpke.AEAD(v), nil
+	}
+	return 0, fmt.Errorf("unknown AEAD %q", s)
+}
+
+func kemName(id hpke.KEM) string {
+	for _, k := range kemNames {
+		if k.id == id {
+			return k.name
+		}
+	}
+	return fmt.Sprintf("unknown(0x%04x)", uint16(id))
+}
+
+func kdfName(id hpke.KDF) string {
+	for _, k := range kdfNames {
+		if k.id == id {
+			return k.name
+		}
+	}
+	return fmt.Sprintf("unknown(0x%04x)", uint16(id))
+}
+
+func aeadName(id hpke.AEAD) string {
+	for _, a := range aeadNames {
+		if a.id == id {
+			return a.name
+		}
+	}
+	return fmt.Sprintf("unknown(0x%04x)", uint16(id))
+}
+
+func listNames() string {
+	b := &strings.Builder{}
+	fmt.Fprintln(b, "KEMs:")
+	for _, k := range kemNames {
+		fmt.Fprintf(b, "  %-20v 0x%04x\n", k.name, uint16(k.id))
+	}
+	fmt.Fprintln(b, "KDFs:")
+	for _, k := range kdfNames {
+		fmt.Fprintf(b, "  %-20v 0x%04x\n", k.name, uint16(k.id))
+	}
+	fmt.Fprintln(b, "AEADs:")
+	for _, a := range aeadNames {
+		fmt.Fprintf(b, "  %-20v 0x%04x\n", a.name, uint16(a.id))
+	}
+	return b.String()
+}
@@ -0,0 +1,268 @@
+package main
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/cloudflare/circl/hpke"
+)
+
+// An envelope is the file format produced by the seal command. It is
+// composed of a header followed by a sequence of frames. Each frame holds
+// the encryption of a chunk of the plaintext under the HPKE context set up
+// by the header. (Expressed in TLS syntax.)
+//
+//  struct {
+//      opaque magic[4] = "HPKE";
+//      uint8 version = 1;
+//      uint8 mode;
+//      HpkeKemId kem_id;
+//      HpkeKdfId kdf_id;
+//      HpkeAeadId aead_id;
+//      uint32 chunk_size;
+//      opaque enc<0..2^16-1>;
+//  } EnvelopeHeader;
+//
+//  struct {
+//      uint32 flags_and_length; // most significant bit set on the last frame.
+//      opaque ct[length];
+//  } EnvelopeFrame;
+//
+// Every chunk is chunk_size bytes long except for the last one, which may be
+// shorter or empty. The associated data of the AEAD encryption of a frame is
+// the serialized EnvelopeHeader, followed by the byte 0x01 for the last
+// frame or 0x00 for the others, and by the user-provided associated data.
+// Hence, any change to the header is detected by the opener. As the nonce is
+// incremented on each frame, reordering, truncation and extension of the
+// sequence of frames are detected as well.
+const (
+	envelopeMagic     = "HPKE"
+	envelopeVersion   = 1
+	envelopeFinalFlag = uint32(1) << 31
+
+	defaultChunkSize = 64 << 10
+	maxChunkSize     = 16 << 20
+
+	headerFixedSize = 4 + 1 + 1 + 2 + 2 + 2 + 4 + 2
+)
+
+const (
+	modeBase    uint8 = 0x00
+	modePSK     uint8 = 0x01
+	modeAuth    uint8 = 0x02
+	modeAuthPSK uint8 = 0x03
+)
+
+var modeNames = [...]string{"base", "psk", "auth", "auth_psk"}
+
+var (
+	errBadMagic   = errors.New("not an HPKE envelope")
+	errBadVersion = errors.New("unsupported envelope version")
+	errBadHeader  = errors.New("malformed envelope header")
+	errBadFrame   = errors.New("malformed envelope frame")
+	errTruncated  = errors.New("envelope is truncated")
+	errTrailing   = errors.New("trailing data after the last frame")
+)
+
+type header struct {
+	mode      uint8
+	suite     hpke.Suite
+	chunkSize uint32
+	enc       []byte
+}
+
+func (h *header) marshal() []byte {
+	kemID, kdfID, aeadID := h.suite.Params()
+	b := make([]byte, headerFixedSize, headerFixedSize+len(h.enc))
+	copy(b[0:4], envelopeMagic)
+	b[4] = envelopeVersion
+	b[5] = h.mode
+	binary.BigEndian.PutUint16(b[6:8], uint16(kemID))
+	binary.BigEndian.PutUint16(b[8:10], uint16(kdfID))
+	binary.BigEndian.PutUint16(b[10:12], uint16(aeadID))
+	binary.BigEndian.PutUint32(b[12:16], h.chunkSize)
+	binary.BigEndian.PutUint16(b[16:18], uint16(len(h.enc)))
+	return append(b, h.enc...)
+}
+
+func readHeader(r io.Reader) (*header, error) {
+	var b [headerFixedSize]byte
+	if _, err := io.ReadFull(r, b[:]); err != nil {
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, errTruncated
+		}
+		return nil, err
+	}
+	if string(b[0:4]) != envelopeMagic {
+		return nil, errBadMagic
+	}
+	if b[4] != envelopeVersion {
+		return nil, errBadVersion
+	}
+
+	h := &header{mode: b[5]}
+	if int(h.mode) >= len(modeNames) {
+		return nil, fmt.Errorf("%w: unknown mode %v", errBadHeader, h.mode)
+	}
+	kemID := hpke.KEM(binary.BigEndian.Uint16(b[6:8]))
+	kdfID := hpke.KDF(binary.BigEndian.Uint16(b[8:10]))
+	aeadID := hpke.AEAD(binary.BigEndian.Uint16(b[10:12]))
+	suite, err := hpke.LookupSuite(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadHeader, err)
+	}
+	h.suite = suite
+
+	h.chunkSize = binary.BigEndian.Uint32(b[12:16])
+	if h.chunkSize == 0 || h.chunkSize > maxChunkSize {
+		return nil, fmt.Errorf("%w: invalid chunk size %v", errBadHeader, h.chunkSize)
+	}
+
+	encLen := int(binary.BigEndian.Uint16(b[16:18]))
+	scheme, err := kemID.LookupScheme()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errBadHeader, err)
+	}
+	if encLen != scheme.CiphertextSize() {
+		return nil, fmt.Errorf("%w: invalid encapsulated key size %v", errBadHeader, encLen)
+	}
+	h.enc = make([]byte, encLen)
+	if _, err := io.ReadFull(r, h.enc); err != nil {
+		return nil, errTruncated
+	}
+	return h, nil
+}
+
+// frameAAD returns the associated data of a frame, which binds the header
+// of the envelope.
+func frameAAD(hdr []byte, final bool, aad []byte) []byte {
+	flag := byte(0)
+	if final {
+		flag = 1
+	}
+	b := make([]byte, 0, len(hdr)+1+len(aad))
+	b = append(append(b, hdr...), flag)
+	return append(b, aad...)
+}
+
+// sealStream encrypts the contents of r in chunks of h.chunkSize bytes and
+// writes the frames to w. The header h must be written to w beforehand.
+func sealStream(w io.Writer, r io.Reader, sealer hpke.Sealer, h *header, aad []byte) error {
+	chunkSize := int(h.chunkSize)
+	hdr := h.marshal()
+	br := bufio.NewReaderSize(r, chunkSize)
+	buf := make([]byte, chunkSize)
+	var frameHdr [4]byte
+	for {
+		n, err := io.ReadFull(br, buf)
+		final := false
+		switch {
+		case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+			final = true
+		case err != nil:
+			return err
+		default:
+			if _, err = br.Peek(1); errors.Is(err, io.EOF) {
+				final = true
+			} else if err != nil {
+				return err
+			}
+		}
+
+		ct, err := sealer.Seal(buf[:n], frameAAD(hdr, final, aad))
+		if err != nil {
+			return err
+		}
+		flags := uint32(len(ct))
+		if final {
+			flags |= envelopeFinalFlag
+		}
+		binary.BigEndian.PutUint32(frameHdr[:], flags)
+		if _, err = w.Write(frameHdr[:]); err != nil {
+			return err
+		}
+		if _, err = w.Write(ct); err != nil {
+			return err
+		}
+		if final {
+			return nil
+		}
+	}
+}
+
+// openStream decrypts the frames read from r and writes the plaintext to w.
+// Each chunk is authenticated before being written, however, an error
+// reported after some output was written means that the envelope was
+// truncated or tampered with.
+func openStream(w io.Writer, r io.Reader, opener hpke.Opener, h *header, aad []byte) error {
+	_, _, aeadID := h.suite.Params()
+	maxLen := aeadID.CipherLen(uint(h.chunkSize))
+	hdr := h.marshal()
+	var frameHdr [4]byte
+	for {
+		if _, err := io.ReadFull(r, frameHdr[:]); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return errTruncated
+			}
+			return err
+		}
+		flags := binary.BigEndian.Uint32(frameHdr[:])
+		final := flags&envelopeFinalFlag != 0
+		ctLen := uint(flags &^ envelopeFinalFlag)
+		if ctLen > maxLen {
+			return errBadFrame
+		}
+
+		ct := make([]byte, ctLen)
+		if _, err := io.ReadFull(r, ct); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return errTruncated
+			}
+			return err
+		}
+		pt, err := opener.Open(ct, frameAAD(hdr, final, aad))
+		if err != nil {
+			return err
+		}
+		if _, err = w.Write(pt); err != nil {
+			return err
+		}
+
+		if final {
+			var b [1]byte
+			if _, err := io.ReadFull(r, b[:]); err == nil {
+				return errTrailing
+			}
+			return nil
+		}
+	}
+}
+
+// frameStats walks through the frames of an envelope without decrypting
+// them.
+func frameStats(r io.Reader) (frames int, ctBytes uint64, complete bool, err error) {
+	var frameHdr [4]byte
+	for {
+		if _, err = io.ReadFull(r, frameHdr[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return frames, ctBytes, false, nil
+			}
+			return frames, ctBytes, false, errTruncated
+		}
+		flags := binary.BigEndian.Uint32(frameHdr[:])
+		ctLen := int64(flags &^ envelopeFinalFlag)
+		n, err := io.CopyN(ioutil.Discard, r, ctLen)
+		if err != nil {
+			return frames, ctBytes + uint64(n), false, errTruncated
+		}
+		frames++
+		ctBytes += uint64(n)
+		if flags&envelopeFinalFlag != 0 {
+			return frames, ctBytes, true, nil
+		}
+	}
+}
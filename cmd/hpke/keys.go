@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/kem"
+)
+
+const (
+	pemPrivateKey = "HPKE PRIVATE KEY"
+	pemPublicKey  = "HPKE PUBLIC KEY"
+	pemKEMHeader  = "KEM"
+)
+
+// encodeKey returns the PEM encoding of a marshaled KEM key. The KEM
+// identifier is stored as a header of the PEM block.
+func encodeKey(blockType string, kemID hpke.KEM, key []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{
+		Type:    blockType,
+		Headers: map[string]string{pemKEMHeader: fmt.Sprintf("0x%04x", uint16(kemID))},
+		Bytes:   key,
+	})
+}
+
+// decodeKey reads a key either in PEM or in raw format. For raw keys, kemID
+// must be provided; for PEM keys, kemID is taken from the PEM header and
+// must match the provided one if it is not zero.
+func decodeKey(data []byte, blockType string, kemID hpke.KEM) (hpke.KEM, []byte, error) {
+	if !bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN")) {
+		if kemID == 0 {
+			return 0, nil, errors.New("raw keys require the -kem flag")
+		}
+		return kemID, data, nil
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return 0, nil, errors.New("invalid PEM data")
+	}
+	if block.Type != blockType {
+		return 0, nil, fmt.Errorf("expected PEM block %q, got %q", blockType, block.Type)
+	}
+	id, err := parseKEM(block.Headers[pemKEMHeader])
+	if err != nil {
+		return 0, nil, err
+	}
+	if kemID != 0 && kemID != id {
+		return 0, nil, fmt.Errorf("key is for KEM %v, not %v", kemName(id), kemName(kemID))
+	}
+	return id, block.Bytes, nil
+}
+
+func readPrivateKey(path string, kemID hpke.KEM) (hpke.KEM, kem.PrivateKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	id, raw, err := decodeKey(data, pemPrivateKey, kemID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	scheme, err := id.LookupScheme()
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	if len(raw) != scheme.PrivateKeySize() {
+		return 0, nil, fmt.Errorf("%v: %w", path, kem.ErrPrivKeySize)
+	}
+	sk, err := scheme.UnmarshalBinaryPrivateKey(raw)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return id, sk, nil
+}
+
+func readPublicKey(path string, kemID hpke.KEM) (hpke.KEM, kem.PublicKey, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, nil, err
+	}
+	id, raw, err := decodeKey(data, pemPublicKey, kemID)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	scheme, err := id.LookupScheme()
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	if len(raw) != scheme.PublicKeySize() {
+		return 0, nil, fmt.Errorf("%v: %w", path, kem.ErrPubKeySize)
+	}
+	pk, err := scheme.UnmarshalBinaryPublicKey(raw)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%v: %w", path, err)
+	}
+	return id, pk, nil
+}
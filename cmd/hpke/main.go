@@ -0,0 +1,436 @@
+// Command hpke generates HPKE keys and encrypts files using Hybrid Public
+// Key Encryption.
+//
+// Usage:
+//
+//  hpke keygen  -kem name [-format pem|raw] -out sk [-pub pk]
+//  hpke seal    -pub pk [-kdf name] [-aead name] [-sender-key sk]
+//               [-psk hex -psk-id string] [-info string] [-aad string]
+//               [-in file] [-out file]
+//  hpke open    -key sk [-sender-pub pk] [-psk hex -psk-id string]
+//               [-info string] [-aad string] [-in file] [-out file]
+//  hpke inspect [-in file]
+//  hpke list
+//
+// Keys are read and written either in raw format, as produced by the
+// MarshalBinary methods of kem.PublicKey and kem.PrivateKey, or in PEM format
+// with the KEM identifier stored in the "KEM" header of the block. Reading a
+// raw key requires the -kem flag.
+//
+// The HPKE mode of seal and open is selected by the flags: -psk and -psk-id
+// select the PSK mode, -sender-key (for seal) and -sender-pub (for open)
+// select the Auth mode, and all of them select the AuthPSK mode.
+//
+// Files are read from the standard input and written to the standard output
+// when -in or -out are omitted or set to "-". The format of sealed files is
+// described in envelope.go; the inspect command prints the header of a
+// sealed file.
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/kem"
+)
+
+var errUsage = errors.New("usage error")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, errUsage) {
+			fmt.Fprintln(os.Stderr, "hpke:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	if len(args) == 0 {
+		usage(stderr)
+		return errUsage
+	}
+
+	cmds := map[string]func([]string, io.Reader, io.Writer, io.Writer) error{
+		"keygen":  cmdKeygen,
+		"seal":    cmdSeal,
+		"open":    cmdOpen,
+		"inspect": cmdInspect,
+		"list": func([]string, io.Reader, io.Writer, io.Writer) error {
+			_, err := fmt.Fprint(stdout, listNames())
+			return err
+		},
+	}
+	cmd, ok := cmds[args[0]]
+	if !ok {
+		usage(stderr)
+		return errUsage
+	}
+	return cmd(args[1:], stdin, stdout, stderr)
+}
+
+func usage(w io.Writer) {
+	fmt.Fprintln(w, "usage: hpke <keygen|seal|open|inspect|list> [flags]")
+	fmt.Fprintln(w, "run 'hpke <command> -h' for the flags of a command.")
+}
+
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	return fs
+}
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		return errUsage
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintf(fs.Output(), "unexpected argument: %v\n", fs.Arg(0))
+		return errUsage
+	}
+	return nil
+}
+
+func cmdKeygen(args []string, _ io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("keygen", stderr)
+	kemFlag := fs.String("kem", "X25519_HKDF_SHA256", "KEM name or identifier")
+	format := fs.String("format", "pem", "output format: pem or raw")
+	out := fs.String("out", "-", "private key output file")
+	pub := fs.String("pub", "", "public key output file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	kemID, err := parseKEM(*kemFlag)
+	if err != nil {
+		return err
+	}
+	if *format != "pem" && *format != "raw" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+
+	scheme, err := kemID.LookupScheme()
+	if err != nil {
+		return err
+	}
+	pk, sk, err := scheme.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+	skBytes, err := sk.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	pkBytes, err := pk.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	if *format == "pem" {
+		skBytes = encodeKey(pemPrivateKey, kemID, skBytes)
+		pkBytes = encodeKey(pemPublicKey, kemID, pkBytes)
+	}
+
+	if err = writeFile(*out, stdout, skBytes, 0o600); err != nil {
+		return err
+	}
+	if *pub != "" {
+		return writeFile(*pub, stdout, pkBytes, 0o644)
+	}
+	return nil
+}
+
+// commonFlags are shared by the seal and open commands.
+type commonFlags struct {
+	kem, in, out, info, aad, psk, pskID *string
+}
+
+func addCommonFlags(fs *flag.FlagSet) *commonFlags {
+	return &commonFlags{
+		kem:   fs.String("kem", "", "KEM name or identifier, required for raw keys"),
+		in:    fs.String("in", "-", "input file"),
+		out:   fs.String("out", "-", "output file"),
+		info:  fs.String("info", "", "info string of the HPKE context"),
+		aad:   fs.String("aad", "", "associated data"),
+		psk:   fs.String("psk", "", "pre-shared key in hexadecimal"),
+		pskID: fs.String("psk-id", "", "identifier of the pre-shared key"),
+	}
+}
+
+func (c *commonFlags) kemID() (hpke.KEM, error) {
+	if *c.kem == "" {
+		return 0, nil
+	}
+	return parseKEM(*c.kem)
+}
+
+func (c *commonFlags) pskInputs() (psk, pskID []byte, err error) {
+	if (*c.psk == "") != (*c.pskID == "") {
+		return nil, nil, errors.New("-psk and -psk-id must be used together")
+	}
+	if *c.psk == "" {
+		return nil, nil, nil
+	}
+	psk, err = hex.DecodeString(*c.psk)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid -psk: %w", err)
+	}
+	return psk, []byte(*c.pskID), nil
+}
+
+func selectMode(auth bool, psk []byte) uint8 {
+	switch {
+	case auth && psk != nil:
+		return modeAuthPSK
+	case auth:
+		return modeAuth
+	case psk != nil:
+		return modePSK
+	default:
+		return modeBase
+	}
+}
+
+func cmdSeal(args []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
+	fs := newFlagSet("seal", stderr)
+	c := addCommonFlags(fs)
+	pub := fs.String("pub", "", "receiver's public key file")
+	senderKey := fs.String("sender-key", "", "sender's private key file (Auth modes)")
+	kdfFlag := fs.String("kdf", "HKDF_SHA256", "KDF name or identifier")
+	aeadFlag := fs.String("aead", "AES128GCM", "AEAD name or identifier")
+	chunk := fs.Uint("chunk", defaultChunkSize, "size of plaintext chunks")
+	if err = parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *pub == "" {
+		fmt.Fprintln(stderr, "seal: missing -pub")
+		return errUsage
+	}
+	if *chunk == 0 || *chunk > maxChunkSize {
+		return fmt.Errorf("chunk size must be between 1 and %v", maxChunkSize)
+	}
+
+	kemID, err := c.kemID()
+	if err != nil {
+		return err
+	}
+	kdfID, err := parseKDF(*kdfFlag)
+	if err != nil {
+		return err
+	}
+	aeadID, err := parseAEAD(*aeadFlag)
+	if err != nil {
+		return err
+	}
+	psk, pskID, err := c.pskInputs()
+	if err != nil {
+		return err
+	}
+	kemID, pkR, err := readPublicKey(*pub, kemID)
+	if err != nil {
+		return err
+	}
+	var skS kem.PrivateKey
+	if *senderKey != "" {
+		if _, skS, err = readPrivateKey(*senderKey, kemID); err != nil {
+			return err
+		}
+	}
+
+	suite, err := hpke.LookupSuite(kemID, kdfID, aeadID)
+	if err != nil {
+		return err
+	}
+	sender, err := suite.NewSender(pkR, []byte(*c.info))
+	if err != nil {
+		return err
+	}
+	h := &header{mode: selectMode(skS != nil, psk), suite: suite, chunkSize: uint32(*chunk)}
+	var sealer hpke.Sealer
+	switch h.mode {
+	case modeBase:
+		h.enc, sealer, err = sender.Setup(rand.Reader)
+	case modePSK:
+		h.enc, sealer, err = sender.SetupPSK(rand.Reader, psk, pskID)
+	case modeAuth:
+		h.enc, sealer, err = sender.SetupAuth(rand.Reader, skS)
+	case modeAuthPSK:
+		h.enc, sealer, err = sender.SetupAuthPSK(rand.Reader, skS, psk, pskID)
+	}
+	if err != nil {
+		return err
+	}
+
+	r, closeIn, err := openInput(*c.in, stdin)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+	w, closeOut, err := createOutput(*c.out, stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOut(); err == nil {
+			err = cerr
+		}
+	}()
+
+	if _, err = w.Write(h.marshal()); err != nil {
+		return err
+	}
+	return sealStream(w, r, sealer, h, []byte(*c.aad))
+}
+
+func cmdOpen(args []string, stdin io.Reader, stdout, stderr io.Writer) (err error) {
+	fs := newFlagSet("open", stderr)
+	c := addCommonFlags(fs)
+	key := fs.String("key", "", "receiver's private key file")
+	senderPub := fs.String("sender-pub", "", "sender's public key file (Auth modes)")
+	if err = parseFlags(fs, args); err != nil {
+		return err
+	}
+	if *key == "" {
+		fmt.Fprintln(stderr, "open: missing -key")
+		return errUsage
+	}
+
+	kemID, err := c.kemID()
+	if err != nil {
+		return err
+	}
+	psk, pskID, err := c.pskInputs()
+	if err != nil {
+		return err
+	}
+
+	r, closeIn, err := openInput(*c.in, stdin)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+	h, err := readHeader(r)
+	if err != nil {
+		return err
+	}
+	hKEM, _, _ := h.suite.Params()
+	if kemID != 0 && kemID != hKEM {
+		return fmt.Errorf("envelope uses KEM %v, not %v", kemName(hKEM), kemName(kemID))
+	}
+
+	_, skR, err := readPrivateKey(*key, hKEM)
+	if err != nil {
+		return err
+	}
+	var pkS kem.PublicKey
+	if *senderPub != "" {
+		if _, pkS, err = readPublicKey(*senderPub, hKEM); err != nil {
+			return err
+		}
+	}
+	if mode := selectMode(pkS != nil, psk); mode != h.mode {
+		return fmt.Errorf("envelope uses %v mode, but flags select %v mode",
+			modeNames[h.mode], modeNames[mode])
+	}
+
+	receiver, err := h.suite.NewReceiver(skR, []byte(*c.info))
+	if err != nil {
+		return err
+	}
+	var opener hpke.Opener
+	switch h.mode {
+	case modeBase:
+		opener, err = receiver.Setup(h.enc)
+	case modePSK:
+		opener, err = receiver.SetupPSK(h.enc, psk, pskID)
+	case modeAuth:
+		opener, err = receiver.SetupAuth(h.enc, pkS)
+	case modeAuthPSK:
+		opener, err = receiver.SetupAuthPSK(h.enc, psk, pskID, pkS)
+	}
+	if err != nil {
+		return err
+	}
+
+	w, closeOut, err := createOutput(*c.out, stdout)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := closeOut(); err == nil {
+			err = cerr
+		}
+	}()
+	return openStream(w, r, opener, h, []byte(*c.aad))
+}
+
+func cmdInspect(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	fs := newFlagSet("inspect", stderr)
+	in := fs.String("in", "-", "input file")
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	r, closeIn, err := openInput(*in, stdin)
+	if err != nil {
+		return err
+	}
+	defer closeIn()
+	h, err := readHeader(r)
+	if err != nil {
+		return err
+	}
+	frames, ctBytes, complete, err := frameStats(r)
+	if err != nil {
+		return err
+	}
+
+	kemID, kdfID, aeadID := h.suite.Params()
+	fmt.Fprintf(stdout, "version:    %v\n", envelopeVersion)
+	fmt.Fprintf(stdout, "mode:       %v (0x%02x)\n", modeNames[h.mode], h.mode)
+	fmt.Fprintf(stdout, "kem:        %v (0x%04x)\n", kemName(kemID), uint16(kemID))
+	fmt.Fprintf(stdout, "kdf:        %v (0x%04x)\n", kdfName(kdfID), uint16(kdfID))
+	fmt.Fprintf(stdout, "aead:       %v (0x%04x)\n", aeadName(aeadID), uint16(aeadID))
+	fmt.Fprintf(stdout, "chunk size: %v\n", h.chunkSize)
+	fmt.Fprintf(stdout, "enc:        %x\n", h.enc)
+	fmt.Fprintf(stdout, "frames:     %v\n", frames)
+	fmt.Fprintf(stdout, "ciphertext: %v bytes\n", ctBytes)
+	fmt.Fprintf(stdout, "complete:   %v\n", complete)
+	return nil
+}
+
+func openInput(path string, stdin io.Reader) (io.Reader, func() error, error) {
+	if path == "" || path == "-" {
+		return stdin, func() error { return nil }, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+func createOutput(path string, stdout io.Writer) (io.Writer, func() error, error) {
+	if path == "" || path == "-" {
+		return stdout, func() error { return nil }, nil
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	return f, f.Close, nil
+}
+
+func writeFile(path string, stdout io.Writer, data []byte, perm os.FileMode) error {
+	if path == "" || path == "-" {
+		_, err := stdout.Write(data)
+		return err
+	}
+	return ioutil.WriteFile(path, data, perm)
+}
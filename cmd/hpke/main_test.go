@@ -0,0 +1,324 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var binPath string
+
+func TestMain(m *testing.M) {
+	dir, err := ioutil.TempDir("", "hpke-cmd")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	binPath = filepath.Join(dir, "hpke")
+	out, err := exec.Command("go", "build", "-o", binPath, ".").CombinedOutput()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "building hpke: %v\n%s", err, out)
+		os.RemoveAll(dir)
+		os.Exit(1)
+	}
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// hpkeCmd runs the binary and returns its standard output.
+func hpkeCmd(t *testing.T, stdin []byte, args ...string) ([]byte, error) {
+	t.Helper()
+	cmd := exec.Command(binPath, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%v: %s", err, stderr.Bytes())
+	}
+	return stdout.Bytes(), nil
+}
+
+func mustRun(t *testing.T, stdin []byte, args ...string) []byte {
+	t.Helper()
+	out, err := hpkeCmd(t, stdin, args...)
+	test.CheckNoErr(t, err, strings.Join(args, " "))
+	return out
+}
+
+func TestKeygen(t *testing.T) {
+	dir := t.TempDir()
+	for _, k := range kemNames {
+		scheme := k.id.Scheme()
+		for _, format := range []string{"pem", "raw"} {
+			sk := filepath.Join(dir, k.name+"."+format+".sk")
+			pk := filepath.Join(dir, k.name+"."+format+".pk")
+			mustRun(t, nil, "keygen", "-kem", k.name, "-format", format, "-out", sk, "-pub", pk)
+
+			skData, err := ioutil.ReadFile(sk)
+			test.CheckNoErr(t, err, "reading private key")
+			pkData, err := ioutil.ReadFile(pk)
+			test.CheckNoErr(t, err, "reading public key")
+			if format == "pem" {
+				block, _ := pem.Decode(skData)
+				if block == nil || block.Type != pemPrivateKey ||
+					block.Headers[pemKEMHeader] != fmt.Sprintf("0x%04x", uint16(k.id)) {
+					t.Fatalf("%v: invalid PEM private key", k.name)
+				}
+				skData = block.Bytes
+				block, _ = pem.Decode(pkData)
+				if block == nil || block.Type != pemPublicKey {
+					t.Fatalf("%v: invalid PEM public key", k.name)
+				}
+				pkData = block.Bytes
+			}
+
+			skR, err := scheme.UnmarshalBinaryPrivateKey(skData)
+			test.CheckNoErr(t, err, "library rejects private key")
+			pkR, err := scheme.UnmarshalBinaryPublicKey(pkData)
+			test.CheckNoErr(t, err, "library rejects public key")
+			if !skR.Public().Equal(pkR) {
+				t.Fatalf("%v: public key does not match private key", k.name)
+			}
+		}
+	}
+
+	_, err := hpkeCmd(t, nil, "keygen", "-kem", "unknown")
+	test.CheckIsErr(t, err, "keygen must fail with unknown KEM")
+}
+
+type modeCase struct {
+	name      string
+	mode      uint8
+	sealFlags []string
+	openFlags []string
+}
+
+func modeCases(skS, pkS string) []modeCase {
+	psk := []string{"-psk", "0123456789abcdef0123456789abcdef", "-psk-id", "psk id"}
+	return []modeCase{
+		{"base", modeBase, nil, nil},
+		{"psk", modePSK, psk, psk},
+		{"auth", modeAuth, []string{"-sender-key", skS}, []string{"-sender-pub", pkS}},
+		{
+			"auth_psk", modeAuthPSK,
+			append([]string{"-sender-key", skS}, psk...),
+			append([]string{"-sender-pub", pkS}, psk...),
+		},
+	}
+}
+
+func TestSealOpen(t *testing.T) {
+	dir := t.TempDir()
+	skR, pkR := filepath.Join(dir, "r.sk"), filepath.Join(dir, "r.pk")
+	skS, pkS := filepath.Join(dir, "s.sk"), filepath.Join(dir, "s.pk")
+	mustRun(t, nil, "keygen", "-kem", "X25519_HKDF_SHA256", "-out", skR, "-pub", pkR)
+	mustRun(t, nil, "keygen", "-kem", "X25519_HKDF_SHA256", "-out", skS, "-pub", pkS)
+
+	for _, size := range []int{0, 1, 1000, 4096, 10000} {
+		pt := make([]byte, size)
+		_, _ = rand.Read(pt)
+		ptFile := filepath.Join(dir, "pt")
+		test.CheckNoErr(t, ioutil.WriteFile(ptFile, pt, 0o600), "writing plaintext")
+
+		for _, mc := range modeCases(skS, pkS) {
+			ctx := fmt.Sprintf("size: %v mode: %v", size, mc.name)
+			common := []string{"-info", "info", "-aad", "aad"}
+
+			// Files.
+			ctFile := filepath.Join(dir, "ct")
+			args := append([]string{"seal", "-pub", pkR, "-chunk", "1000", "-in", ptFile, "-out", ctFile}, common...)
+			mustRun(t, nil, append(args, mc.sealFlags...)...)
+			args = append([]string{"open", "-key", skR, "-in", ctFile}, common...)
+			got := mustRun(t, nil, append(args, mc.openFlags...)...)
+			if !bytes.Equal(got, pt) {
+				test.ReportError(t, got, pt, ctx)
+			}
+
+			// Standard streams.
+			args = append([]string{"seal", "-pub", pkR, "-chunk", "4096"}, common...)
+			ct := mustRun(t, pt, append(args, mc.sealFlags...)...)
+			args = append([]string{"open", "-key", skR}, common...)
+			got = mustRun(t, ct, append(args, mc.openFlags...)...)
+			if !bytes.Equal(got, pt) {
+				test.ReportError(t, got, pt, ctx)
+			}
+
+			out := mustRun(t, ct, "inspect")
+			if !strings.Contains(string(out), "mode:       "+mc.name) ||
+				!strings.Contains(string(out), "X25519_HKDF_SHA256") ||
+				!strings.Contains(string(out), "complete:   true") {
+				t.Fatalf("%v: unexpected inspect output:\n%s", ctx, out)
+			}
+
+			// Wrong associated data, truncation and trailing data.
+			args = append([]string{"open", "-key", skR, "-info", "info", "-aad", "bad"}, mc.openFlags...)
+			_, err := hpkeCmd(t, ct, args...)
+			test.CheckIsErr(t, err, ctx+": open must fail with wrong aad")
+			args = append([]string{"open", "-key", skR}, common...)
+			_, err = hpkeCmd(t, ct[:len(ct)-1], append(args, mc.openFlags...)...)
+			test.CheckIsErr(t, err, ctx+": open must fail on truncated input")
+			_, err = hpkeCmd(t, append(ct, 0), append(args, mc.openFlags...)...)
+			test.CheckIsErr(t, err, ctx+": open must fail on trailing data")
+		}
+	}
+}
+
+// TestLibraryInterop checks that envelopes produced by the binary are opened
+// by the library and vice versa.
+func TestLibraryInterop(t *testing.T) {
+	dir := t.TempDir()
+	kemID := hpke.KEM_P384_HKDF_SHA384
+	suite := hpke.NewSuite(kemID, hpke.KDF_HKDF_SHA384, hpke.AEAD_ChaCha20Poly1305)
+	info, aad := []byte("interop info"), []byte("interop aad")
+	psk, pskID := []byte("0123456789abcdef0123456789abcdef"), []byte("psk id")
+	pt := make([]byte, 3000)
+	_, _ = rand.Read(pt)
+
+	pkR, skR, err := kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation")
+	pkS, skS, err := kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation")
+	files := map[string][]byte{}
+	for name, key := range map[string]interface{ MarshalBinary() ([]byte, error) }{
+		"r.sk": skR, "r.pk": pkR, "s.sk": skS, "s.pk": pkS,
+	} {
+		raw, err := key.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal key")
+		files[name] = raw
+		test.CheckNoErr(t, ioutil.WriteFile(filepath.Join(dir, name), raw, 0o600), "writing key")
+	}
+	path := func(name string) string { return filepath.Join(dir, name) }
+
+	for _, mc := range modeCases(path("s.sk"), path("s.pk")) {
+		pskFlags := []string{"-psk", fmt.Sprintf("%x", psk), "-psk-id", string(pskID)}
+		hasPSK := mc.mode == modePSK || mc.mode == modeAuthPSK
+		hasAuth := mc.mode == modeAuth || mc.mode == modeAuthPSK
+		sealFlags := []string{"-kem", "0x0011", "-kdf", "HKDF_SHA384", "-aead", "ChaCha20Poly1305"}
+		openFlags := []string{"-kem", "P384_HKDF_SHA384"}
+		if hasPSK {
+			sealFlags = append(sealFlags, pskFlags...)
+			openFlags = append(openFlags, pskFlags...)
+		}
+		if hasAuth {
+			sealFlags = append(sealFlags, "-sender-key", path("s.sk"))
+			openFlags = append(openFlags, "-sender-pub", path("s.pk"))
+		}
+		common := []string{"-info", string(info), "-aad", string(aad), "-chunk", "1024"}
+
+		// Binary seals, library opens.
+		ct := mustRun(t, pt, append(append([]string{"seal", "-pub", path("r.pk")}, common...), sealFlags...)...)
+		r := bytes.NewReader(ct)
+		h, err := readHeader(r)
+		test.CheckNoErr(t, err, "reading header")
+		if h.suite != suite || h.mode != mc.mode {
+			test.ReportError(t, h.suite, suite, mc.name)
+		}
+		receiver, err := suite.NewReceiver(skR, info)
+		test.CheckNoErr(t, err, "new receiver")
+		var opener hpke.Opener
+		switch mc.mode {
+		case modeBase:
+			opener, err = receiver.Setup(h.enc)
+		case modePSK:
+			opener, err = receiver.SetupPSK(h.enc, psk, pskID)
+		case modeAuth:
+			opener, err = receiver.SetupAuth(h.enc, pkS)
+		case modeAuthPSK:
+			opener, err = receiver.SetupAuthPSK(h.enc, psk, pskID, pkS)
+		}
+		test.CheckNoErr(t, err, "receiver setup")
+		var got bytes.Buffer
+		test.CheckNoErr(t, openStream(&got, r, opener, h, aad), "library open")
+		if !bytes.Equal(got.Bytes(), pt) {
+			test.ReportError(t, got.Bytes(), pt, mc.name)
+		}
+
+		// Library seals, binary opens.
+		sender, err := suite.NewSender(pkR, info)
+		test.CheckNoErr(t, err, "new sender")
+		h = &header{mode: mc.mode, suite: suite, chunkSize: 512}
+		var sealer hpke.Sealer
+		switch mc.mode {
+		case modeBase:
+			h.enc, sealer, err = sender.Setup(rand.Reader)
+		case modePSK:
+			h.enc, sealer, err = sender.SetupPSK(rand.Reader, psk, pskID)
+		case modeAuth:
+			h.enc, sealer, err = sender.SetupAuth(rand.Reader, skS)
+		case modeAuthPSK:
+			h.enc, sealer, err = sender.SetupAuthPSK(rand.Reader, skS, psk, pskID)
+		}
+		test.CheckNoErr(t, err, "sender setup")
+		var env bytes.Buffer
+		env.Write(h.marshal())
+		test.CheckNoErr(t, sealStream(&env, bytes.NewReader(pt), sealer, h, aad), "library seal")
+		args := append([]string{"open", "-key", path("r.sk"), "-info", string(info), "-aad", string(aad)}, openFlags...)
+		out := mustRun(t, env.Bytes(), args...)
+		if !bytes.Equal(out, pt) {
+			test.ReportError(t, out, pt, mc.name)
+		}
+	}
+}
+
+// TestHeaderTamper checks that changes to the header of an envelope are
+// detected when opening it.
+func TestHeaderTamper(t *testing.T) {
+	dir := t.TempDir()
+	sk, pk := filepath.Join(dir, "r.sk"), filepath.Join(dir, "r.pk")
+	mustRun(t, nil, "keygen", "-kem", "X25519_HKDF_SHA256", "-out", sk, "-pub", pk)
+	pt := make([]byte, 3000)
+	_, _ = rand.Read(pt)
+	ct := mustRun(t, pt, "seal", "-pub", pk, "-chunk", "1000", "-aad", "aad")
+	mustRun(t, ct, "open", "-key", sk, "-aad", "aad")
+
+	for _, tc := range []struct {
+		name  string
+		field []byte
+		value []byte
+	}{
+		{"chunk_size", ct[12:16], []byte{0x00, 0x00, 0x10, 0x00}},
+		{"kdf_id", ct[8:10], []byte{0x00, 0x03}},
+		{"aead_id", ct[10:12], []byte{0x00, 0x03}},
+	} {
+		old := append([]byte{}, tc.field...)
+		copy(tc.field, tc.value)
+		_, err := hpkeCmd(t, ct, "open", "-key", sk, "-aad", "aad")
+		test.CheckIsErr(t, err, "open must fail with tampered "+tc.name)
+		copy(tc.field, old)
+	}
+}
+
+func TestInspectErrors(t *testing.T) {
+	for _, tc := range []struct {
+		in   []byte
+		want error
+	}{
+		{nil, errTruncated},
+		{[]byte("NOPE\x01"), errTruncated},
+		{[]byte("NOPE\x01\x00\x00\x20\x00\x01\x00\x01\x00\x00\x00\x10\x00\x20"), errBadMagic},
+		{[]byte("HPKE\x02\x00\x00\x20\x00\x01\x00\x01\x00\x00\x00\x10\x00\x20"), errBadVersion},
+		{[]byte("HPKE\x01\x07\x00\x20\x00\x01\x00\x01\x00\x00\x00\x10\x00\x20"), errBadHeader},
+		{[]byte("HPKE\x01\x00\x00\x99\x00\x01\x00\x01\x00\x00\x00\x10\x00\x20"), errBadHeader},
+		{[]byte("HPKE\x01\x00\x00\x20\x00\x01\x00\x01\x00\x00\x00\x00\x00\x20"), errBadHeader},
+		{[]byte("HPKE\x01\x00\x00\x20\x00\x01\x00\x01\x00\x00\x00\x10\x00\x21"), errBadHeader},
+		{[]byte("HPKE\x01\x00\x00\x20\x00\x01\x00\x01\x00\x00\x00\x10\x00\x20\x00"), errTruncated},
+	} {
+		_, err := readHeader(bytes.NewReader(tc.in))
+		if !errors.Is(err, tc.want) {
+			test.ReportError(t, err, tc.want, tc.in)
+		}
+	}
+}
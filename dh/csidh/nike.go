@@ -0,0 +1,161 @@
+package csidh
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/nike"
+)
+
+// This file contains the boilerplate code to connect CSIDH-512 to the
+// generic NIKE API.
+
+// Scheme returns the generic NIKE interface for CSIDH-512.
+//
+// The randomness used for sampling points during the group action is read
+// from crypto/rand.Reader, as it has no effect on the results. Key pairs
+// generated from the same randomness are thus equal. DeriveKeyPair reads
+// the randomness from SHAKE256 of the seed.
+func Scheme() nike.Scheme { return scheme{} }
+
+type scheme struct{}
+
+// Size of the seeds of DeriveKeyPair.
+const seedSize = 32
+
+type nikePublicKey struct {
+	pk PublicKey
+}
+
+type nikePrivateKey struct {
+	sk PrivateKey
+	pk nikePublicKey
+}
+
+func (scheme) Name() string          { return "CSIDH-512" }
+func (scheme) PublicKeySize() int    { return PublicKeySize }
+func (scheme) PrivateKeySize() int   { return PrivateKeySize }
+func (scheme) SharedSecretSize() int { return SharedSecretSize }
+func (scheme) SeedSize() int         { return seedSize }
+
+func (*nikePublicKey) Scheme() nike.Scheme  { return scheme{} }
+func (*nikePrivateKey) Scheme() nike.Scheme { return scheme{} }
+
+func (scheme) GenerateKeyPair() (nike.PublicKey, nike.PrivateKey, error) {
+	return scheme{}.GenerateKeyPairFrom(nil)
+}
+
+func (scheme) GenerateKeyPairFrom(rand io.Reader) (
+	nike.PublicKey, nike.PrivateKey, error) {
+	var priv nikePrivateKey
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if err := GeneratePrivateKey(&priv.sk, rand); err != nil {
+		return nil, nil, err
+	}
+	GeneratePublicKey(&priv.pk.pk, &priv.sk, cryptoRand.Reader)
+	return priv.Public(), &priv, nil
+}
+
+func (scheme) DeriveKeyPair(seed []byte) (nike.PublicKey, nike.PrivateKey) {
+	if len(seed) != seedSize {
+		panic(nike.ErrSeedSize)
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	pk, sk, err := scheme{}.GenerateKeyPairFrom(&h)
+	if err != nil {
+		panic(err)
+	}
+	return pk, sk
+}
+
+func (scheme) DeriveSecret(sk nike.PrivateKey, pk nike.PublicKey) (
+	[]byte, error) {
+	priv, ok := sk.(*nikePrivateKey)
+	if !ok {
+		return nil, nike.ErrTypeMismatch
+	}
+	pub, ok := pk.(*nikePublicKey)
+	if !ok {
+		return nil, nike.ErrTypeMismatch
+	}
+
+	// DeriveSecret overwrites the public key and both keys hold working
+	// buffers, so it operates on copies to allow concurrent use.
+	var ss [SharedSecretSize]byte
+	prv, peer := priv.sk, pub.pk
+	if !DeriveSecret(&ss, &peer, &prv, cryptoRand.Reader) {
+		return nil, nike.ErrPubKey
+	}
+	return ss[:], nil
+}
+
+func (scheme) UnmarshalBinaryPublicKey(buf []byte) (nike.PublicKey, error) {
+	if len(buf) != PublicKeySize {
+		return nil, nike.ErrPubKeySize
+	}
+	var pub nikePublicKey
+	pub.pk.Import(buf)
+	if !isLess(&pub.pk.a, &p) {
+		return nil, nike.ErrPubKey
+	}
+	return &pub, nil
+}
+
+func (scheme) UnmarshalBinaryPrivateKey(buf []byte) (nike.PrivateKey, error) {
+	if len(buf) != PrivateKeySize {
+		return nil, nike.ErrPrivKeySize
+	}
+	var priv nikePrivateKey
+	priv.sk.Import(buf)
+
+	// Each byte holds two exponents, which must be in [-expMax, expMax].
+	for _, v := range priv.sk.e {
+		lo, hi := (v<<4)>>4, v>>4
+		if lo < -expMax || lo > expMax || hi < -expMax || hi > expMax {
+			return nil, nike.ErrPrivKey
+		}
+	}
+	GeneratePublicKey(&priv.pk.pk, &priv.sk, cryptoRand.Reader)
+	return &priv, nil
+}
+
+func (pk *nikePublicKey) MarshalBinary() ([]byte, error) {
+	var ret [PublicKeySize]byte
+	pk.pk.Export(ret[:])
+	return ret[:], nil
+}
+
+func (pk *nikePublicKey) Equal(other nike.PublicKey) bool {
+	oth, ok := other.(*nikePublicKey)
+	if !ok {
+		return false
+	}
+	return pk.pk.a.equal(&oth.pk.a)
+}
+
+func (sk *nikePrivateKey) MarshalBinary() ([]byte, error) {
+	var ret [PrivateKeySize]byte
+	sk.sk.Export(ret[:])
+	return ret[:], nil
+}
+
+func (sk *nikePrivateKey) Equal(other nike.PrivateKey) bool {
+	oth, ok := other.(*nikePrivateKey)
+	if !ok {
+		return false
+	}
+	var a, b [PrivateKeySize]byte
+	sk.sk.Export(a[:])
+	oth.sk.Export(b[:])
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+func (sk *nikePrivateKey) Public() nike.PublicKey {
+	pk := sk.pk
+	return &pk
+}
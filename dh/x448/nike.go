@@ -0,0 +1,133 @@
+package x448
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/circl/nike"
+)
+
+// This file contains the boilerplate code to connect X448 to the
+// generic NIKE API.
+
+// Scheme returns the generic NIKE interface for X448.
+//
+// The seed of DeriveKeyPair is used as the secret key, as in the DHKEM of
+// RFC 9180.
+func Scheme() nike.Scheme { return scheme{} }
+
+type scheme struct{}
+
+type nikePublicKey Key
+
+type nikePrivateKey struct {
+	sk Key
+	pk Key
+}
+
+func (scheme) Name() string          { return "X448" }
+func (scheme) PublicKeySize() int    { return Size }
+func (scheme) PrivateKeySize() int   { return Size }
+func (scheme) SharedSecretSize() int { return Size }
+func (scheme) SeedSize() int         { return Size }
+
+func (*nikePublicKey) Scheme() nike.Scheme  { return scheme{} }
+func (*nikePrivateKey) Scheme() nike.Scheme { return scheme{} }
+
+func newPrivateKey(sk *Key) *nikePrivateKey {
+	priv := &nikePrivateKey{sk: *sk}
+	KeyGen(&priv.pk, &priv.sk)
+	return priv
+}
+
+func (scheme) GenerateKeyPair() (nike.PublicKey, nike.PrivateKey, error) {
+	return scheme{}.GenerateKeyPairFrom(nil)
+}
+
+func (scheme) GenerateKeyPairFrom(rand io.Reader) (
+	nike.PublicKey, nike.PrivateKey, error) {
+	var seed [Size]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	pk, sk := scheme{}.DeriveKeyPair(seed[:])
+	return pk, sk, nil
+}
+
+func (scheme) DeriveKeyPair(seed []byte) (nike.PublicKey, nike.PrivateKey) {
+	if len(seed) != Size {
+		panic(nike.ErrSeedSize)
+	}
+	var sk Key
+	copy(sk[:], seed)
+	priv := newPrivateKey(&sk)
+	return priv.Public(), priv
+}
+
+func (scheme) DeriveSecret(sk nike.PrivateKey, pk nike.PublicKey) (
+	[]byte, error) {
+	priv, ok := sk.(*nikePrivateKey)
+	if !ok {
+		return nil, nike.ErrTypeMismatch
+	}
+	pub, ok := pk.(*nikePublicKey)
+	if !ok {
+		return nil, nike.ErrTypeMismatch
+	}
+	var ss Key
+	if !Shared(&ss, &priv.sk, (*Key)(pub)) {
+		return nil, nike.ErrPubKey
+	}
+	return ss[:], nil
+}
+
+func (scheme) UnmarshalBinaryPublicKey(buf []byte) (nike.PublicKey, error) {
+	if len(buf) != Size {
+		return nil, nike.ErrPubKeySize
+	}
+	var pk nikePublicKey
+	copy(pk[:], buf)
+	return &pk, nil
+}
+
+func (scheme) UnmarshalBinaryPrivateKey(buf []byte) (nike.PrivateKey, error) {
+	if len(buf) != Size {
+		return nil, nike.ErrPrivKeySize
+	}
+	var sk Key
+	copy(sk[:], buf)
+	return newPrivateKey(&sk), nil
+}
+
+func (pk *nikePublicKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, pk[:]...), nil
+}
+
+func (pk *nikePublicKey) Equal(other nike.PublicKey) bool {
+	oth, ok := other.(*nikePublicKey)
+	if !ok {
+		return false
+	}
+	return *pk == *oth
+}
+
+func (sk *nikePrivateKey) MarshalBinary() ([]byte, error) {
+	return append([]byte{}, sk.sk[:]...), nil
+}
+
+func (sk *nikePrivateKey) Equal(other nike.PrivateKey) bool {
+	oth, ok := other.(*nikePrivateKey)
+	if !ok {
+		return false
+	}
+	return subtle.ConstantTimeCompare(sk.sk[:], oth.sk[:]) == 1
+}
+
+func (sk *nikePrivateKey) Public() nike.PublicKey {
+	pk := nikePublicKey(sk.pk)
+	return &pk
+}
@@ -78,3 +78,22 @@ func (e Curve) CombinedMult(m, n *Scalar, P *Point) *Point {
 	n4.divBy4(n)
 	return e.pull(twistCurve{}.CombinedMult(m4, n4, twistCurve{}.pull(P)))
 }
+
+// MultiCombinedMult returns mG+n[0]P[0]+...+n[k-1]P[k-1], where G is the
+// generator point. This function is non-constant time.
+//
+// Panics if n and P are not of the same length.
+func (e Curve) MultiCombinedMult(m *Scalar, n []*Scalar, P []*Point) *Point {
+	if len(n) != len(P) {
+		panic("goldilocks: mismatched number of scalars and points")
+	}
+	m4 := &Scalar{}
+	m4.divBy4(m)
+	n4 := make([]Scalar, len(n))
+	Q := make([]*twistPoint, len(P))
+	for i := range P {
+		n4[i].divBy4(n[i])
+		Q[i] = twistCurve{}.pull(P[i])
+	}
+	return e.pull(twistCurve{}.multiCombinedMult(m4, n4, Q))
+}
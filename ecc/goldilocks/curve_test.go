@@ -81,6 +81,25 @@ func TestScalarMult(t *testing.T) {
 			got := e.Add(kG, lP)
 			want := e.CombinedMult(k, l, P)
 
+			if !e.IsOnCurve(got) || !e.IsOnCurve(want) || !got.IsEqual(want) {
+				test.ReportError(t, got, want, P, k, l)
+			}
+		}
+	})
+	t.Run("kG+sum(lP)", func(t *testing.T) {
+		const numPoints = 5
+		l := make([]*goldilocks.Scalar, numPoints)
+		P := make([]*goldilocks.Point, numPoints)
+		for i := 0; i < testTimes; i++ {
+			_, _ = rand.Read(k[:])
+			want := e.ScalarBaseMult(k)
+			for j := range P {
+				l[j], P[j] = &goldilocks.Scalar{}, randomPoint()
+				_, _ = rand.Read(l[j][:])
+				want = e.Add(want, e.ScalarMult(l[j], P[j]))
+			}
+			got := e.MultiCombinedMult(k, l, P)
+
 			if !e.IsOnCurve(got) || !e.IsOnCurve(want) || !got.IsEqual(want) {
 				test.ReportError(t, got, want, P, k, l)
 			}
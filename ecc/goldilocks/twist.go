@@ -131,6 +131,48 @@ func (e twistCurve) CombinedMult(m, n *Scalar, P *twistPoint) *twistPoint {
 	return Q
 }
 
+// multiCombinedMult returns mG+n[0]P[0]+...+n[k-1]P[k-1]. The points P are
+// overwritten.
+func (e twistCurve) multiCombinedMult(m *Scalar, n []Scalar, P []*twistPoint) *twistPoint {
+	nafFix := math.OmegaNAF(conv.BytesLe2BigInt(m[:]), omegaFix)
+	nafVar := make([][]int32, len(P))
+	TabQ := make([][1 << (omegaVar - 2)]preTwistPointProy, len(P))
+	l := len(nafFix)
+	for i := range P {
+		nafVar[i] = math.OmegaNAF(conv.BytesLe2BigInt(n[i][:]), omegaVar)
+		if len(nafVar[i]) > l {
+			l = len(nafVar[i])
+		}
+		P[i].oddMultiples(TabQ[i][:])
+	}
+
+	Q := e.Identity()
+	for j := l - 1; j >= 0; j-- {
+		Q.Double()
+		// Generator point
+		if j < len(nafFix) && nafFix[j] != 0 {
+			idxM := absolute(nafFix[j]) >> 1
+			R := tabVerif[idxM]
+			if nafFix[j] < 0 {
+				R.neg()
+			}
+			Q.mixAddZ1(&R)
+		}
+		// Variable input points
+		for i := range nafVar {
+			if j < len(nafVar[i]) && nafVar[i][j] != 0 {
+				idxN := absolute(nafVar[i][j]) >> 1
+				S := TabQ[i][idxN]
+				if nafVar[i][j] < 0 {
+					S.neg()
+				}
+				Q.mixAdd(&S)
+			}
+		}
+	}
+	return Q
+}
+
 // absolute returns always a positive value.
 func absolute(x int32) int32 {
 	mask := x >> 31
@@ -0,0 +1,325 @@
+package secp256k1
+
+import (
+	"encoding/binary"
+	"math/bits"
+)
+
+// limbs is a 256-bit integer as four 64-bit words, least significant first.
+type limbs = [4]uint64
+
+// montMul sets z = x*y/2^256 mod m, for x, y < m, where m0inv is -1/m
+// mod 2^64.  This is the CIOS method of Koç, Acar and Kaliski, unrolled.
+func montMul(z, x, y, m *limbs, m0inv uint64) {
+	x0, x1, x2, x3 := x[0], x[1], x[2], x[3]
+	m0, m1, m2, m3 := m[0], m[1], m[2], m[3]
+	var t0, t1, t2, t3, t4, t5, c, cc, hi, lo, u uint64
+
+	// Round 0
+	yi := y[0]
+	hi, lo = bits.Mul64(x0, yi)
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x1, yi)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x2, yi)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x3, yi)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, cc = bits.Add64(t4, c, 0)
+	t5 = cc
+	u = t0 * m0inv
+	hi, lo = bits.Mul64(u, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(u, m1)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(u, m2)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(u, m3)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Round 1
+	yi = y[1]
+	hi, lo = bits.Mul64(x0, yi)
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x1, yi)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x2, yi)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x3, yi)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, cc = bits.Add64(t4, c, 0)
+	t5 = cc
+	u = t0 * m0inv
+	hi, lo = bits.Mul64(u, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(u, m1)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(u, m2)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(u, m3)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Round 2
+	yi = y[2]
+	hi, lo = bits.Mul64(x0, yi)
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x1, yi)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x2, yi)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x3, yi)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, cc = bits.Add64(t4, c, 0)
+	t5 = cc
+	u = t0 * m0inv
+	hi, lo = bits.Mul64(u, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(u, m1)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(u, m2)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(u, m3)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Round 3
+	yi = y[3]
+	hi, lo = bits.Mul64(x0, yi)
+	lo, cc = bits.Add64(lo, t0, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(x1, yi)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(x2, yi)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	hi, lo = bits.Mul64(x3, yi)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t3, c = lo, hi
+	t4, cc = bits.Add64(t4, c, 0)
+	t5 = cc
+	u = t0 * m0inv
+	hi, lo = bits.Mul64(u, m0)
+	_, cc = bits.Add64(lo, t0, 0)
+	c = hi + cc
+	hi, lo = bits.Mul64(u, m1)
+	lo, cc = bits.Add64(lo, t1, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t0, c = lo, hi
+	hi, lo = bits.Mul64(u, m2)
+	lo, cc = bits.Add64(lo, t2, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t1, c = lo, hi
+	hi, lo = bits.Mul64(u, m3)
+	lo, cc = bits.Add64(lo, t3, 0)
+	hi += cc
+	lo, cc = bits.Add64(lo, c, 0)
+	hi += cc
+	t2, c = lo, hi
+	t3, cc = bits.Add64(t4, c, 0)
+	t4 = t5 + cc
+
+	// Final subtraction, as the result is smaller than 2m.
+	var r0, r1, r2, r3, b uint64
+	r0, b = bits.Sub64(t0, m0, 0)
+	r1, b = bits.Sub64(t1, m1, b)
+	r2, b = bits.Sub64(t2, m2, b)
+	r3, b = bits.Sub64(t3, m3, b)
+	_, b = bits.Sub64(t4, 0, b)
+	mask := -b
+	z[0] = r0 ^ ((r0 ^ t0) & mask)
+	z[1] = r1 ^ ((r1 ^ t1) & mask)
+	z[2] = r2 ^ ((r2 ^ t2) & mask)
+	z[3] = r3 ^ ((r3 ^ t3) & mask)
+}
+
+// reduceOnce sets z = hi*2^256 + x mod m, for hi*2^256 + x < 2m.
+func reduceOnce(z, x *limbs, hi uint64, m *limbs) {
+	var r limbs
+	var b uint64
+	r[0], b = bits.Sub64(x[0], m[0], 0)
+	r[1], b = bits.Sub64(x[1], m[1], b)
+	r[2], b = bits.Sub64(x[2], m[2], b)
+	r[3], b = bits.Sub64(x[3], m[3], b)
+	_, b = bits.Sub64(hi, 0, b)
+	cmov(&r, x, b)
+	*z = r
+}
+
+// addMod sets z = x+y mod m, for x, y < m.
+func addMod(z, x, y, m *limbs) {
+	var t limbs
+	var c uint64
+	t[0], c = bits.Add64(x[0], y[0], 0)
+	t[1], c = bits.Add64(x[1], y[1], c)
+	t[2], c = bits.Add64(x[2], y[2], c)
+	t[3], c = bits.Add64(x[3], y[3], c)
+	reduceOnce(z, &t, c, m)
+}
+
+// subMod sets z = x-y mod m, for x, y < m.
+func subMod(z, x, y, m *limbs) {
+	var b, c uint64
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+	mask := -b
+	z[0], c = bits.Add64(z[0], m[0]&mask, 0)
+	z[1], c = bits.Add64(z[1], m[1]&mask, c)
+	z[2], c = bits.Add64(z[2], m[2]&mask, c)
+	z[3], _ = bits.Add64(z[3], m[3]&mask, c)
+}
+
+// cmov sets z = x if b == 1, and leaves z unchanged if b == 0.
+func cmov(z, x *limbs, b uint64) {
+	mask := -b
+	for i := range z {
+		z[i] ^= (z[i] ^ x[i]) & mask
+	}
+}
+
+// isZero returns 1 if x is zero, and 0 otherwise.
+func isZero(x *limbs) uint64 {
+	w := x[0] | x[1] | x[2] | x[3]
+	return 1 ^ ((w | -w) >> 63)
+}
+
+// isLess returns 1 if x < y, and 0 otherwise.
+func isLess(x, y *limbs) uint64 {
+	var b uint64
+	_, b = bits.Sub64(x[0], y[0], 0)
+	_, b = bits.Sub64(x[1], y[1], b)
+	_, b = bits.Sub64(x[2], y[2], b)
+	_, b = bits.Sub64(x[3], y[3], b)
+	return b
+}
+
+// montPow sets z = x^e in Montgomery form, where one is 1 in Montgomery
+// form.  It runs in time independent of x, but not of the exponent e.
+func montPow(z, x, e, one, m *limbs, m0inv uint64) {
+	t := *one
+	for i := 255; i >= 0; i-- {
+		montMul(&t, &t, &t, m, m0inv)
+		if (e[i/64]>>uint(i%64))&1 == 1 {
+			montMul(&t, &t, x, m, m0inv)
+		}
+	}
+	*z = t
+}
+
+// setBytes sets z to the 32-byte big-endian integer in b.
+func setBytes(z *limbs, b []byte) {
+	z[3] = binary.BigEndian.Uint64(b[0:8])
+	z[2] = binary.BigEndian.Uint64(b[8:16])
+	z[1] = binary.BigEndian.Uint64(b[16:24])
+	z[0] = binary.BigEndian.Uint64(b[24:32])
+}
+
+// putBytes writes x as a 32-byte big-endian integer into b.
+func putBytes(b []byte, x *limbs) {
+	binary.BigEndian.PutUint64(b[0:8], x[3])
+	binary.BigEndian.PutUint64(b[8:16], x[2])
+	binary.BigEndian.PutUint64(b[16:24], x[1])
+	binary.BigEndian.PutUint64(b[24:32], x[0])
+}
@@ -0,0 +1,20 @@
+// Package secp256k1 provides the prime-order elliptic curve group
+// secp256k1 of SEC 2, as used by Bitcoin and Ethereum.
+//
+// The curve is y^2 = x^3 + 7 over the prime field of order
+// p = 2^256 - 2^32 - 977, and its group of points has prime order n.
+//
+// Field elements are in Montgomery form and points use homogeneous
+// projective coordinates with the complete addition formulas of
+// Renes-Costello-Batina, so that ScalarMult and ScalarBaseMult run in
+// constant time.  CombinedMult is meant for verification and is not
+// constant time.
+//
+// References:
+//   - SEC 2: Recommended Elliptic Curve Domain Parameters, version 2.0.
+//     https://www.secg.org/sec2-v2.pdf
+//   - SEC 1: Elliptic Curve Cryptography, version 2.0.
+//     https://www.secg.org/sec1-v2.pdf
+//   - Renes, Costello, Batina. Complete addition formulas for prime order
+//     elliptic curves. https://eprint.iacr.org/2015/1060
+package secp256k1
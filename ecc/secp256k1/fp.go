@@ -0,0 +1,75 @@
+package secp256k1
+
+// fp is an element of the base field in Montgomery form.
+type fp limbs
+
+var (
+	// p = 2^256 - 2^32 - 977 is the order of the base field.
+	p = limbs{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// pInv = -1/p mod 2^64.
+	pInv = uint64(0xd838091dd2253531)
+	// pR2 = 2^512 mod p.
+	pR2 = limbs{0x000007a2000e90a1, 0x0000000000000001, 0x0000000000000000, 0x0000000000000000}
+	// pMinus2 is the exponent used for inversion.
+	pMinus2 = limbs{0xfffffffefffffc2d, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+	// pPlus1Div4 is the exponent used for square roots, since p = 3 mod 4.
+	pPlus1Div4 = limbs{0xffffffffbfffff0c, 0xffffffffffffffff, 0xffffffffffffffff, 0x3fffffffffffffff}
+
+	// fpOne is 1 in Montgomery form, that is, 2^256 mod p.
+	fpOne = fp{0x00000001000003d1, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000}
+	// fpB is b = 7 in Montgomery form.
+	fpB = fp{0x0000000700001ab7, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000}
+	// fpB3 is 3*b = 21 in Montgomery form.
+	fpB3 = fp{0x0000001500005025, 0x0000000000000000, 0x0000000000000000, 0x0000000000000000}
+)
+
+func fpMul(z, x, y *fp) { montMul((*limbs)(z), (*limbs)(x), (*limbs)(y), &p, pInv) }
+func fpSqr(z, x *fp)    { fpMul(z, x, x) }
+func fpAdd(z, x, y *fp) { addMod((*limbs)(z), (*limbs)(x), (*limbs)(y), &p) }
+func fpSub(z, x, y *fp) { subMod((*limbs)(z), (*limbs)(x), (*limbs)(y), &p) }
+func fpNeg(z, x *fp)    { fpSub(z, &fp{}, x) }
+
+// fpCmov sets z = x if b == 1, and leaves z unchanged if b == 0.
+func fpCmov(z, x *fp, b uint64) { cmov((*limbs)(z), (*limbs)(x), b) }
+
+// fpIsZero returns 1 if x is zero, and 0 otherwise.
+func fpIsZero(x *fp) uint64 { return isZero((*limbs)(x)) }
+
+// fpInv sets z = 1/x, and z = 0 if x = 0.
+func fpInv(z, x *fp) {
+	montPow((*limbs)(z), (*limbs)(x), &pMinus2, (*limbs)(&fpOne), &p, pInv)
+}
+
+// fpSqrt sets z to a square root of x and returns true if x is a square.
+// Otherwise, it returns false and z is unspecified.
+func fpSqrt(z, x *fp) bool {
+	var r, r2 fp
+	montPow((*limbs)(&r), (*limbs)(x), &pPlus1Div4, (*limbs)(&fpOne), &p, pInv)
+	fpSqr(&r2, &r)
+	*z = r
+	return r2 == *x
+}
+
+// fpSetBytes sets z to the 32-byte big-endian value in b, and returns
+// false if it is not smaller than p.
+func fpSetBytes(z *fp, b []byte) bool {
+	var t limbs
+	setBytes(&t, b)
+	ok := isLess(&t, &p)
+	montMul((*limbs)(z), &t, &pR2, &p, pInv)
+	return ok == 1
+}
+
+// fpBytes writes x as a 32-byte big-endian value into b.
+func fpBytes(b []byte, x *fp) {
+	var t limbs
+	montMul(&t, (*limbs)(x), &limbs{1}, &p, pInv)
+	putBytes(b, &t)
+}
+
+// fpIsOdd returns 1 if x, out of Montgomery form, is odd, and 0 otherwise.
+func fpIsOdd(x *fp) uint64 {
+	var t limbs
+	montMul(&t, (*limbs)(x), &limbs{1}, &p, pInv)
+	return t[0] & 1
+}
@@ -0,0 +1,370 @@
+package secp256k1
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/cloudflare/circl/math"
+)
+
+// Sizes of the SEC 1 encodings of points.
+const (
+	CompressedSize   = 33
+	UncompressedSize = 65
+)
+
+// ErrInvalidPoint is returned when decoding an invalid encoding of a point.
+var ErrInvalidPoint = errors.New("secp256k1: invalid point encoding")
+
+// Point is a point of the curve in homogeneous projective coordinates
+// (X:Y:Z), which represent the affine point (X/Z, Y/Z).  The zero value
+// is not a valid point; use Identity instead.
+type Point struct{ x, y, z fp }
+
+var (
+	fpGx = fp{0xd7362e5a487e2097, 0x231e295329bc66db, 0x979f48c033fd129c, 0x9981e643e9089f48}
+	fpGy = fp{0xb15ea6d2d3dbabe2, 0x8dfc5d5d1f1dc64d, 0x70b6b59aac19c136, 0xcf3f851fd4a582d6}
+)
+
+// Identity returns the point at infinity.
+func Identity() *Point { return &Point{y: fpOne} }
+
+// Generator returns the generator of the group.
+func Generator() *Point { return &Point{x: fpGx, y: fpGy, z: fpOne} }
+
+func (P Point) String() string {
+	if P.IsIdentity() {
+		return "(infinity)"
+	}
+	b, _ := P.MarshalBinary()
+	return fmt.Sprintf("%x", b)
+}
+
+// Set sets P = Q.
+func (P *Point) Set(Q *Point) { *P = *Q }
+
+// Neg sets P = -Q.
+func (P *Point) Neg(Q *Point) { P.x, P.z = Q.x, Q.z; fpNeg(&P.y, &Q.y) }
+
+// Add sets P = Q + R.  It is complete, that is, it works for any Q and R.
+func (P *Point) Add(Q, R *Point) {
+	// Algorithm 7 of Renes-Costello-Batina for a = 0.
+	var t0, t1, t2, t3, t4, x3, y3, z3 fp
+	fpMul(&t0, &Q.x, &R.x)
+	fpMul(&t1, &Q.y, &R.y)
+	fpMul(&t2, &Q.z, &R.z)
+	fpAdd(&t3, &Q.x, &Q.y)
+	fpAdd(&t4, &R.x, &R.y)
+	fpMul(&t3, &t3, &t4)
+	fpAdd(&t4, &t0, &t1)
+	fpSub(&t3, &t3, &t4)
+	fpAdd(&t4, &Q.y, &Q.z)
+	fpAdd(&x3, &R.y, &R.z)
+	fpMul(&t4, &t4, &x3)
+	fpAdd(&x3, &t1, &t2)
+	fpSub(&t4, &t4, &x3)
+	fpAdd(&x3, &Q.x, &Q.z)
+	fpAdd(&y3, &R.x, &R.z)
+	fpMul(&x3, &x3, &y3)
+	fpAdd(&y3, &t0, &t2)
+	fpSub(&y3, &x3, &y3)
+	fpAdd(&x3, &t0, &t0)
+	fpAdd(&t0, &x3, &t0)
+	fpMul(&t2, &fpB3, &t2)
+	fpAdd(&z3, &t1, &t2)
+	fpSub(&t1, &t1, &t2)
+	fpMul(&y3, &fpB3, &y3)
+	fpMul(&x3, &t4, &y3)
+	fpMul(&t2, &t3, &t1)
+	fpSub(&x3, &t2, &x3)
+	fpMul(&y3, &y3, &t0)
+	fpMul(&t1, &t1, &z3)
+	fpAdd(&y3, &t1, &y3)
+	fpMul(&t0, &t0, &t3)
+	fpMul(&z3, &z3, &t4)
+	fpAdd(&z3, &z3, &t0)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// Double sets P = 2Q.
+func (P *Point) Double(Q *Point) {
+	// Algorithm 9 of Renes-Costello-Batina for a = 0.
+	var t0, t1, t2, x3, y3, z3 fp
+	fpSqr(&t0, &Q.y)
+	fpAdd(&z3, &t0, &t0)
+	fpAdd(&z3, &z3, &z3)
+	fpAdd(&z3, &z3, &z3)
+	fpMul(&t1, &Q.y, &Q.z)
+	fpSqr(&t2, &Q.z)
+	fpMul(&t2, &fpB3, &t2)
+	fpMul(&x3, &t2, &z3)
+	fpAdd(&y3, &t0, &t2)
+	fpMul(&z3, &t1, &z3)
+	fpAdd(&t1, &t2, &t2)
+	fpAdd(&t2, &t1, &t2)
+	fpSub(&t0, &t0, &t2)
+	fpMul(&y3, &t0, &y3)
+	fpAdd(&y3, &x3, &y3)
+	fpMul(&t1, &Q.x, &Q.y)
+	fpMul(&x3, &t0, &t1)
+	fpAdd(&x3, &x3, &x3)
+	P.x, P.y, P.z = x3, y3, z3
+}
+
+// cmov sets P = Q if b == 1, and leaves P unchanged if b == 0.
+func (P *Point) cmov(Q *Point, b uint64) {
+	fpCmov(&P.x, &Q.x, b)
+	fpCmov(&P.y, &Q.y, b)
+	fpCmov(&P.z, &Q.z, b)
+}
+
+// ScalarMult sets P = kQ.  It runs in constant time.
+func (P *Point) ScalarMult(k *Scalar, Q *Point) {
+	// Fixed window of 4 bits, with table lookups in constant time.
+	var tab [16]Point
+	tab[0] = *Identity()
+	tab[1] = *Q
+	for i := 2; i < 16; i++ {
+		tab[i].Add(&tab[i-1], Q)
+	}
+
+	R := Identity()
+	var T Point
+	for i := 63; i >= 0; i-- {
+		R.Double(R)
+		R.Double(R)
+		R.Double(R)
+		R.Double(R)
+		w := (k.k[i/16] >> (4 * uint(i%16))) & 0xF
+		T = tab[0]
+		for j := 1; j < 16; j++ {
+			T.cmov(&tab[j], isZero(&limbs{w ^ uint64(j)}))
+		}
+		R.Add(R, &T)
+	}
+	*P = *R
+}
+
+// baseTable holds the multiples j*16^i*G of the generator, for
+// 0 <= i < 64 and 0 <= j < 16.
+var (
+	baseTable     [64][16]Point
+	baseTableOnce sync.Once
+)
+
+func initBaseTable() {
+	G := Generator()
+	for i := range baseTable {
+		baseTable[i][0] = *Identity()
+		for j := 1; j < 16; j++ {
+			baseTable[i][j].Add(&baseTable[i][j-1], G)
+		}
+		G.Add(&baseTable[i][15], G)
+	}
+}
+
+// ScalarBaseMult sets P = kG, where G is the generator.  It runs in
+// constant time.
+func (P *Point) ScalarBaseMult(k *Scalar) {
+	baseTableOnce.Do(initBaseTable)
+	R := Identity()
+	var T Point
+	for i := 0; i < 64; i++ {
+		w := (k.k[i/16] >> (4 * uint(i%16))) & 0xF
+		T = baseTable[i][0]
+		for j := 1; j < 16; j++ {
+			T.cmov(&baseTable[i][j], isZero(&limbs{w ^ uint64(j)}))
+		}
+		R.Add(R, &T)
+	}
+	*P = *R
+}
+
+// oddMultiples returns the points Q, 3Q, ..., (2^(w-1)-1)Q.
+func oddMultiples(Q *Point, w uint) []Point {
+	tab := make([]Point, 1<<(w-2))
+	var Q2 Point
+	Q2.Double(Q)
+	tab[0] = *Q
+	for i := 1; i < len(tab); i++ {
+		tab[i].Add(&tab[i-1], &Q2)
+	}
+	return tab
+}
+
+// nafOf returns the width-w NAF of k.
+func nafOf(k *Scalar, w uint) []int32 {
+	var b big.Int
+	b.SetBytes(k.Bytes())
+	return math.OmegaNAF(&b, w)
+}
+
+// MultiScalarMult sets P = sum k[i]Q[i].  It does not run in constant
+// time, and must only be used with public inputs.
+func (P *Point) MultiScalarMult(k []*Scalar, Q []*Point) {
+	if len(k) != len(Q) {
+		panic("secp256k1: mismatched lengths")
+	}
+	const w = 5
+	nafs := make([][]int32, len(k))
+	tabs := make([][]Point, len(k))
+	l := 0
+	for i := range k {
+		nafs[i] = nafOf(k[i], w)
+		if len(nafs[i]) > l {
+			l = len(nafs[i])
+		}
+		tabs[i] = oddMultiples(Q[i], w)
+	}
+
+	R := Identity()
+	var T Point
+	for j := l - 1; j >= 0; j-- {
+		R.Double(R)
+		for i := range nafs {
+			if j >= len(nafs[i]) || nafs[i][j] == 0 {
+				continue
+			}
+			d := nafs[i][j]
+			if d > 0 {
+				R.Add(R, &tabs[i][d>>1])
+			} else {
+				T.Neg(&tabs[i][(-d)>>1])
+				R.Add(R, &T)
+			}
+		}
+	}
+	*P = *R
+}
+
+// CombinedMult sets P = mG + nQ, where G is the generator.  It does not
+// run in constant time, and must only be used with public inputs, as in
+// signature verification.
+func (P *Point) CombinedMult(m, n *Scalar, Q *Point) {
+	P.MultiScalarMult([]*Scalar{m, n}, []*Point{Generator(), Q})
+}
+
+// IsIdentity returns whether P is the point at infinity.
+func (P *Point) IsIdentity() bool { return fpIsZero(&P.z) == 1 }
+
+// IsEqual returns whether P and Q are the same point.
+func (P *Point) IsEqual(Q *Point) bool {
+	var l, r fp
+	fpMul(&l, &P.x, &Q.z)
+	fpMul(&r, &Q.x, &P.z)
+	fpSub(&l, &l, &r)
+	b := fpIsZero(&l)
+	fpMul(&l, &P.y, &Q.z)
+	fpMul(&r, &Q.y, &P.z)
+	fpSub(&l, &l, &r)
+	return b&fpIsZero(&l) == 1
+}
+
+// IsOnCurve returns whether P satisfies the curve equation
+// Y^2 Z = X^3 + 7 Z^3.
+func (P *Point) IsOnCurve() bool {
+	var l, r, t fp
+	fpSqr(&l, &P.y)
+	fpMul(&l, &l, &P.z)
+	fpSqr(&r, &P.x)
+	fpMul(&r, &r, &P.x)
+	fpSqr(&t, &P.z)
+	fpMul(&t, &t, &P.z)
+	fpMul(&t, &t, &fpB)
+	fpAdd(&r, &r, &t)
+	fpSub(&l, &l, &r)
+	return fpIsZero(&l) == 1
+}
+
+// affine returns the affine coordinates of P, which must not be the
+// point at infinity.
+func (P *Point) affine() (x, y fp) {
+	var zInv fp
+	fpInv(&zInv, &P.z)
+	fpMul(&x, &P.x, &zInv)
+	fpMul(&y, &P.y, &zInv)
+	return
+}
+
+// XBytes returns the affine x-coordinate of P as a 32-byte big-endian
+// value.  It returns an error if P is the point at infinity.
+func (P *Point) XBytes() ([]byte, error) {
+	if P.IsIdentity() {
+		return nil, ErrInvalidPoint
+	}
+	x, _ := P.affine()
+	var b [32]byte
+	fpBytes(b[:], &x)
+	return b[:], nil
+}
+
+// IsYOdd returns whether the affine y-coordinate of P is odd.  P must not
+// be the point at infinity.
+func (P *Point) IsYOdd() bool {
+	_, y := P.affine()
+	return fpIsOdd(&y) == 1
+}
+
+// MarshalBinary returns the uncompressed SEC 1 encoding of P, which must
+// not be the point at infinity.
+func (P *Point) MarshalBinary() ([]byte, error) {
+	if P.IsIdentity() {
+		return nil, ErrInvalidPoint
+	}
+	x, y := P.affine()
+	var b [UncompressedSize]byte
+	b[0] = 0x04
+	fpBytes(b[1:33], &x)
+	fpBytes(b[33:], &y)
+	return b[:], nil
+}
+
+// MarshalBinaryCompress returns the compressed SEC 1 encoding of P, which
+// must not be the point at infinity.
+func (P *Point) MarshalBinaryCompress() ([]byte, error) {
+	if P.IsIdentity() {
+		return nil, ErrInvalidPoint
+	}
+	x, y := P.affine()
+	var b [CompressedSize]byte
+	b[0] = 0x02 | byte(fpIsOdd(&y))
+	fpBytes(b[1:], &x)
+	return b[:], nil
+}
+
+// UnmarshalBinary sets P to the point encoded in the compressed or
+// uncompressed SEC 1 format.  The point at infinity is rejected.
+func (P *Point) UnmarshalBinary(b []byte) error {
+	var x, y fp
+	switch {
+	case len(b) == CompressedSize && (b[0] == 0x02 || b[0] == 0x03):
+		if !fpSetBytes(&x, b[1:]) {
+			return ErrInvalidPoint
+		}
+		// y^2 = x^3 + 7
+		var y2 fp
+		fpSqr(&y2, &x)
+		fpMul(&y2, &y2, &x)
+		fpAdd(&y2, &y2, &fpB)
+		if !fpSqrt(&y, &y2) {
+			return ErrInvalidPoint
+		}
+		var negY fp
+		fpNeg(&negY, &y)
+		fpCmov(&y, &negY, fpIsOdd(&y)^uint64(b[0]&1))
+	case len(b) == UncompressedSize && b[0] == 0x04:
+		if !fpSetBytes(&x, b[1:33]) || !fpSetBytes(&y, b[33:]) {
+			return ErrInvalidPoint
+		}
+	default:
+		return ErrInvalidPoint
+	}
+	Q := Point{x: x, y: y, z: fpOne}
+	if !Q.IsOnCurve() {
+		return ErrInvalidPoint
+	}
+	*P = Q
+	return nil
+}
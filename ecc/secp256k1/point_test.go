@@ -0,0 +1,245 @@
+package secp256k1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/secp256k1"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var (
+	prime, _ = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	gx, _    = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	gy, _    = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+)
+
+// affinePoint is a reference implementation of the group law with math/big.
+type affinePoint struct{ x, y *big.Int } // nil x is the point at infinity
+
+func (a affinePoint) add(b affinePoint) affinePoint {
+	if a.x == nil {
+		return b
+	}
+	if b.x == nil {
+		return a
+	}
+	var l big.Int
+	if a.x.Cmp(b.x) == 0 {
+		if new(big.Int).Add(a.y, b.y).Mod(new(big.Int).Add(a.y, b.y), prime).Sign() == 0 {
+			return affinePoint{}
+		}
+		// l = 3x^2 / 2y
+		l.Mul(a.x, a.x).Mul(&l, big.NewInt(3))
+		l.Mul(&l, new(big.Int).ModInverse(new(big.Int).Lsh(a.y, 1), prime))
+	} else {
+		l.Sub(b.y, a.y)
+		l.Mul(&l, new(big.Int).ModInverse(new(big.Int).Sub(b.x, a.x).Mod(new(big.Int).Sub(b.x, a.x), prime), prime))
+	}
+	l.Mod(&l, prime)
+	x := new(big.Int).Mul(&l, &l)
+	x.Sub(x, a.x).Sub(x, b.x).Mod(x, prime)
+	y := new(big.Int).Sub(a.x, x)
+	y.Mul(y, &l).Sub(y, a.y).Mod(y, prime)
+	return affinePoint{x, y}
+}
+
+func (a affinePoint) mul(k *big.Int) affinePoint {
+	r := affinePoint{}
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		r = r.add(r)
+		if k.Bit(i) == 1 {
+			r = r.add(a)
+		}
+	}
+	return r
+}
+
+func (a affinePoint) bytes() []byte {
+	b := make([]byte, secp256k1.UncompressedSize)
+	b[0] = 4
+	a.x.FillBytes(b[1:33])
+	a.y.FillBytes(b[33:])
+	return b
+}
+
+func marshal(t testing.TB, P *secp256k1.Point) []byte {
+	b, err := P.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	return b
+}
+
+func TestScalarBaseMult(t *testing.T) {
+	const testTimes = 1 << 7
+	g := affinePoint{gx, gy}
+	for i := 0; i < testTimes; i++ {
+		k, bk := randomScalar(t)
+		var P secp256k1.Point
+		P.ScalarBaseMult(k)
+		got := marshal(t, &P)
+		want := g.mul(bk).bytes()
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, bk)
+		}
+
+		var Q secp256k1.Point
+		Q.ScalarMult(k, secp256k1.Generator())
+		test.CheckOk(P.IsEqual(&Q), "ScalarMult and ScalarBaseMult differ", t)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	var k secp256k1.Scalar
+	k.SetUint64(1)
+	k.Neg(&k)
+	var P secp256k1.Point
+	P.ScalarBaseMult(&k)
+	P.Add(&P, secp256k1.Generator())
+	test.CheckOk(P.IsIdentity(), "(n-1)G + G must be the identity", t)
+
+	var Z secp256k1.Scalar
+	P.ScalarMult(&Z, secp256k1.Generator())
+	test.CheckOk(P.IsIdentity(), "0G must be the identity", t)
+	_, err := P.MarshalBinary()
+	test.CheckIsErr(t, err, "identity must not be encodable")
+}
+
+func TestAddComplete(t *testing.T) {
+	k, _ := randomScalar(t)
+	var P, Q, R secp256k1.Point
+	P.ScalarBaseMult(k)
+
+	Q.Add(&P, &P)
+	R.Double(&P)
+	test.CheckOk(Q.IsEqual(&R), "P+P must be 2P", t)
+
+	Q.Add(&P, secp256k1.Identity())
+	test.CheckOk(Q.IsEqual(&P), "P+0 must be P", t)
+
+	Q.Neg(&P)
+	Q.Add(&P, &Q)
+	test.CheckOk(Q.IsIdentity(), "P-P must be the identity", t)
+
+	Q.Double(secp256k1.Identity())
+	test.CheckOk(Q.IsIdentity(), "2*0 must be the identity", t)
+}
+
+func TestMultiScalarMult(t *testing.T) {
+	const testTimes = 1 << 5
+	for i := 0; i < testTimes; i++ {
+		const size = 7
+		ks := make([]*secp256k1.Scalar, size)
+		Ps := make([]*secp256k1.Point, size)
+		want := secp256k1.Identity()
+		for j := range ks {
+			ks[j], _ = randomScalar(t)
+			r, _ := randomScalar(t)
+			Ps[j] = new(secp256k1.Point)
+			Ps[j].ScalarBaseMult(r)
+			var T secp256k1.Point
+			T.ScalarMult(ks[j], Ps[j])
+			want.Add(want, &T)
+		}
+		var got secp256k1.Point
+		got.MultiScalarMult(ks, Ps)
+		test.CheckOk(got.IsEqual(want), "MultiScalarMult failed", t)
+
+		var m secp256k1.Scalar
+		var G, Q secp256k1.Point
+		m.SetUint64(0)
+		G.ScalarMult(ks[1], Ps[1])
+		Q.CombinedMult(&m, ks[1], Ps[1])
+		test.CheckOk(Q.IsEqual(&G), "CombinedMult failed", t)
+		G.ScalarBaseMult(ks[0])
+		G.Add(&G, &Q)
+		Q.CombinedMult(ks[0], ks[1], Ps[1])
+		test.CheckOk(Q.IsEqual(&G), "CombinedMult failed", t)
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	const testTimes = 1 << 7
+	for i := 0; i < testTimes; i++ {
+		k, _ := randomScalar(t)
+		var P, Q secp256k1.Point
+		P.ScalarBaseMult(k)
+
+		enc := marshal(t, &P)
+		test.CheckNoErr(t, Q.UnmarshalBinary(enc), "unmarshal failed")
+		test.CheckOk(P.IsEqual(&Q), "uncompressed roundtrip failed", t)
+
+		comp, err := P.MarshalBinaryCompress()
+		test.CheckNoErr(t, err, "marshal failed")
+		test.CheckOk(comp[0] == 2 || comp[0] == 3, "wrong prefix", t)
+		test.CheckOk(P.IsYOdd() == (comp[0] == 3), "wrong parity", t)
+		test.CheckOk(bytes.Equal(comp[1:], enc[1:33]), "wrong x-coordinate", t)
+		x, err := P.XBytes()
+		test.CheckNoErr(t, err, "XBytes failed")
+		test.CheckOk(bytes.Equal(x, enc[1:33]), "wrong x-coordinate", t)
+		test.CheckNoErr(t, Q.UnmarshalBinary(comp), "unmarshal failed")
+		test.CheckOk(P.IsEqual(&Q), "compressed roundtrip failed", t)
+	}
+}
+
+func TestEncodingInvalid(t *testing.T) {
+	g := affinePoint{gx, gy}.bytes()
+	var P secp256k1.Point
+
+	for _, b := range [][]byte{
+		nil,
+		g[:64],
+		append([]byte{2}, g[1:]...),
+		append([]byte{4}, g[1:33]...),
+	} {
+		test.CheckIsErr(t, P.UnmarshalBinary(b), "wrong length or prefix must fail")
+	}
+
+	// Not on the curve.
+	bad := append([]byte{}, g...)
+	bad[64] ^= 1
+	test.CheckIsErr(t, P.UnmarshalBinary(bad), "point not on curve must fail")
+
+	// x = p is not canonical.
+	bad = append([]byte{2}, prime.Bytes()...)
+	test.CheckIsErr(t, P.UnmarshalBinary(bad), "non-canonical x must fail")
+
+	// x = 5 has no point since 5^3 + 7 = 132 is not a square modulo p.
+	bad = make([]byte, secp256k1.CompressedSize)
+	bad[0], bad[32] = 2, 5
+	test.CheckIsErr(t, P.UnmarshalBinary(bad), "x without point must fail")
+}
+
+func TestGenerator(t *testing.T) {
+	comp, err := secp256k1.Generator().MarshalBinaryCompress()
+	test.CheckNoErr(t, err, "marshal failed")
+	want, _ := hex.DecodeString("0279be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798")
+	if !bytes.Equal(comp, want) {
+		test.ReportError(t, comp, want)
+	}
+	test.CheckOk(secp256k1.Generator().IsOnCurve(), "generator not on curve", t)
+}
+
+func BenchmarkPoint(b *testing.B) {
+	k, _ := randomScalar(b)
+	m, _ := randomScalar(b)
+	var P secp256k1.Point
+	P.ScalarBaseMult(k)
+
+	b.Run("ScalarBaseMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarBaseMult(k)
+		}
+	})
+	b.Run("ScalarMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.ScalarMult(k, &P)
+		}
+	})
+	b.Run("CombinedMult", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			P.CombinedMult(k, m, &P)
+		}
+	})
+}
@@ -0,0 +1,112 @@
+package secp256k1
+
+// ScalarSize is the size in bytes of an encoded Scalar.
+const ScalarSize = 32
+
+// Scalar is an integer modulo the order n of the group.  The zero value
+// is the scalar 0.
+type Scalar struct{ k limbs }
+
+var (
+	// n is the order of the group.
+	n = limbs{0xbfd25e8cd0364141, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+	// nInv = -1/n mod 2^64.
+	nInv = uint64(0x4b0dff665588b13f)
+	// nR = 2^256 mod n.
+	nR = limbs{0x402da1732fc9bebf, 0x4551231950b75fc4, 0x0000000000000001, 0x0000000000000000}
+	// nR2 = 2^512 mod n.
+	nR2 = limbs{0x896cf21467d7d140, 0x741496c20e7cf878, 0xe697f5e45bcd07c6, 0x9d671cd581c69bc5}
+	// nMinus2 is the exponent used for inversion.
+	nMinus2 = limbs{0xbfd25e8cd036413f, 0xbaaedce6af48a03b, 0xfffffffffffffffe, 0xffffffffffffffff}
+	// halfN = (n-1)/2.
+	halfN = limbs{0xdfe92f46681b20a0, 0x5d576e7357a4501d, 0xffffffffffffffff, 0x7fffffffffffffff}
+)
+
+// Order returns the order n of the group as a 32-byte big-endian value.
+func Order() []byte {
+	var b [ScalarSize]byte
+	putBytes(b[:], &n)
+	return b[:]
+}
+
+// SetBytes sets k to the 32-byte big-endian integer in b reduced modulo n,
+// and returns whether b was smaller than n.  It panics if b is not of
+// length ScalarSize.
+func (k *Scalar) SetBytes(b []byte) bool {
+	if len(b) != ScalarSize {
+		panic("secp256k1: scalar must be of ScalarSize bytes")
+	}
+	var t limbs
+	setBytes(&t, b)
+	ok := isLess(&t, &n)
+	// Since n > 2^255, a single subtraction reduces t.
+	reduceOnce(&k.k, &t, 0, &n)
+	return ok == 1
+}
+
+// Bytes returns k as a 32-byte big-endian value.
+func (k *Scalar) Bytes() []byte {
+	var b [ScalarSize]byte
+	putBytes(b[:], &k.k)
+	return b[:]
+}
+
+// SetUint64 sets k = x.
+func (k *Scalar) SetUint64(x uint64) { k.k = limbs{x} }
+
+// Set sets k = x.
+func (k *Scalar) Set(x *Scalar) { *k = *x }
+
+// Add sets k = x + y.
+func (k *Scalar) Add(x, y *Scalar) { addMod(&k.k, &x.k, &y.k, &n) }
+
+// Sub sets k = x - y.
+func (k *Scalar) Sub(x, y *Scalar) { subMod(&k.k, &x.k, &y.k, &n) }
+
+// Neg sets k = -x.
+func (k *Scalar) Neg(x *Scalar) { subMod(&k.k, &limbs{}, &x.k, &n) }
+
+// Mul sets k = x * y.
+func (k *Scalar) Mul(x, y *Scalar) {
+	var t limbs
+	montMul(&t, &x.k, &y.k, &n, nInv)
+	montMul(&k.k, &t, &nR2, &n, nInv)
+}
+
+// Inv sets k = 1/x, and k = 0 if x = 0.  It runs in constant time.
+func (k *Scalar) Inv(x *Scalar) {
+	var t limbs
+	montMul(&t, &x.k, &nR2, &n, nInv)
+	montPow(&t, &t, &nMinus2, &nR, &n, nInv)
+	montMul(&k.k, &t, &limbs{1}, &n, nInv)
+}
+
+// CondNeg sets k = -k if b is true.  It runs in constant time.
+func (k *Scalar) CondNeg(b bool) {
+	var t Scalar
+	t.Neg(k)
+	cmov(&k.k, &t.k, boolToUint64(b))
+}
+
+// IsZero returns whether k is zero.
+func (k *Scalar) IsZero() bool { return isZero(&k.k) == 1 }
+
+// IsEqual returns whether k and x are equal.
+func (k *Scalar) IsEqual(x *Scalar) bool {
+	var t limbs
+	subMod(&t, &k.k, &x.k, &n)
+	return isZero(&t) == 1
+}
+
+// IsHigh returns whether k is greater than (n-1)/2.
+func (k *Scalar) IsHigh() bool { return isLess(&halfN, &k.k) == 1 }
+
+// bit returns the i-th bit of k.
+func (k *Scalar) bit(i uint) uint64 { return (k.k[i/64] >> (i % 64)) & 1 }
+
+func boolToUint64(b bool) uint64 {
+	if b {
+		return 1
+	}
+	return 0
+}
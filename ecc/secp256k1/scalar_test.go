@@ -0,0 +1,93 @@
+package secp256k1_test
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/ecc/secp256k1"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+var order = new(big.Int).SetBytes(secp256k1.Order())
+
+func randomScalar(t testing.TB) (*secp256k1.Scalar, *big.Int) {
+	var b [secp256k1.ScalarSize]byte
+	_, err := rand.Read(b[:])
+	test.CheckNoErr(t, err, "rand failed")
+	var k secp256k1.Scalar
+	k.SetBytes(b[:])
+	return &k, new(big.Int).Mod(new(big.Int).SetBytes(b[:]), order)
+}
+
+func scalarToBig(k *secp256k1.Scalar) *big.Int { return new(big.Int).SetBytes(k.Bytes()) }
+
+func TestScalarArith(t *testing.T) {
+	const testTimes = 1 << 10
+	for i := 0; i < testTimes; i++ {
+		x, bx := randomScalar(t)
+		y, by := randomScalar(t)
+		var z secp256k1.Scalar
+		want := new(big.Int)
+
+		z.Add(x, y)
+		want.Add(bx, by).Mod(want, order)
+		if got := scalarToBig(&z); got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, bx, by)
+		}
+
+		z.Sub(x, y)
+		want.Sub(bx, by).Mod(want, order)
+		if got := scalarToBig(&z); got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, bx, by)
+		}
+
+		z.Mul(x, y)
+		want.Mul(bx, by).Mod(want, order)
+		if got := scalarToBig(&z); got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, bx, by)
+		}
+
+		z.Neg(x)
+		want.Neg(bx).Mod(want, order)
+		if got := scalarToBig(&z); got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, bx)
+		}
+
+		z.Inv(x)
+		want.ModInverse(bx, order)
+		if got := scalarToBig(&z); got.Cmp(want) != 0 {
+			test.ReportError(t, got, want, bx)
+		}
+
+		half := new(big.Int).Rsh(order, 1)
+		if got, want := x.IsHigh(), bx.Cmp(half) > 0; got != want {
+			test.ReportError(t, got, want, bx)
+		}
+	}
+}
+
+func TestScalarSetBytes(t *testing.T) {
+	var k secp256k1.Scalar
+	test.CheckOk(!k.SetBytes(secp256k1.Order()), "n must not be canonical", t)
+	test.CheckOk(k.IsZero(), "n must reduce to zero", t)
+
+	max := make([]byte, secp256k1.ScalarSize)
+	for i := range max {
+		max[i] = 0xff
+	}
+	test.CheckOk(!k.SetBytes(max), "2^256-1 must not be canonical", t)
+	want := new(big.Int).SetBytes(max)
+	want.Mod(want, order)
+	if got := scalarToBig(&k); got.Cmp(want) != 0 {
+		test.ReportError(t, got, want)
+	}
+
+	nMinus1 := new(big.Int).Sub(order, big.NewInt(1))
+	test.CheckOk(k.SetBytes(nMinus1.FillBytes(max)), "n-1 must be canonical", t)
+	test.CheckOk(k.IsHigh(), "n-1 must be high", t)
+
+	var z secp256k1.Scalar
+	z.Inv(&z)
+	test.CheckOk(z.IsZero(), "inverse of zero must be zero", t)
+}
@@ -4,7 +4,7 @@ go 1.16
 
 require (
 	github.com/bwesterb/go-ristretto v1.2.1
-	github.com/ethereum/go-ethereum v1.10.18 // indirect
+	github.com/ethereum/go-ethereum v1.10.18
 	golang.org/x/crypto v0.0.0-20220622213112-05595931fe9d
 	golang.org/x/sys v0.0.0-20220624220833-87e55d714810
 )
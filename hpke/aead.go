@@ -87,7 +87,7 @@ func (c *sealContext) Seal(pt, aad []byte) ([]byte, error) {
 func (c *openContext) Open(ct, aad []byte) ([]byte, error) {
 	pt, err := c.AEAD.Open(nil, c.calcNonce(), ct, aad)
 	if err != nil {
-		return nil, err
+		return nil, ErrAEADOpen
 	}
 	err = c.increment()
 	if err != nil {
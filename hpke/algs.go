@@ -56,23 +56,34 @@ func (k KEM) IsValid() bool {
 }
 
 // Scheme returns an instance of a KEM that supports authentication. Panics if
-// the KEM identifier is invalid.
+// the KEM identifier is invalid, so identifiers coming from untrusted inputs
+// must be looked up with LookupScheme instead.
 func (k KEM) Scheme() kem.AuthScheme {
+	s, err := k.LookupScheme()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// LookupScheme returns an instance of a KEM that supports authentication, or
+// ErrInvalidKEM if the KEM identifier is invalid.
+func (k KEM) LookupScheme() (kem.AuthScheme, error) {
 	switch k {
 	case KEM_P256_HKDF_SHA256:
-		return dhkemp256hkdfsha256
+		return dhkemp256hkdfsha256, nil
 	case KEM_K256_HKDF_SHA256:
-		return dhkemk256hkdfsha256
+		return dhkemk256hkdfsha256, nil
 	case KEM_P384_HKDF_SHA384:
-		return dhkemp384hkdfsha384
+		return dhkemp384hkdfsha384, nil
 	case KEM_P521_HKDF_SHA512:
-		return dhkemp521hkdfsha512
+		return dhkemp521hkdfsha512, nil
 	case KEM_X25519_HKDF_SHA256:
-		return dhkemx25519hkdfsha256
+		return dhkemx25519hkdfsha256, nil
 	case KEM_X448_HKDF_SHA512:
-		return dhkemx448hkdfsha512
+		return dhkemx448hkdfsha512, nil
 	default:
-		panic(ErrInvalidKEM)
+		return nil, ErrInvalidKEM
 	}
 }
 
@@ -85,7 +96,7 @@ func (k KEM) validatePublicKey(pk kem.PublicKey) bool {
 		pub, ok := pk.(*xKEMPubKey)
 		return ok && k == pub.scheme.id && pub.Validate()
 	default:
-		panic(ErrInvalidKEM)
+		return false
 	}
 }
 
@@ -98,7 +109,7 @@ func (k KEM) validatePrivateKey(sk kem.PrivateKey) bool {
 		priv, ok := sk.(*xKEMPrivKey)
 		return ok && k == priv.scheme.id && priv.Validate()
 	default:
-		panic(ErrInvalidKEM)
+		return false
 	}
 }
 
@@ -206,7 +217,7 @@ func (a AEAD) New(key []byte) (cipher.AEAD, error) {
 	case AEAD_ChaCha20Poly1305:
 		return chacha20poly1305.New(key)
 	default:
-		panic(ErrInvalidAEAD)
+		return nil, ErrInvalidAEAD
 	}
 }
 
@@ -0,0 +1,32 @@
+package hpke
+
+import (
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+// PublicKeyFromEd25519 returns the public key of KEM_X25519_HKDF_SHA256
+// corresponding to an Ed25519 public key, so that content can be encrypted
+// to the holder of an Ed25519 identity. It returns an error if pub is not
+// a valid point of prime order. See ed25519.PublicKey.ToX25519.
+func PublicKeyFromEd25519(pub ed25519.PublicKey) (kem.PublicKey, error) {
+	k, err := pub.ToX25519()
+	if err != nil {
+		return nil, err
+	}
+	return KEM_X25519_HKDF_SHA256.Scheme().UnmarshalBinaryPublicKey(k[:])
+}
+
+// PrivateKeyFromEd25519 returns the private key of KEM_X25519_HKDF_SHA256
+// corresponding to an Ed25519 private key, whose public key is the one
+// returned by PublicKeyFromEd25519 for priv.Public(). See
+// ed25519.PrivateKey.ToX25519.
+// It will panic if len(priv) is not ed25519.PrivateKeySize.
+func PrivateKeyFromEd25519(priv ed25519.PrivateKey) kem.PrivateKey {
+	k := priv.ToX25519()
+	sk, err := KEM_X25519_HKDF_SHA256.Scheme().UnmarshalBinaryPrivateKey(k[:])
+	if err != nil {
+		panic(err)
+	}
+	return sk
+}
@@ -0,0 +1,44 @@
+package hpke_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestEd25519Keys(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "keygen failed")
+	pk, err := hpke.PublicKeyFromEd25519(pub)
+	test.CheckNoErr(t, err, "public key conversion failed")
+	sk := hpke.PrivateKeyFromEd25519(priv)
+	test.CheckOk(sk.Public().Equal(pk), "converted keys do not match", t)
+
+	suite := hpke.NewSuite(hpke.KEM_X25519_HKDF_SHA256,
+		hpke.KDF_HKDF_SHA256, hpke.AEAD_ChaCha20Poly1305)
+	info := []byte("info")
+	sender, err := suite.NewSender(pk, info)
+	test.CheckNoErr(t, err, "NewSender failed")
+	enc, sealer, err := sender.Setup(rand.Reader)
+	test.CheckNoErr(t, err, "sender setup failed")
+	receiver, err := suite.NewReceiver(sk, info)
+	test.CheckNoErr(t, err, "NewReceiver failed")
+	opener, err := receiver.Setup(enc)
+	test.CheckNoErr(t, err, "receiver setup failed")
+
+	msg := []byte("message to an Ed25519 identity")
+	ct, err := sealer.Seal(msg, nil)
+	test.CheckNoErr(t, err, "seal failed")
+	pt, err := opener.Open(ct, nil)
+	test.CheckNoErr(t, err, "open failed")
+	test.CheckOk(bytes.Equal(pt, msg), "wrong plaintext", t)
+
+	identity := make(ed25519.PublicKey, ed25519.PublicKeySize)
+	identity[0] = 1
+	_, err = hpke.PublicKeyFromEd25519(identity)
+	test.CheckIsErr(t, err, "low-order point accepted")
+}
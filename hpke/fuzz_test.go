@@ -0,0 +1,187 @@
+//go:build go1.18
+// +build go1.18
+
+package hpke
+
+import (
+	"crypto/rand"
+	"errors"
+	"testing"
+)
+
+func FuzzUnmarshalSealer(f *testing.F) {
+	raw := fuzzContextSeeds(f)
+	for i := range raw {
+		f.Add(raw[i])
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		s, err := UnmarshalSealer(data)
+		if err != nil {
+			if s != nil {
+				t.Fatal("non-nil sealer returned with an error")
+			}
+			return
+		}
+		_, _ = s.Seal([]byte("plaintext"), []byte("aad"))
+		_ = s.Export([]byte("context"), 32)
+	})
+}
+
+func FuzzUnmarshalOpener(f *testing.F) {
+	raw := fuzzContextSeeds(f)
+	for i := range raw {
+		f.Add(raw[i])
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		o, err := UnmarshalOpener(data)
+		if err != nil {
+			if o != nil {
+				t.Fatal("non-nil opener returned with an error")
+			}
+			return
+		}
+		_, err = o.Open([]byte("ciphertext that does not authenticate"), nil)
+		if err == nil {
+			t.Fatal("forged ciphertext was accepted")
+		}
+		_ = o.Export([]byte("context"), 32)
+	})
+}
+
+func FuzzReceiverSetup(f *testing.F) {
+	type receiver struct {
+		suite Suite
+		r     *Receiver
+		pkS   []byte
+	}
+	var receivers []receiver
+	for _, kemID := range []KEM{
+		KEM_P256_HKDF_SHA256,
+		KEM_P384_HKDF_SHA384,
+		KEM_P521_HKDF_SHA512,
+		KEM_X25519_HKDF_SHA256,
+		KEM_X448_HKDF_SHA512,
+		KEM_K256_HKDF_SHA256,
+	} {
+		suite := NewSuite(kemID, KDF_HKDF_SHA256, AEAD_AES128GCM)
+		pk, sk, err := kemID.Scheme().GenerateKeyPair()
+		if err != nil {
+			f.Fatal(err)
+		}
+		r, err := suite.NewReceiver(sk, []byte("info"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		s, err := suite.NewSender(pk, []byte("info"))
+		if err != nil {
+			f.Fatal(err)
+		}
+		enc, _, err := s.SetupAuth(rand.Reader, sk)
+		if err != nil {
+			f.Fatal(err)
+		}
+		pkS, _ := pk.MarshalBinary()
+		receivers = append(receivers, receiver{suite, r, pkS})
+		for mode := uint8(0); mode < 4; mode++ {
+			f.Add(uint8(len(receivers)-1), mode, enc, pkS, []byte("psk"), []byte("id"))
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, idx, mode uint8, enc, pkS, psk, pskID []byte) {
+		rr := receivers[int(idx)%len(receivers)]
+		var (
+			o   Opener
+			err error
+		)
+		switch mode % 4 {
+		case modeBase:
+			o, err = rr.r.Setup(enc)
+		case modePSK:
+			o, err = rr.r.SetupPSK(enc, psk, pskID)
+		case modeAuth, modeAuthPSK:
+			kemID, _, _ := rr.suite.Params()
+			pk, perr := kemID.Scheme().UnmarshalBinaryPublicKey(pkS)
+			if perr != nil {
+				return
+			}
+			if mode%4 == modeAuth {
+				o, err = rr.r.SetupAuth(enc, pk)
+			} else {
+				o, err = rr.r.SetupAuthPSK(enc, psk, pskID, pk)
+			}
+			if errors.Is(err, ErrInvalidKEMPublicKey) {
+				return
+			}
+		}
+		if err != nil {
+			if o != nil {
+				t.Fatal("non-nil opener returned with an error")
+			}
+			return
+		}
+		_, _ = o.Open(enc, pkS)
+	})
+}
+
+func FuzzUnmarshalKeyring(f *testing.F) {
+	suite := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_AES128GCM)
+	k := NewKeyring()
+	_, sk, err := KEM_X25519_HKDF_SHA256.Scheme().GenerateKeyPair()
+	if err != nil {
+		f.Fatal(err)
+	}
+	if err = k.Rotate(7, suite, sk); err != nil {
+		f.Fatal(err)
+	}
+	raw, err := k.MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	ct, err := k.Seal(rand.Reader, nil, nil, []byte("plaintext"))
+	if err != nil {
+		f.Fatal(err)
+	}
+	f.Add(raw, ct)
+
+	f.Fuzz(func(t *testing.T, raw, ct []byte) {
+		_, _ = k.Open(nil, nil, ct)
+		k2, err := UnmarshalKeyring(raw)
+		if err != nil {
+			return
+		}
+		_, _ = k2.Open(nil, nil, ct)
+	})
+}
+
+func fuzzContextSeeds(f *testing.F) [][]byte {
+	suite := NewSuite(KEM_X25519_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_ChaCha20Poly1305)
+	pk, sk, err := KEM_X25519_HKDF_SHA256.Scheme().GenerateKeyPair()
+	if err != nil {
+		f.Fatal(err)
+	}
+	s, err := suite.NewSender(pk, nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+	enc, sealer, err := s.Setup(rand.Reader)
+	if err != nil {
+		f.Fatal(err)
+	}
+	r, err := suite.NewReceiver(sk, nil)
+	if err != nil {
+		f.Fatal(err)
+	}
+	opener, err := r.Setup(enc)
+	if err != nil {
+		f.Fatal(err)
+	}
+	rawS, err := sealer.MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	rawO, err := opener.MarshalBinary()
+	if err != nil {
+		f.Fatal(err)
+	}
+	return [][]byte{nil, {0}, {1}, rawS, rawO}
+}
@@ -0,0 +1,59 @@
+//go:build ignore
+// +build ignore
+
+// Generates testdata/vectors_k256.json.gz, the test vectors of the suites
+// using DHKEM(secp256k1, HKDF-SHA256), which are not covered by RFC 9180.
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+
+	"github.com/cloudflare/circl/hpke"
+)
+
+func main() {
+	kdfs := []hpke.KDF{
+		hpke.KDF_HKDF_SHA256,
+		hpke.KDF_HKDF_SHA384,
+		hpke.KDF_HKDF_SHA512,
+	}
+	aeads := []hpke.AEAD{
+		hpke.AEAD_AES128GCM,
+		hpke.AEAD_AES256GCM,
+		hpke.AEAD_ChaCha20Poly1305,
+	}
+
+	var vectors []*hpke.TestVector
+	for mode := hpke.ModeBase; mode <= hpke.ModeAuthPSK; mode++ {
+		for _, kdf := range kdfs {
+			for _, aead := range aeads {
+				suite := hpke.NewSuite(hpke.KEM_K256_HKDF_SHA256, kdf, aead)
+				v, err := suite.GenerateTestVector(hpke.TestVectorParams{Mode: mode})
+				if err != nil {
+					panic(err)
+				}
+				vectors = append(vectors, v)
+			}
+		}
+	}
+
+	out, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		panic(err)
+	}
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err = w.Write(out); err != nil {
+		panic(err)
+	}
+	if err = w.Close(); err != nil {
+		panic(err)
+	}
+	err = ioutil.WriteFile("testdata/vectors_k256.json.gz", buf.Bytes(), 0o644)
+	if err != nil {
+		panic(err)
+	}
+}
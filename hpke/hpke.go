@@ -78,13 +78,24 @@ type Suite struct {
 }
 
 // NewSuite builds a Suite from a specified set of algorithms. Panics
-// if an algorithm identifier is not valid.
+// if an algorithm identifier is not valid, so identifiers coming from
+// untrusted inputs must be looked up with LookupSuite instead.
 func NewSuite(kemID KEM, kdfID KDF, aeadID AEAD) Suite {
+	s, err := LookupSuite(kemID, kdfID, aeadID)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// LookupSuite builds a Suite from a specified set of algorithms. It returns
+// ErrInvalidHPKESuite if an algorithm identifier is not valid.
+func LookupSuite(kemID KEM, kdfID KDF, aeadID AEAD) (Suite, error) {
 	s := Suite{kemID, kdfID, aeadID}
 	if !s.isValid() {
-		panic(ErrInvalidHPKESuite)
+		return Suite{}, ErrInvalidHPKESuite
 	}
-	return s
+	return s, nil
 }
 
 type state struct {
@@ -105,6 +116,9 @@ type Sender struct {
 
 // NewSender creates a Sender with knowledge of the receiver's public-key.
 func (suite Suite) NewSender(pkR kem.PublicKey, info []byte) (*Sender, error) {
+	if !suite.isValid() {
+		return nil, ErrInvalidHPKESuite
+	}
 	if !suite.kemID.validatePublicKey(pkR) {
 		return nil, ErrInvalidKEMPublicKey
 	}
@@ -119,6 +133,9 @@ func (suite Suite) NewSender(pkR kem.PublicKey, info []byte) (*Sender, error) {
 // Returns the Sealer and corresponding encapsulated key.
 func (s *Sender) Setup(rnd io.Reader) (enc []byte, seal Sealer, err error) {
 	s.modeID = modeBase
+	s.state.skS = nil
+	s.state.psk = nil
+	s.state.pskID = nil
 	return s.allSetup(rnd)
 }
 
@@ -133,6 +150,8 @@ func (s *Sender) SetupAuth(rnd io.Reader, skS kem.PrivateKey) (
 
 	s.modeID = modeAuth
 	s.state.skS = skS
+	s.state.psk = nil
+	s.state.pskID = nil
 	return s.allSetup(rnd)
 }
 
@@ -142,6 +161,7 @@ func (s *Sender) SetupPSK(rnd io.Reader, psk, pskID []byte) (
 	enc []byte, seal Sealer, err error,
 ) {
 	s.modeID = modePSK
+	s.state.skS = nil
 	s.state.psk = psk
 	s.state.pskID = pskID
 	return s.allSetup(rnd)
@@ -174,6 +194,9 @@ type Receiver struct {
 func (suite Suite) NewReceiver(skR kem.PrivateKey, info []byte) (
 	*Receiver, error,
 ) {
+	if !suite.isValid() {
+		return nil, ErrInvalidHPKESuite
+	}
 	if !suite.kemID.validatePrivateKey(skR) {
 		return nil, ErrInvalidKEMPrivateKey
 	}
@@ -186,6 +209,9 @@ func (suite Suite) NewReceiver(skR kem.PrivateKey, info []byte) (
 func (r *Receiver) Setup(enc []byte) (Opener, error) {
 	r.modeID = modeBase
 	r.enc = enc
+	r.state.pkS = nil
+	r.state.psk = nil
+	r.state.pskID = nil
 	return r.allSetup()
 }
 
@@ -199,6 +225,8 @@ func (r *Receiver) SetupAuth(enc []byte, pkS kem.PublicKey) (Opener, error) {
 	r.modeID = modeAuth
 	r.enc = enc
 	r.state.pkS = pkS
+	r.state.psk = nil
+	r.state.pskID = nil
 	return r.allSetup()
 }
 
@@ -208,6 +236,7 @@ func (r *Receiver) SetupAuth(enc []byte, pkS kem.PublicKey) (Opener, error) {
 func (r *Receiver) SetupPSK(enc, psk, pskID []byte) (Opener, error) {
 	r.modeID = modePSK
 	r.enc = enc
+	r.state.pkS = nil
 	r.state.psk = psk
 	r.state.pskID = pskID
 	return r.allSetup()
@@ -237,18 +266,16 @@ func (s *Sender) allSetup(rnd io.Reader) ([]byte, Sealer, error) {
 	if rnd == nil {
 		rnd = rand.Reader
 	}
-	seed := make([]byte, scheme.SeedSize())
-	_, err := io.ReadFull(rnd, seed)
-	if err != nil {
-		return nil, nil, err
-	}
 
-	var enc, ss []byte
+	var (
+		enc, ss []byte
+		err     error
+	)
 	switch s.modeID {
 	case modeBase, modePSK:
-		enc, ss, err = scheme.EncapsulateDeterministically(s.pkR, seed)
+		enc, ss, err = scheme.EncapsulateFrom(rnd, s.pkR)
 	case modeAuth, modeAuthPSK:
-		enc, ss, err = scheme.AuthEncapsulateDeterministically(s.pkR, s.skS, seed)
+		enc, ss, err = scheme.AuthEncapsulateFrom(rnd, s.pkR, s.skS)
 	}
 	if err != nil {
 		return nil, nil, err
@@ -292,4 +319,10 @@ var (
 	ErrInvalidKEMPrivateKey   = errors.New("hpke: invalid KEM private key")
 	ErrInvalidKEMSharedSecret = errors.New("hpke: invalid KEM shared secret")
 	ErrAEADSeqOverflows       = errors.New("hpke: AEAD sequence number overflows")
+	ErrAEADOpen               = errors.New("hpke: AEAD authentication failed")
+	ErrInconsistentPSKInputs  = errors.New("hpke: inconsistent PSK inputs")
+	ErrUnexpectedPSKInput     = errors.New("hpke: PSK input provided when not needed")
+	ErrMissingPSKInput        = errors.New("hpke: missing required PSK input")
+	ErrInvalidContext         = errors.New("hpke: invalid serialized context")
+	ErrIncorrectRole          = errors.New("hpke: incorrect role of serialized context")
 )
@@ -3,11 +3,12 @@ package hpke_test
 import (
 	"bytes"
 	"crypto/rand"
-	"encoding/hex"
+	"errors"
 	"fmt"
 	"testing"
 
 	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/kem"
 )
 
 func Example() {
@@ -91,7 +92,6 @@ func TestWorks(t *testing.T) {
 		t.Fatal(err)
 	}
 	enc, sealer, err := Alice.Setup(rand.Reader)
-	t.Log("encap", hex.EncodeToString(enc))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -100,7 +100,6 @@ func TestWorks(t *testing.T) {
 	ptAlice := []byte("text encrypted to Bob's public key")
 	aad := []byte("additional public data")
 	ct, err := sealer.Seal(ptAlice, aad)
-	t.Log("ct", hex.EncodeToString(ct))
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -119,7 +118,117 @@ func TestWorks(t *testing.T) {
 	if !bytes.Equal(ptAlice, ptBob) {
 		t.Fatalf("Plaintext disagrees")
 	}
-	skBytes, _ := privateBob.MarshalBinary()
-	t.Log("sk", hex.EncodeToString(skBytes))
-	t.Fatal()
+}
+
+func TestSetupErrors(t *testing.T) {
+	kemID := hpke.KEM_X25519_HKDF_SHA256
+	suite := hpke.NewSuite(kemID, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM)
+	pk, sk, err := kemID.Scheme().GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, skP256, err := hpke.KEM_P256_HKDF_SHA256.Scheme().GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = (hpke.Suite{}).NewSender(pk, nil); !errors.Is(err, hpke.ErrInvalidHPKESuite) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidHPKESuite)
+	}
+	if _, err = (hpke.Suite{}).NewReceiver(sk, nil); !errors.Is(err, hpke.ErrInvalidHPKESuite) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidHPKESuite)
+	}
+	if _, err = suite.NewReceiver(skP256, nil); !errors.Is(err, hpke.ErrInvalidKEMPrivateKey) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidKEMPrivateKey)
+	}
+
+	sender, err := suite.NewSender(pk, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiver, err := suite.NewReceiver(sk, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = sender.SetupPSK(rand.Reader, []byte("psk"), nil); !errors.Is(err, hpke.ErrInconsistentPSKInputs) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInconsistentPSKInputs)
+	}
+	if _, _, err = sender.SetupPSK(rand.Reader, nil, nil); !errors.Is(err, hpke.ErrMissingPSKInput) {
+		t.Errorf("got %v; want %v", err, hpke.ErrMissingPSKInput)
+	}
+	if _, err = receiver.Setup(nil); !errors.Is(err, hpke.ErrInvalidKEMPublicKey) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidKEMPublicKey)
+	}
+	if _, err = receiver.Setup(make([]byte, 33)); !errors.Is(err, hpke.ErrInvalidKEMPublicKey) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidKEMPublicKey)
+	}
+	if _, err = receiver.Setup(make([]byte, 32)); !errors.Is(err, hpke.ErrInvalidKEMSharedSecret) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidKEMSharedSecret)
+	}
+
+	enc, sealer, err := sender.Setup(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ct, err := sealer.Seal([]byte("message"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = receiver.SetupPSK(enc, nil, []byte("id")); !errors.Is(err, hpke.ErrInconsistentPSKInputs) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInconsistentPSKInputs)
+	}
+	opener, err := receiver.Setup(enc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = opener.Open(ct, []byte("wrong aad")); !errors.Is(err, hpke.ErrAEADOpen) {
+		t.Errorf("got %v; want %v", err, hpke.ErrAEADOpen)
+	}
+
+	if _, err = hpke.AEAD(0xFF).New(make([]byte, 16)); !errors.Is(err, hpke.ErrInvalidAEAD) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidAEAD)
+	}
+	_, _, err = kemID.Scheme().EncapsulateDeterministically(pk, make([]byte, 3))
+	if !errors.Is(err, kem.ErrSeedSize) {
+		t.Errorf("got %v; want %v", err, kem.ErrSeedSize)
+	}
+	_, err = kemID.Scheme().Decapsulate(skP256, make([]byte, 32))
+	if !errors.Is(err, kem.ErrTypeMismatch) {
+		t.Errorf("got %v; want %v", err, kem.ErrTypeMismatch)
+	}
+
+	if _, err = hpke.KEM(0xFF).LookupScheme(); !errors.Is(err, hpke.ErrInvalidKEM) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidKEM)
+	}
+	for _, s := range []struct {
+		kemID  hpke.KEM
+		kdfID  hpke.KDF
+		aeadID hpke.AEAD
+	}{
+		{0xFF, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM},
+		{kemID, 0xFF, hpke.AEAD_AES128GCM},
+		{kemID, hpke.KDF_HKDF_SHA256, 0xFF},
+	} {
+		if _, err = hpke.LookupSuite(s.kemID, s.kdfID, s.aeadID); !errors.Is(err, hpke.ErrInvalidHPKESuite) {
+			t.Errorf("got %v; want %v", err, hpke.ErrInvalidHPKESuite)
+		}
+	}
+
+	// Serialized contexts with an unknown KEM identifier.
+	rawSealer, err := sealer.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawOpener, err := opener.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawSealer[1], rawSealer[2] = 0xFF, 0xFF
+	rawOpener[1], rawOpener[2] = 0xFF, 0xFF
+	if _, err = hpke.UnmarshalSealer(rawSealer); !errors.Is(err, hpke.ErrInvalidHPKESuite) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidHPKESuite)
+	}
+	if _, err = hpke.UnmarshalOpener(rawOpener); !errors.Is(err, hpke.ErrInvalidHPKESuite) {
+		t.Errorf("got %v; want %v", err, hpke.ErrInvalidHPKESuite)
+	}
 }
@@ -26,8 +26,9 @@ type kemBase struct {
 	dhKEM
 }
 
-func (k kemBase) Name() string       { return k.name }
-func (k kemBase) SharedKeySize() int { return k.Hash.Size() }
+func (k kemBase) Name() string         { return k.name }
+func (k kemBase) SharedKeySize() int   { return k.Hash.Size() }
+func (k kemBase) HPKEIdentifier() uint { return uint(k.id) }
 
 func (k kemBase) getSuiteID() (sid [5]byte) {
 	sid[0], sid[1], sid[2] = 'K', 'E', 'M'
@@ -80,8 +81,13 @@ func (k kemBase) labeledExpand(prk, label, info []byte, l uint16) []byte {
 func (k kemBase) AuthEncapsulate(pkr kem.PublicKey, sks kem.PrivateKey) (
 	ct []byte, ss []byte, err error,
 ) {
-	seed := make([]byte, k.SeedSize())
-	_, err = io.ReadFull(rand.Reader, seed)
+	return k.AuthEncapsulateFrom(rand.Reader, pkr, sks)
+}
+
+func (k kemBase) AuthEncapsulateFrom(
+	rnd io.Reader, pkr kem.PublicKey, sks kem.PrivateKey,
+) (ct []byte, ss []byte, err error) {
+	seed, err := k.readSeed(rnd)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -92,8 +98,13 @@ func (k kemBase) AuthEncapsulate(pkr kem.PublicKey, sks kem.PrivateKey) (
 func (k kemBase) Encapsulate(pkr kem.PublicKey) (
 	ct []byte, ss []byte, err error,
 ) {
-	seed := make([]byte, k.SeedSize())
-	_, err = io.ReadFull(rand.Reader, seed)
+	return k.EncapsulateFrom(rand.Reader, pkr)
+}
+
+func (k kemBase) EncapsulateFrom(rnd io.Reader, pkr kem.PublicKey) (
+	ct []byte, ss []byte, err error,
+) {
+	seed, err := k.readSeed(rnd)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -101,6 +112,17 @@ func (k kemBase) Encapsulate(pkr kem.PublicKey) (
 	return k.encap(pkr, seed)
 }
 
+func (k kemBase) readSeed(rnd io.Reader) ([]byte, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	seed := make([]byte, k.SeedSize())
+	if _, err := io.ReadFull(rnd, seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
 func (k kemBase) AuthEncapsulateDeterministically(
 	pkr kem.PublicKey, sks kem.PrivateKey, seed []byte,
 ) (ct, ss []byte, err error) {
@@ -117,6 +139,9 @@ func (k kemBase) encap(
 	pkR kem.PublicKey,
 	seed []byte,
 ) (ct []byte, ss []byte, err error) {
+	if len(seed) != k.SeedSize() {
+		return nil, nil, kem.ErrSeedSize
+	}
 	dh := make([]byte, k.sizeDH())
 	enc, kemCtx, err := k.coreEncap(dh, pkR, seed)
 	if err != nil {
@@ -131,6 +156,9 @@ func (k kemBase) authEncap(
 	skS kem.PrivateKey,
 	seed []byte,
 ) (ct []byte, ss []byte, err error) {
+	if len(seed) != k.SeedSize() {
+		return nil, nil, kem.ErrSeedSize
+	}
 	dhLen := k.sizeDH()
 	dh := make([]byte, 2*dhLen)
 	enc, kemCtx, err := k.coreEncap(dh[:dhLen], pkR, seed)
@@ -0,0 +1,359 @@
+package hpke
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"sort"
+	"sync"
+
+	"github.com/cloudflare/circl/kem"
+	"golang.org/x/crypto/cryptobyte"
+)
+
+// KeyID identifies a receiver key held in a Keyring.
+type KeyID uint32
+
+// KeyState indicates whether a key of a Keyring is used for sealing.
+type KeyState uint8
+
+const (
+	// KeyActive is the state of the key used by Keyring.Seal. A keyring
+	// holds at most one active key.
+	KeyActive KeyState = 0x00
+	// KeyRetired is the state of keys that are only used for opening
+	// ciphertexts sealed before a rotation.
+	KeyRetired KeyState = 0x01
+)
+
+// Keyring holds a set of receiver keys indexed by key identifiers. One of
+// them can be active, and it is used to seal new messages; the retired ones
+// are kept to open messages sealed before a rotation. A Keyring is safe for
+// concurrent use.
+//
+// Ciphertexts produced by Keyring.Seal have the following format (expressed
+// in TLS syntax). Note that this format is not defined by the HPKE standard.
+//
+//	struct {
+//	    uint32 key_id;
+//	    opaque enc[Nenc];
+//	    opaque ct<0..2^32-1>; // until the end of the message.
+//	} KeyringCiphertext;
+type Keyring struct {
+	mu     sync.RWMutex
+	keys   map[KeyID]*keyringEntry
+	active *keyringEntry
+}
+
+type keyringEntry struct {
+	id    KeyID
+	suite Suite
+	skR   kem.PrivateKey
+	pkR   kem.PublicKey
+	state KeyState
+}
+
+// NewKeyring returns an empty keyring.
+func NewKeyring() *Keyring {
+	return &Keyring{keys: make(map[KeyID]*keyringEntry)}
+}
+
+// Add inserts a retired key in the keyring. Returns an error if the key
+// identifier is already in use, or if the key does not match the suite.
+func (k *Keyring) Add(id KeyID, suite Suite, skR kem.PrivateKey) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.add(id, suite, skR, KeyRetired)
+}
+
+// Rotate inserts a new key in the keyring and makes it the active key.
+// The previously active key, if any, becomes retired.
+func (k *Keyring) Rotate(id KeyID, suite Suite, skR kem.PrivateKey) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.add(id, suite, skR, KeyActive)
+}
+
+// Activate makes the key identified by id the active key. The previously
+// active key, if any, becomes retired.
+func (k *Keyring) Activate(id KeyID) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	e, ok := k.keys[id]
+	if !ok {
+		return ErrUnknownKeyID
+	}
+	k.setActive(e)
+	return nil
+}
+
+// Retire sets the key identified by id as retired. If it was the active
+// key, the keyring has no active key until Rotate or Activate are called.
+func (k *Keyring) Retire(id KeyID) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	e, ok := k.keys[id]
+	if !ok {
+		return ErrUnknownKeyID
+	}
+	if e == k.active {
+		k.active = nil
+	}
+	e.state = KeyRetired
+	return nil
+}
+
+// Remove deletes the key identified by id from the keyring. Messages
+// sealed to this key can no longer be opened.
+func (k *Keyring) Remove(id KeyID) error {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	e, ok := k.keys[id]
+	if !ok {
+		return ErrUnknownKeyID
+	}
+	if e == k.active {
+		k.active = nil
+	}
+	delete(k.keys, id)
+	return nil
+}
+
+// Active returns the identifier, the suite, and the public key of the
+// active key.
+func (k *Keyring) Active() (KeyID, Suite, kem.PublicKey, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.active == nil {
+		return 0, Suite{}, nil, ErrNoActiveKey
+	}
+	return k.active.id, k.active.suite, k.active.pkR, nil
+}
+
+// State returns the state of the key identified by id.
+func (k *Keyring) State(id KeyID) (KeyState, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	e, ok := k.keys[id]
+	if !ok {
+		return 0, ErrUnknownKeyID
+	}
+	return e.state, nil
+}
+
+// KeyIDs returns the identifiers of all the keys in the keyring in
+// increasing order.
+func (k *Keyring) KeyIDs() []KeyID {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	ids := make([]KeyID, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// Seal encrypts a plaintext to the active key using Base Mode, and tags the
+// ciphertext with the identifier of the active key. If rnd is nil,
+// crypto/rand.Reader is used.
+func (k *Keyring) Seal(rnd io.Reader, info, aad, pt []byte) ([]byte, error) {
+	k.mu.RLock()
+	e := k.active
+	k.mu.RUnlock()
+	if e == nil {
+		return nil, ErrNoActiveKey
+	}
+
+	sender, err := e.suite.NewSender(e.pkR, info)
+	if err != nil {
+		return nil, err
+	}
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	enc, sealer, err := sender.Setup(rnd)
+	if err != nil {
+		return nil, err
+	}
+	ct, err := sealer.Seal(pt, aad)
+	if err != nil {
+		return nil, err
+	}
+
+	var b cryptobyte.Builder
+	b.AddUint32(uint32(e.id))
+	b.AddBytes(enc)
+	b.AddBytes(ct)
+	return b.Bytes()
+}
+
+// Open decrypts a ciphertext produced by Seal using the key whose
+// identifier is found in the ciphertext. Both active and retired keys are
+// used for opening.
+func (k *Keyring) Open(info, aad, ct []byte) ([]byte, error) {
+	var id uint32
+	s := cryptobyte.String(ct)
+	if !s.ReadUint32(&id) {
+		return nil, ErrInvalidKeyringCiphertext
+	}
+
+	k.mu.RLock()
+	e, ok := k.keys[KeyID(id)]
+	k.mu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKeyID
+	}
+
+	var enc []byte
+	if !s.ReadBytes(&enc, e.suite.kemID.Scheme().CiphertextSize()) {
+		return nil, ErrInvalidKeyringCiphertext
+	}
+
+	receiver, err := e.suite.NewReceiver(e.skR, info)
+	if err != nil {
+		return nil, err
+	}
+	opener, err := receiver.Setup(enc)
+	if err != nil {
+		return nil, err
+	}
+	return opener.Open([]byte(s), aad)
+}
+
+// MarshalBinary serializes the keyring, including private keys, according
+// to the format specified below. (Expressed in TLS syntax.) Note that this
+// format is not defined by the HPKE standard.
+//
+//	enum { active(0), retired(1) } KeyState;
+//
+//	struct {
+//	    uint32 key_id;
+//	    KeyState state;
+//	    HpkeKemId kem_id;
+//	    HpkeKdfId kdf_id;
+//	    HpkeAeadId aead_id;
+//	    opaque private_key<0..2^16-1>;
+//	} KeyringEntry;
+//
+//	struct {
+//	    KeyringEntry entries<0..2^24-1>;
+//	} Keyring;
+//
+// Entries are sorted by key identifier.
+func (k *Keyring) MarshalBinary() ([]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	ids := make([]KeyID, 0, len(k.keys))
+	for id := range k.keys {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var b cryptobyte.Builder
+	b.AddUint24LengthPrefixed(func(b *cryptobyte.Builder) {
+		for _, id := range ids {
+			e := k.keys[id]
+			sk, err := e.skR.MarshalBinary()
+			if err != nil {
+				b.SetError(err)
+				return
+			}
+			b.AddUint32(uint32(e.id))
+			b.AddUint8(uint8(e.state))
+			b.AddUint16(uint16(e.suite.kemID))
+			b.AddUint16(uint16(e.suite.kdfID))
+			b.AddUint16(uint16(e.suite.aeadID))
+			b.AddUint16LengthPrefixed(func(b *cryptobyte.Builder) {
+				b.AddBytes(sk)
+			})
+		}
+	})
+	return b.Bytes()
+}
+
+// UnmarshalKeyring parses a keyring serialized with Keyring.MarshalBinary.
+func UnmarshalKeyring(raw []byte) (*Keyring, error) {
+	var entries cryptobyte.String
+	s := cryptobyte.String(raw)
+	if !s.ReadUint24LengthPrefixed(&entries) || !s.Empty() {
+		return nil, ErrInvalidKeyring
+	}
+
+	k := NewKeyring()
+	for !entries.Empty() {
+		var (
+			id     uint32
+			state  uint8
+			kemID  KEM
+			kdfID  KDF
+			aeadID AEAD
+			sk     cryptobyte.String
+		)
+		if !entries.ReadUint32(&id) ||
+			!entries.ReadUint8(&state) ||
+			!entries.ReadUint16((*uint16)(&kemID)) ||
+			!entries.ReadUint16((*uint16)(&kdfID)) ||
+			!entries.ReadUint16((*uint16)(&aeadID)) ||
+			!entries.ReadUint16LengthPrefixed(&sk) {
+			return nil, ErrInvalidKeyring
+		}
+		suite, err := LookupSuite(kemID, kdfID, aeadID)
+		if err != nil {
+			return nil, err
+		}
+		if KeyState(state) != KeyActive && KeyState(state) != KeyRetired {
+			return nil, ErrInvalidKeyring
+		}
+		if KeyState(state) == KeyActive && k.active != nil {
+			return nil, ErrInvalidKeyring
+		}
+
+		skR, err := suite.kemID.Scheme().UnmarshalBinaryPrivateKey(sk)
+		if err != nil {
+			return nil, err
+		}
+		if err := k.add(KeyID(id), suite, skR, KeyState(state)); err != nil {
+			return nil, err
+		}
+	}
+	return k, nil
+}
+
+// add must be called with the write lock held.
+func (k *Keyring) add(id KeyID, suite Suite, skR kem.PrivateKey, state KeyState) error {
+	if !suite.isValid() {
+		return ErrInvalidHPKESuite
+	}
+	if !suite.kemID.validatePrivateKey(skR) {
+		return ErrInvalidKEMPrivateKey
+	}
+	if _, ok := k.keys[id]; ok {
+		return ErrDuplicateKeyID
+	}
+
+	e := &keyringEntry{id, suite, skR, skR.Public(), KeyRetired}
+	k.keys[id] = e
+	if state == KeyActive {
+		k.setActive(e)
+	}
+	return nil
+}
+
+// setActive must be called with the write lock held.
+func (k *Keyring) setActive(e *keyringEntry) {
+	if k.active != nil {
+		k.active.state = KeyRetired
+	}
+	e.state = KeyActive
+	k.active = e
+}
+
+var (
+	ErrNoActiveKey              = errors.New("hpke: keyring has no active key")
+	ErrUnknownKeyID             = errors.New("hpke: unknown key identifier")
+	ErrDuplicateKeyID           = errors.New("hpke: duplicate key identifier")
+	ErrInvalidKeyring           = errors.New("hpke: invalid keyring encoding")
+	ErrInvalidKeyringCiphertext = errors.New("hpke: invalid keyring ciphertext")
+)
@@ -0,0 +1,140 @@
+package hpke_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+)
+
+func genKey(t *testing.T, s hpke.Suite) kem.PrivateKey {
+	t.Helper()
+	kemID, _, _ := s.Params()
+	_, sk, err := kemID.Scheme().GenerateKeyPair()
+	test.CheckNoErr(t, err, "key generation failed")
+	return sk
+}
+
+func TestKeyringRotation(t *testing.T) {
+	s1 := hpke.NewSuite(hpke.KEM_X25519_HKDF_SHA256, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM)
+	s2 := hpke.NewSuite(hpke.KEM_P256_HKDF_SHA256, hpke.KDF_HKDF_SHA256, hpke.AEAD_ChaCha20Poly1305)
+	info := []byte("keyring info")
+	aad := []byte("keyring aad")
+	pt := []byte("keyring plaintext")
+
+	k := hpke.NewKeyring()
+	_, err := k.Seal(rand.Reader, info, aad, pt)
+	if !errors.Is(err, hpke.ErrNoActiveKey) {
+		test.ReportError(t, err, hpke.ErrNoActiveKey)
+	}
+
+	test.CheckNoErr(t, k.Rotate(1, s1, genKey(t, s1)), "rotate failed")
+	ct1, err := k.Seal(rand.Reader, info, aad, pt)
+	test.CheckNoErr(t, err, "seal failed")
+
+	test.CheckNoErr(t, k.Rotate(2, s2, genKey(t, s2)), "rotate failed")
+	ct2, err := k.Seal(rand.Reader, info, aad, pt)
+	test.CheckNoErr(t, err, "seal failed")
+
+	id, suite, _, err := k.Active()
+	test.CheckNoErr(t, err, "no active key")
+	if id != 2 || suite != s2 {
+		test.ReportError(t, id, 2, suite)
+	}
+	state, err := k.State(1)
+	test.CheckNoErr(t, err, "unknown key")
+	if state != hpke.KeyRetired {
+		test.ReportError(t, state, hpke.KeyRetired)
+	}
+
+	err = k.Rotate(2, s1, genKey(t, s1))
+	if !errors.Is(err, hpke.ErrDuplicateKeyID) {
+		test.ReportError(t, err, hpke.ErrDuplicateKeyID)
+	}
+
+	for _, ct := range [][]byte{ct1, ct2} {
+		got, err := k.Open(info, aad, ct)
+		test.CheckNoErr(t, err, "open failed")
+		if !bytes.Equal(got, pt) {
+			test.ReportError(t, got, pt)
+		}
+	}
+
+	test.CheckNoErr(t, k.Remove(1), "remove failed")
+	_, err = k.Open(info, aad, ct1)
+	if !errors.Is(err, hpke.ErrUnknownKeyID) {
+		test.ReportError(t, err, hpke.ErrUnknownKeyID)
+	}
+	_, err = k.Open(info, aad, ct2[:3])
+	if !errors.Is(err, hpke.ErrInvalidKeyringCiphertext) {
+		test.ReportError(t, err, hpke.ErrInvalidKeyringCiphertext)
+	}
+	_, err = k.Open(info, aad, ct2[:10])
+	if !errors.Is(err, hpke.ErrInvalidKeyringCiphertext) {
+		test.ReportError(t, err, hpke.ErrInvalidKeyringCiphertext)
+	}
+
+	test.CheckNoErr(t, k.Retire(2), "retire failed")
+	_, err = k.Seal(rand.Reader, info, aad, pt)
+	if !errors.Is(err, hpke.ErrNoActiveKey) {
+		test.ReportError(t, err, hpke.ErrNoActiveKey)
+	}
+	got, err := k.Open(info, aad, ct2)
+	test.CheckNoErr(t, err, "open with retired key failed")
+	if !bytes.Equal(got, pt) {
+		test.ReportError(t, got, pt)
+	}
+}
+
+func TestKeyringSerialization(t *testing.T) {
+	suites := []hpke.Suite{
+		hpke.NewSuite(hpke.KEM_P384_HKDF_SHA384, hpke.KDF_HKDF_SHA384, hpke.AEAD_AES256GCM),
+		hpke.NewSuite(hpke.KEM_K256_HKDF_SHA256, hpke.KDF_HKDF_SHA256, hpke.AEAD_AES128GCM),
+		hpke.NewSuite(hpke.KEM_X448_HKDF_SHA512, hpke.KDF_HKDF_SHA512, hpke.AEAD_ChaCha20Poly1305),
+	}
+	info := []byte("keyring info")
+	aad := []byte("keyring aad")
+	pt := []byte("keyring plaintext")
+
+	k := hpke.NewKeyring()
+	var cts [][]byte
+	for i, s := range suites {
+		test.CheckNoErr(t, k.Rotate(hpke.KeyID(10+i), s, genKey(t, s)), "rotate failed")
+		ct, err := k.Seal(rand.Reader, info, aad, pt)
+		test.CheckNoErr(t, err, "seal failed")
+		cts = append(cts, ct)
+	}
+
+	raw, err := k.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	k2, err := hpke.UnmarshalKeyring(raw)
+	test.CheckNoErr(t, err, "unmarshal failed")
+
+	raw2, err := k2.MarshalBinary()
+	test.CheckNoErr(t, err, "marshal failed")
+	if !bytes.Equal(raw, raw2) {
+		test.ReportError(t, raw2, raw)
+	}
+
+	id, _, _, err := k2.Active()
+	test.CheckNoErr(t, err, "no active key")
+	if id != 12 {
+		test.ReportError(t, id, 12)
+	}
+	for _, ct := range cts {
+		got, err := k2.Open(info, aad, ct)
+		test.CheckNoErr(t, err, "open failed")
+		if !bytes.Equal(got, pt) {
+			test.ReportError(t, got, pt)
+		}
+	}
+
+	for _, bad := range [][]byte{nil, raw[:len(raw)-1], append(raw, 0)} {
+		_, err = hpke.UnmarshalKeyring(bad)
+		test.CheckIsErr(t, err, "unmarshal must fail")
+	}
+}
@@ -1,7 +1,7 @@
 package hpke
 
 import (
-	"errors"
+	"fmt"
 
 	"golang.org/x/crypto/cryptobyte"
 )
@@ -34,11 +34,14 @@ func unmarshalContext(raw []byte) (*encdecContext, error) {
 		t   cryptobyte.String
 	)
 
+	var kemID KEM
+	var kdfID KDF
+	var aeadID AEAD
 	c := new(encdecContext)
 	s := cryptobyte.String(raw)
-	if !s.ReadUint16((*uint16)(&c.suite.kemID)) ||
-		!s.ReadUint16((*uint16)(&c.suite.kdfID)) ||
-		!s.ReadUint16((*uint16)(&c.suite.aeadID)) ||
+	if !s.ReadUint16((*uint16)(&kemID)) ||
+		!s.ReadUint16((*uint16)(&kdfID)) ||
+		!s.ReadUint16((*uint16)(&aeadID)) ||
 		!s.ReadUint8LengthPrefixed(&t) ||
 		!t.ReadBytes(&c.exporterSecret, len(t)) ||
 		!s.ReadUint8LengthPrefixed(&t) ||
@@ -47,21 +50,22 @@ func unmarshalContext(raw []byte) (*encdecContext, error) {
 		!t.ReadBytes(&c.baseNonce, len(t)) ||
 		!s.ReadUint8LengthPrefixed(&t) ||
 		!t.ReadBytes(&c.sequenceNumber, len(t)) {
-		return nil, errors.New("failed to parse context")
+		return nil, ErrInvalidContext
 	}
 
-	if !c.suite.isValid() {
-		return nil, ErrInvalidHPKESuite
+	c.suite, err = LookupSuite(kemID, kdfID, aeadID)
+	if err != nil {
+		return nil, err
 	}
 
 	Nh := c.suite.kdfID.ExtractSize()
 	if len(c.exporterSecret) != Nh {
-		return nil, errors.New("invalid exporter secret length")
+		return nil, fmt.Errorf("%w: invalid exporter secret length", ErrInvalidContext)
 	}
 
 	Nk := int(c.suite.aeadID.KeySize())
 	if len(c.key) != Nk {
-		return nil, errors.New("invalid key length")
+		return nil, fmt.Errorf("%w: invalid key length", ErrInvalidContext)
 	}
 
 	c.AEAD, err = c.suite.aeadID.New(c.key)
@@ -71,10 +75,10 @@ func unmarshalContext(raw []byte) (*encdecContext, error) {
 
 	Nn := c.AEAD.NonceSize()
 	if len(c.baseNonce) != Nn {
-		return nil, errors.New("invalid base nonce length")
+		return nil, fmt.Errorf("%w: invalid base nonce length", ErrInvalidContext)
 	}
 	if len(c.sequenceNumber) != Nn {
-		return nil, errors.New("invalid sequence number length")
+		return nil, fmt.Errorf("%w: invalid sequence number length", ErrInvalidContext)
 	}
 	c.nonce = make([]byte, Nn)
 
@@ -111,8 +115,11 @@ func (c *sealContext) MarshalBinary() ([]byte, error) {
 
 // UnmarshalSealer parses an HPKE sealer.
 func UnmarshalSealer(raw []byte) (Sealer, error) {
+	if len(raw) == 0 {
+		return nil, ErrInvalidContext
+	}
 	if raw[0] != 0 {
-		return nil, errors.New("incorrect role")
+		return nil, ErrIncorrectRole
 	}
 	context, err := unmarshalContext(raw[1:])
 	if err != nil {
@@ -140,8 +147,11 @@ func (c *openContext) MarshalBinary() ([]byte, error) {
 // UnmarshalOpener parses a serialized HPKE opener and returns the corresponding
 // Opener.
 func UnmarshalOpener(raw []byte) (Opener, error) {
+	if len(raw) == 0 {
+		return nil, ErrInvalidContext
+	}
 	if raw[0] != 1 {
-		return nil, errors.New("incorrect role")
+		return nil, ErrIncorrectRole
 	}
 	context, err := unmarshalContext(raw[1:])
 	if err != nil {
@@ -3,6 +3,7 @@ package hpke
 import (
 	"bytes"
 	"crypto/rand"
+	"errors"
 	"testing"
 )
 
@@ -82,3 +83,16 @@ func TestContextSerialization(t *testing.T) {
 		t.Error("parsing an opener as a sealer succeeded; want failure")
 	}
 }
+
+func TestContextUnmarshalErrors(t *testing.T) {
+	for _, raw := range [][]byte{nil, {}, {0}, {1}, {0, 0, 0x20}, {1, 0xff, 0xff}} {
+		if _, err := UnmarshalSealer(raw); !errors.Is(err, ErrInvalidContext) &&
+			!errors.Is(err, ErrIncorrectRole) {
+			t.Errorf("UnmarshalSealer(%x): got %v", raw, err)
+		}
+		if _, err := UnmarshalOpener(raw); !errors.Is(err, ErrInvalidContext) &&
+			!errors.Is(err, ErrIncorrectRole) {
+			t.Errorf("UnmarshalOpener(%x): got %v", raw, err)
+		}
+	}
+}
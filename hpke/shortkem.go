@@ -5,6 +5,7 @@ import (
 	"crypto/rand"
 	"crypto/subtle"
 	"fmt"
+	"io"
 	"math/big"
 
 	"github.com/cloudflare/circl/kem"
@@ -25,8 +26,11 @@ func (s shortKEM) byteSize() int { return (s.Params().BitSize + 7) / 8 }
 
 func (s shortKEM) sizeDH() int { return s.byteSize() }
 func (s shortKEM) calcDH(dh []byte, sk kem.PrivateKey, pk kem.PublicKey) error {
-	PK := pk.(*shortKEMPubKey)
-	SK := sk.(*shortKEMPrivKey)
+	PK, okPK := pk.(*shortKEMPubKey)
+	SK, okSK := sk.(*shortKEMPrivKey)
+	if !okPK || !okSK || PK.scheme.id != s.id || SK.scheme.id != s.id {
+		return kem.ErrTypeMismatch
+	}
 	l := len(dh)
 	x, _ := s.ScalarMult(PK.x, PK.y, SK.priv) // only x-coordinate is used.
 	if x.Sign() == 0 {
@@ -76,18 +80,28 @@ func (s shortKEM) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 }
 
 func (s shortKEM) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
-	sk, x, y, err := elliptic.GenerateKey(s, rand.Reader)
+	return s.GenerateKeyPairFrom(rand.Reader)
+}
+
+func (s shortKEM) GenerateKeyPairFrom(rnd io.Reader) (kem.PublicKey, kem.PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
+	sk, x, y, err := elliptic.GenerateKey(s, rnd)
+	if err != nil {
+		return nil, nil, err
+	}
 	pub := &shortKEMPubKey{s, x, y}
-	return pub, &shortKEMPrivKey{s, sk, pub}, err
+	return pub, &shortKEMPrivKey{s, sk, pub}, nil
 }
 
 func (s shortKEM) UnmarshalBinaryPrivateKey(data []byte) (kem.PrivateKey, error) {
 	l := s.PrivateKeySize()
-	if len(data) < l {
+	if len(data) != l {
 		return nil, ErrInvalidKEMPrivateKey
 	}
 	sk := &shortKEMPrivKey{s, make([]byte, l), nil}
-	copy(sk.priv[l-len(data):l], data[:l])
+	copy(sk.priv, data)
 	return sk, nil
 }
 
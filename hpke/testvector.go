@@ -0,0 +1,278 @@
+package hpke
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem"
+)
+
+// Identifiers of the HPKE modes, as they appear in the mode field of a
+// TestVector.
+const (
+	ModeBase    uint8 = modeBase
+	ModePSK     uint8 = modePSK
+	ModeAuth    uint8 = modeAuth
+	ModeAuthPSK uint8 = modeAuthPSK
+)
+
+// TestVector holds the inputs and outputs of an HPKE operation in the JSON
+// format used by the test vectors of RFC 9180. All byte strings are encoded
+// in hexadecimal.
+type TestVector struct {
+	Mode               uint8                  `json:"mode"`
+	KemID              uint16                 `json:"kem_id"`
+	KdfID              uint16                 `json:"kdf_id"`
+	AeadID             uint16                 `json:"aead_id"`
+	Info               string                 `json:"info"`
+	IkmR               string                 `json:"ikmR"`
+	IkmS               string                 `json:"ikmS,omitempty"`
+	IkmE               string                 `json:"ikmE"`
+	SkRm               string                 `json:"skRm"`
+	SkSm               string                 `json:"skSm,omitempty"`
+	SkEm               string                 `json:"skEm"`
+	Psk                string                 `json:"psk,omitempty"`
+	PskID              string                 `json:"psk_id,omitempty"`
+	PkRm               string                 `json:"pkRm"`
+	PkSm               string                 `json:"pkSm,omitempty"`
+	PkEm               string                 `json:"pkEm"`
+	Enc                string                 `json:"enc"`
+	SharedSecret       string                 `json:"shared_secret"`
+	KeyScheduleContext string                 `json:"key_schedule_context"`
+	Secret             string                 `json:"secret"`
+	Key                string                 `json:"key"`
+	BaseNonce          string                 `json:"base_nonce"`
+	ExporterSecret     string                 `json:"exporter_secret"`
+	Encryptions        []TestVectorEncryption `json:"encryptions"`
+	Exports            []TestVectorExport     `json:"exports"`
+}
+
+// TestVectorEncryption is the result of one call to Seal in a TestVector.
+type TestVectorEncryption struct {
+	Aad   string `json:"aad"`
+	Ct    string `json:"ct"`
+	Nonce string `json:"nonce"`
+	Pt    string `json:"pt"`
+}
+
+// TestVectorExport is the result of one call to Export in a TestVector.
+type TestVectorExport struct {
+	ExporterContext string `json:"exporter_context"`
+	L               uint   `json:"L"`
+	ExportedValue   string `json:"exported_value"`
+}
+
+// TestVectorParams specifies the inputs of GenerateTestVector. Fields left
+// empty take the values used in RFC 9180, except for the ikm inputs, which
+// are derived deterministically from the suite and the mode.
+type TestVectorParams struct {
+	Mode uint8
+	// IkmR, IkmE and IkmS are the seeds of the receiver, ephemeral and
+	// sender key pairs. They must be Scheme().SeedSize() bytes long. IkmS is
+	// only used in the Auth and AuthPSK modes.
+	IkmR, IkmE, IkmS []byte
+	Info             []byte
+	// PSK and PSKID are only used in the PSK and AuthPSK modes.
+	PSK, PSKID []byte
+	// NumEncryptions is the number of plaintexts sealed in sequence. If zero,
+	// 257 encryptions are produced so that the sequence number exceeds one
+	// byte.
+	NumEncryptions int
+}
+
+// Default inputs of the test vectors of RFC 9180.
+var (
+	testVectorInfo = []byte("Ode on a Grecian Urn")
+	testVectorPSK  = []byte{
+		0x02, 0x47, 0xfd, 0x33, 0xb9, 0x13, 0x76, 0x0f,
+		0xa1, 0xfa, 0x51, 0xe1, 0x89, 0x2d, 0x9f, 0x30,
+		0x7f, 0xbe, 0x65, 0xeb, 0x17, 0x1e, 0x81, 0x32,
+		0xc2, 0xaf, 0x18, 0x55, 0x5a, 0x73, 0x8b, 0x82,
+	}
+	testVectorPSKID      = []byte("Ennyn Durin aran Moria")
+	testVectorPlaintext  = []byte("Beauty is truth, truth beauty")
+	testVectorNumEncs    = 257
+	testVectorExportCtxs = [][]byte{{}, {0x00}, []byte("TestContext")}
+	testVectorExportLen  = uint(32)
+	testVectorIkmLabel   = []byte("HPKE test vector ikm")
+	testVectorIkmRLabel  = []byte("ikmR")
+	testVectorIkmELabel  = []byte("ikmE")
+	testVectorIkmSLabel  = []byte("ikmS")
+	testVectorAadPrefix  = "Count-"
+)
+
+var errTestVectorMismatch = errors.New("hpke: generated ciphertext does not decrypt")
+
+// GenerateTestVector runs an HPKE key schedule in the given mode, seals a
+// sequence of plaintexts and exports secrets, and records every
+// intermediate value in a TestVector. The output is fully determined by
+// the suite and the parameters.
+func (suite Suite) GenerateTestVector(p TestVectorParams) (*TestVector, error) {
+	if !suite.isValid() {
+		return nil, ErrInvalidHPKESuite
+	}
+	if p.Mode > modeAuthPSK {
+		return nil, fmt.Errorf("hpke: invalid mode %v", p.Mode)
+	}
+	isAuth := p.Mode == modeAuth || p.Mode == modeAuthPSK
+	isPSK := p.Mode == modePSK || p.Mode == modeAuthPSK
+
+	scheme := suite.kemID.Scheme()
+	ikmR := suite.testVectorIkm(p.IkmR, p.Mode, testVectorIkmRLabel)
+	ikmE := suite.testVectorIkm(p.IkmE, p.Mode, testVectorIkmELabel)
+	var ikmS []byte
+	if isAuth {
+		ikmS = suite.testVectorIkm(p.IkmS, p.Mode, testVectorIkmSLabel)
+	}
+	for _, ikm := range [][]byte{ikmR, ikmE, ikmS} {
+		if ikm != nil && len(ikm) != scheme.SeedSize() {
+			return nil, kem.ErrSeedSize
+		}
+	}
+
+	info := p.Info
+	if info == nil {
+		info = testVectorInfo
+	}
+	var psk, pskID []byte
+	if isPSK {
+		psk, pskID = p.PSK, p.PSKID
+		if psk == nil && pskID == nil {
+			psk, pskID = testVectorPSK, testVectorPSKID
+		}
+	}
+	numEncs := p.NumEncryptions
+	if numEncs == 0 {
+		numEncs = testVectorNumEncs
+	}
+
+	pkR, skR := scheme.DeriveKeyPair(ikmR)
+	pkE, skE := scheme.DeriveKeyPair(ikmE)
+	var (
+		pkS     kem.PublicKey
+		skS     kem.PrivateKey
+		enc, ss []byte
+		err     error
+	)
+	if isAuth {
+		pkS, skS = scheme.DeriveKeyPair(ikmS)
+		enc, ss, err = scheme.AuthEncapsulateFrom(bytes.NewReader(ikmE), pkR, skS)
+	} else {
+		enc, ss, err = scheme.EncapsulateFrom(bytes.NewReader(ikmE), pkR)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	st := state{Suite: suite, modeID: p.Mode}
+	ctx, err := st.keySchedule(ss, info, psk, pskID)
+	if err != nil {
+		return nil, err
+	}
+	keySchCtx, secret := st.scheduleSecret(ss, info, psk, pskID)
+
+	r, err := suite.NewReceiver(skR, info)
+	if err != nil {
+		return nil, err
+	}
+	var opener Opener
+	switch p.Mode {
+	case modeBase:
+		opener, err = r.Setup(enc)
+	case modePSK:
+		opener, err = r.SetupPSK(enc, psk, pskID)
+	case modeAuth:
+		opener, err = r.SetupAuth(enc, pkS)
+	case modeAuthPSK:
+		opener, err = r.SetupAuthPSK(enc, psk, pskID, pkS)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	v := &TestVector{
+		Mode:               p.Mode,
+		KemID:              uint16(suite.kemID),
+		KdfID:              uint16(suite.kdfID),
+		AeadID:             uint16(suite.aeadID),
+		Info:               hex.EncodeToString(info),
+		IkmR:               hex.EncodeToString(ikmR),
+		IkmS:               hex.EncodeToString(ikmS),
+		IkmE:               hex.EncodeToString(ikmE),
+		SkRm:               marshalHex(skR),
+		SkEm:               marshalHex(skE),
+		Psk:                hex.EncodeToString(psk),
+		PskID:              hex.EncodeToString(pskID),
+		PkRm:               marshalHex(pkR),
+		PkEm:               marshalHex(pkE),
+		Enc:                hex.EncodeToString(enc),
+		SharedSecret:       hex.EncodeToString(ss),
+		KeyScheduleContext: hex.EncodeToString(keySchCtx),
+		Secret:             hex.EncodeToString(secret),
+		Key:                hex.EncodeToString(ctx.key),
+		BaseNonce:          hex.EncodeToString(ctx.baseNonce),
+		ExporterSecret:     hex.EncodeToString(ctx.exporterSecret),
+	}
+	if isAuth {
+		v.SkSm = marshalHex(skS)
+		v.PkSm = marshalHex(pkS)
+	}
+
+	sealer := &sealContext{ctx}
+	v.Encryptions = make([]TestVectorEncryption, numEncs)
+	for i := range v.Encryptions {
+		aad := []byte(fmt.Sprintf("%v%v", testVectorAadPrefix, i))
+		nonce := hex.EncodeToString(sealer.calcNonce())
+		ct, err := sealer.Seal(testVectorPlaintext, aad)
+		if err != nil {
+			return nil, err
+		}
+		pt, err := opener.Open(ct, aad)
+		if err != nil || !bytes.Equal(pt, testVectorPlaintext) {
+			return nil, errTestVectorMismatch
+		}
+		v.Encryptions[i] = TestVectorEncryption{
+			Aad:   hex.EncodeToString(aad),
+			Ct:    hex.EncodeToString(ct),
+			Nonce: nonce,
+			Pt:    hex.EncodeToString(testVectorPlaintext),
+		}
+	}
+
+	v.Exports = make([]TestVectorExport, len(testVectorExportCtxs))
+	for i, expCtx := range testVectorExportCtxs {
+		v.Exports[i] = TestVectorExport{
+			ExporterContext: hex.EncodeToString(expCtx),
+			L:               testVectorExportLen,
+			ExportedValue: hex.EncodeToString(
+				sealer.Export(expCtx, testVectorExportLen)),
+		}
+	}
+
+	return v, nil
+}
+
+// testVectorIkm returns ikm if it is not nil; otherwise, it derives a seed
+// from the suite, the mode and the label using SHAKE256.
+func (suite Suite) testVectorIkm(ikm []byte, mode uint8, label []byte) []byte {
+	if ikm != nil {
+		return ikm
+	}
+	suiteID := suite.getSuiteID()
+	h := sha3.NewShake256()
+	_, _ = h.Write(testVectorIkmLabel)
+	_, _ = h.Write(suiteID[:])
+	_, _ = h.Write([]byte{mode})
+	_, _ = h.Write(label)
+	ikm = make([]byte, suite.kemID.Scheme().SeedSize())
+	_, _ = h.Read(ikm)
+	return ikm
+}
+
+func marshalHex(k interface{ MarshalBinary() ([]byte, error) }) string {
+	b, _ := k.MarshalBinary()
+	return hex.EncodeToString(b)
+}
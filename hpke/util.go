@@ -2,7 +2,6 @@ package hpke
 
 import (
 	"encoding/binary"
-	"errors"
 	"fmt"
 )
 
@@ -11,14 +10,7 @@ func (st state) keySchedule(ss, info, psk, pskID []byte) (*encdecContext, error)
 		return nil, err
 	}
 
-	pskIDHash := st.labeledExtract(nil, []byte("psk_id_hash"), pskID)
-	infoHash := st.labeledExtract(nil, []byte("info_hash"), info)
-	keySchCtx := append(append(
-		[]byte{st.modeID},
-		pskIDHash...),
-		infoHash...)
-
-	secret := st.labeledExtract(ss, []byte("secret"), psk)
+	keySchCtx, secret := st.scheduleSecret(ss, info, psk, pskID)
 
 	Nk := uint16(st.aeadID.KeySize())
 	key := st.labeledExpand(secret, []byte("key"), keySchCtx, Nk)
@@ -48,20 +40,34 @@ func (st state) keySchedule(ss, info, psk, pskID []byte) (*encdecContext, error)
 	}, nil
 }
 
+// scheduleSecret returns the key schedule context and the secret from which
+// the key, base nonce and exporter secret are expanded.
+func (st state) scheduleSecret(ss, info, psk, pskID []byte) (keySchCtx, secret []byte) {
+	pskIDHash := st.labeledExtract(nil, []byte("psk_id_hash"), pskID)
+	infoHash := st.labeledExtract(nil, []byte("info_hash"), info)
+	keySchCtx = append(append(
+		[]byte{st.modeID},
+		pskIDHash...),
+		infoHash...)
+
+	secret = st.labeledExtract(ss, []byte("secret"), psk)
+	return keySchCtx, secret
+}
+
 func (st state) verifyPSKInputs(psk, pskID []byte) error {
-	gotPSK := psk != nil
-	gotPSKID := pskID != nil
+	gotPSK := len(psk) != 0
+	gotPSKID := len(pskID) != 0
 	if gotPSK != gotPSKID {
-		return errors.New("inconsistent PSK inputs")
+		return ErrInconsistentPSKInputs
 	}
 	switch st.modeID {
-	case modeBase | modeAuth:
+	case modeBase, modeAuth:
 		if gotPSK {
-			return errors.New("PSK input provided when not needed")
+			return ErrUnexpectedPSKInput
 		}
-	case modePSK | modeAuthPSK:
+	case modePSK, modeAuthPSK:
 		if !gotPSK {
-			return errors.New("missing required PSK input")
+			return ErrMissingPSKInput
 		}
 	}
 	return nil
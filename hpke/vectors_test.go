@@ -5,8 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"os"
+	"reflect"
 	"testing"
 
 	"github.com/cloudflare/circl/internal/test"
@@ -15,15 +14,75 @@ import (
 
 func TestVectors(t *testing.T) {
 	// Test vectors from
-	// https://github.com/cfrg/draft-irtf-cfrg-hpke/blob/master/test-vectors.json
-	vectors := readFile(t, "testdata/vectors_v08_779d028.json")
+	// https://github.com/cfrg/draft-irtf-cfrg-hpke/blob/5f503c5/test-vectors.json
+	vectors := readFile(t, "testdata/vectors_rfc9180_5f503c5.json.gz")
 	for i, v := range vectors {
 		t.Run(fmt.Sprintf("v%v", i), v.verify)
 	}
 }
 
-func (v *vector) verify(t *testing.T) {
-	m := v.ModeID
+func TestVectorsK256(t *testing.T) {
+	// Test vectors produced by gen.go.
+	vectors := readFile(t, "testdata/vectors_k256.json.gz")
+	if len(vectors) == 0 {
+		t.Fatal("no test vectors found")
+	}
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("v%v", i), v.verify)
+		t.Run(fmt.Sprintf("gen%v", i), v.regenerate)
+	}
+}
+
+func TestGenerateTestVector(t *testing.T) {
+	vectors := readFile(t, "testdata/vectors_rfc9180_5f503c5.json.gz")
+	for i, v := range vectors {
+		t.Run(fmt.Sprintf("v%v", i), v.regenerate)
+	}
+
+	suite := NewSuite(KEM_K256_HKDF_SHA256, KDF_HKDF_SHA256, AEAD_AES128GCM)
+	for mode := ModeBase; mode <= ModeAuthPSK; mode++ {
+		p := TestVectorParams{Mode: mode, NumEncryptions: 2}
+		v1, err := suite.GenerateTestVector(p)
+		test.CheckNoErr(t, err, "generation failed")
+		v2, err := suite.GenerateTestVector(p)
+		test.CheckNoErr(t, err, "generation failed")
+		if !reflect.DeepEqual(v1, v2) {
+			test.ReportError(t, v1, v2, mode)
+		}
+		v1.verify(t)
+	}
+
+	_, err := suite.GenerateTestVector(TestVectorParams{Mode: 4})
+	test.CheckIsErr(t, err, "should fail with invalid mode")
+	_, err = suite.GenerateTestVector(TestVectorParams{IkmR: []byte{1, 2, 3}})
+	test.CheckIsErr(t, err, "should fail with short ikm")
+}
+
+// regenerate checks that GenerateTestVector reproduces v from its inputs.
+func (v *TestVector) regenerate(t *testing.T) {
+	kem, kdf, aead := KEM(v.KemID), KDF(v.KdfID), AEAD(v.AeadID)
+	if !kem.IsValid() || !kdf.IsValid() || !aead.IsValid() {
+		t.Skipf("Skipping test with unknown suite: %x %x %x", kem, kdf, aead)
+	}
+	s := NewSuite(kem, kdf, aead)
+	got, err := s.GenerateTestVector(TestVectorParams{
+		Mode:           v.Mode,
+		IkmR:           hexB(t, v.IkmR),
+		IkmE:           hexB(t, v.IkmE),
+		IkmS:           hexB(t, v.IkmS),
+		Info:           hexB(t, v.Info),
+		PSK:            hexB(t, v.Psk),
+		PSKID:          hexB(t, v.PskID),
+		NumEncryptions: len(v.Encryptions),
+	})
+	test.CheckNoErr(t, err, "generation failed")
+	if !reflect.DeepEqual(got, v) {
+		test.ReportError(t, got, v, v.Mode, s)
+	}
+}
+
+func (v *TestVector) verify(t *testing.T) {
+	m := v.Mode
 	kem, kdf, aead := KEM(v.KemID), KDF(v.KdfID), AEAD(v.AeadID)
 	if !kem.IsValid() {
 		t.Skipf("Skipping test with unknown KEM: %x", kem)
@@ -46,7 +105,7 @@ func (v *vector) verify(t *testing.T) {
 	v.checkExports(t, opener, m)
 }
 
-func (v *vector) getActors(
+func (v *TestVector) getActors(
 	t *testing.T, dhkem kem.Scheme, s Suite,
 ) (*Sender, *Receiver) {
 	h := s.String() + "\n"
@@ -67,7 +126,7 @@ func (v *vector) getActors(
 	return sender, recv
 }
 
-func (v *vector) setup(t *testing.T, k kem.Scheme,
+func (v *TestVector) setup(t *testing.T, k kem.Scheme,
 	se *Sender, re *Receiver,
 	m modeID, s Suite,
 ) (sealer Sealer, opener Opener) {
@@ -79,7 +138,7 @@ func (v *vector) setup(t *testing.T, k kem.Scheme,
 	var pkS kem.PublicKey
 	var errS, errR, errPK, errSK error
 
-	switch v.ModeID {
+	switch v.Mode {
 	case modeBase:
 		enc, sealer, errS = se.Setup(rd)
 		if errS == nil {
@@ -128,7 +187,7 @@ func (v *vector) setup(t *testing.T, k kem.Scheme,
 	return sealer, opener
 }
 
-func (v *vector) checkAead(t *testing.T, e *encdecContext, m modeID) {
+func (v *TestVector) checkAead(t *testing.T, e *encdecContext, m modeID) {
 	got := e.baseNonce
 	want := hexB(t, v.BaseNonce)
 	if !bytes.Equal(got, want) {
@@ -142,18 +201,21 @@ func (v *vector) checkAead(t *testing.T, e *encdecContext, m modeID) {
 	}
 }
 
-func (v *vector) checkEncryptions(
+func (v *TestVector) checkEncryptions(
 	t *testing.T,
 	se Sealer,
 	op Opener,
 	m modeID,
 ) {
 	for j, encv := range v.Encryptions {
-		pt := hexB(t, encv.Plaintext)
+		pt := hexB(t, encv.Pt)
 		aad := hexB(t, encv.Aad)
 
 		ct, err := se.Seal(pt, aad)
 		test.CheckNoErr(t, err, "error on sealing")
+		if want := hexB(t, encv.Ct); !bytes.Equal(ct, want) {
+			test.ReportError(t, ct, want, m, se.Suite(), j)
+		}
 
 		got, err := op.Open(ct, aad)
 		test.CheckNoErr(t, err, "error on opening")
@@ -165,12 +227,12 @@ func (v *vector) checkEncryptions(
 	}
 }
 
-func (v *vector) checkExports(t *testing.T, context Context, m modeID) {
+func (v *TestVector) checkExports(t *testing.T, context Context, m modeID) {
 	for j, expv := range v.Exports {
-		ctx := hexB(t, expv.ExportContext)
-		want := hexB(t, expv.ExportValue)
+		ctx := hexB(t, expv.ExporterContext)
+		want := hexB(t, expv.ExportedValue)
 
-		got := context.Export(ctx, uint(expv.ExportLength))
+		got := context.Export(ctx, expv.L)
 		if !bytes.Equal(got, want) {
 			test.ReportError(t, got, want, m, context.Suite(), j)
 		}
@@ -184,53 +246,15 @@ func hexB(t *testing.T, x string) []byte {
 	return z
 }
 
-func readFile(t *testing.T, fileName string) []vector {
-	jsonFile, err := os.Open(fileName)
+func readFile(t *testing.T, fileName string) []*TestVector {
+	input, err := test.ReadGzip(fileName)
 	if err != nil {
 		t.Fatalf("File %v can not be opened. Error: %v", fileName, err)
 	}
-	defer jsonFile.Close()
-	input, _ := ioutil.ReadAll(jsonFile)
-	var vectors []vector
+	var vectors []*TestVector
 	err = json.Unmarshal(input, &vectors)
 	if err != nil {
 		t.Fatalf("File %v can not be loaded. Error: %v", fileName, err)
 	}
 	return vectors
 }
-
-type vector struct {
-	ModeID             uint8  `json:"mode"`
-	KemID              uint16 `json:"kem_id"`
-	KdfID              uint16 `json:"kdf_id"`
-	AeadID             uint16 `json:"aead_id"`
-	Info               string `json:"info"`
-	IkmR               string `json:"ikmR"`
-	IkmE               string `json:"ikmE"`
-	SkRm               string `json:"skRm"`
-	SkEm               string `json:"skEm"`
-	SkSm               string `json:"skSm"`
-	Psk                string `json:"psk"`
-	PskID              string `json:"psk_id"`
-	PkSm               string `json:"pkSm"`
-	PkRm               string `json:"pkRm"`
-	PkEm               string `json:"pkEm"`
-	Enc                string `json:"enc"`
-	SharedSecret       string `json:"shared_secret"`
-	KeyScheduleContext string `json:"key_schedule_context"`
-	Secret             string `json:"secret"`
-	Key                string `json:"key"`
-	BaseNonce          string `json:"base_nonce"`
-	ExporterSecret     string `json:"exporter_secret"`
-	Encryptions        []struct {
-		Aad        string `json:"aad"`
-		Ciphertext string `json:"ciphertext"`
-		Nonce      string `json:"nonce"`
-		Plaintext  string `json:"plaintext"`
-	} `json:"encryptions"`
-	Exports []struct {
-		ExportContext string `json:"exportContext"`
-		ExportLength  int    `json:"exportLength"`
-		ExportValue   string `json:"exportValue"`
-	} `json:"exports"`
-}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"crypto/rand"
 	"crypto/subtle"
+	"encoding/asn1"
 	"fmt"
 	"io"
 
@@ -23,10 +24,25 @@ func (x xKEM) CiphertextSize() int        { return x.size }
 func (x xKEM) PublicKeySize() int         { return x.size }
 func (x xKEM) EncapsulationSeedSize() int { return x.size }
 
+// Oid returns the identifier of X25519 or X448 keys of RFC 8410, which
+// are also the keys of this KEM.
+func (x xKEM) Oid() asn1.ObjectIdentifier {
+	switch x.size {
+	case x25519.Size:
+		return asn1.ObjectIdentifier{1, 3, 101, 110}
+	case x448.Size:
+		return asn1.ObjectIdentifier{1, 3, 101, 111}
+	}
+	panic(kem.ErrTypeMismatch)
+}
+
 func (x xKEM) sizeDH() int { return x.size }
 func (x xKEM) calcDH(dh []byte, sk kem.PrivateKey, pk kem.PublicKey) error {
-	PK := pk.(*xKEMPubKey)
-	SK := sk.(*xKEMPrivKey)
+	PK, okPK := pk.(*xKEMPubKey)
+	SK, okSK := sk.(*xKEMPrivKey)
+	if !okPK || !okSK || PK.scheme.id != x.id || SK.scheme.id != x.id {
+		return kem.ErrTypeMismatch
+	}
 	switch x.size {
 	case x25519.Size:
 		var ss, sKey, pKey x25519.Key
@@ -71,8 +87,15 @@ func (x xKEM) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 }
 
 func (x xKEM) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	return x.GenerateKeyPairFrom(rand.Reader)
+}
+
+func (x xKEM) GenerateKeyPairFrom(rnd io.Reader) (kem.PublicKey, kem.PrivateKey, error) {
+	if rnd == nil {
+		rnd = rand.Reader
+	}
 	sk := &xKEMPrivKey{scheme: x, priv: make([]byte, x.PrivateKeySize())}
-	_, err := io.ReadFull(rand.Reader, sk.priv)
+	_, err := io.ReadFull(rnd, sk.priv)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -81,21 +104,21 @@ func (x xKEM) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
 
 func (x xKEM) UnmarshalBinaryPrivateKey(data []byte) (kem.PrivateKey, error) {
 	l := x.PrivateKeySize()
-	if len(data) < l {
+	if len(data) != l {
 		return nil, ErrInvalidKEMPrivateKey
 	}
 	sk := &xKEMPrivKey{x, make([]byte, l), nil}
-	copy(sk.priv, data[:l])
+	copy(sk.priv, data)
 	return sk, nil
 }
 
 func (x xKEM) UnmarshalBinaryPublicKey(data []byte) (kem.PublicKey, error) {
 	l := x.PublicKeySize()
-	if len(data) < l {
+	if len(data) != l {
 		return nil, ErrInvalidKEMPublicKey
 	}
 	pk := &xKEMPubKey{x, make([]byte, l)}
-	copy(pk.pub, data[:l])
+	copy(pk.pub, data)
 	return pk, nil
 }
 
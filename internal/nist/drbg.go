@@ -62,3 +62,10 @@ func (g *DRBG) Fill(x []byte) {
 	}
 	g.update(nil)
 }
+
+// Read implements io.Reader. Each call is equivalent to a call to
+// randombytes, so the output depends on how reads are split.
+func (g *DRBG) Read(x []byte) (int, error) {
+	g.Fill(x)
+	return len(x), nil
+}
@@ -36,6 +36,15 @@ func New512() State {
 	return State{rate: 72, outputLen: 64, dsbyte: 0x06}
 }
 
+// NewLegacyKeccak256 creates a new Keccak-256 hash.
+//
+// Only use this function if you require compatibility with an existing
+// cryptosystem that uses non-standard padding, such as Ethereum.  All
+// other users should use New256 instead.
+func NewLegacyKeccak256() State {
+	return State{rate: 136, outputLen: 32, dsbyte: 0x01}
+}
+
 // Sum224 returns the SHA3-224 digest of the data.
 func Sum224(data []byte) (digest [28]byte) {
 	h := New224()
@@ -116,6 +116,21 @@ func TestUnalignedWrite(t *testing.T) {
 	}
 }
 
+// TestLegacyKeccak256 checks Keccak-256 with the original padding, as used
+// by Ethereum.
+func TestLegacyKeccak256(t *testing.T) {
+	for _, v := range []struct{ msg, digest string }{
+		{"", "c5d2460186f7233c927e7db2dcc703c0e500b653ca82273b7bfad8045d85a470"},
+		{"abc", "4e03657aea45a94fc7d47ba826c8d667c0d1e6e33a64a036ec44f58fa12d6c45"},
+	} {
+		d := NewLegacyKeccak256()
+		_, _ = d.Write([]byte(v.msg))
+		if got := hex.EncodeToString(d.Sum(nil)); got != v.digest {
+			t.Errorf("got %s, want %s", got, v.digest)
+		}
+	}
+}
+
 // TestAppend checks that appending works when reallocation is necessary.
 func TestAppend(t *testing.T) {
 	d := New224()
@@ -1,8 +1,16 @@
 package test
 
 import (
+	"bytes"
+	"compress/gzip"
+	"encoding"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -58,3 +66,58 @@ func CheckPanic(f func()) error {
 	f()
 	return hasPanicked
 }
+
+// CheckMarshal checks that x survives a round trip through y, that is,
+// unmarshaling the encoding of x into y yields the same encoding.
+func CheckMarshal(
+	t *testing.T,
+	x, y interface {
+		encoding.BinaryMarshaler
+		encoding.BinaryUnmarshaler
+	},
+) {
+	t.Helper()
+
+	want, err := x.MarshalBinary()
+	CheckNoErr(t, err, fmt.Sprintf("cannot marshal %T = %v", x, x))
+
+	err = y.UnmarshalBinary(want)
+	CheckNoErr(t, err, fmt.Sprintf("cannot unmarshal %T from %x", y, want))
+
+	got, err := y.MarshalBinary()
+	CheckNoErr(t, err, fmt.Sprintf("cannot marshal %T = %v", y, y))
+
+	if !bytes.Equal(got, want) {
+		ReportError(t, got, want, x, y)
+	}
+}
+
+// ReadGzip is like ioutil.ReadFile, but it gunzips the contents of the file.
+func ReadGzip(path string) ([]byte, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadAll(r)
+}
+
+// HexBytes is a byte slice that is encoded in JSON as a hexadecimal string.
+type HexBytes []byte
+
+func (b HexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+func (b *HexBytes) UnmarshalJSON(data []byte) (err error) {
+	var s string
+	if err = json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	*b, err = hex.DecodeString(strings.TrimPrefix(s, "0x"))
+	return err
+}
@@ -0,0 +1,197 @@
+// Package csidh512 implements a key encapsulation mechanism from the
+// CSIDH-512 non-interactive key exchange.
+//
+// The ciphertext is an ephemeral CSIDH public key, and the shared key is
+// obtained with HKDF-Extract using SHA-256:
+//
+//  ss = HKDF-Extract(salt = ct || pk, IKM = CSIDH(esk, pk))
+//
+// so that it is bound to both the ciphertext and the public key of the
+// recipient. Invalid public keys and ciphertexts, that is, curves that are
+// not supersingular, are rejected with an error.
+package csidh512
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"io"
+
+	"github.com/cloudflare/circl/dh/csidh"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/nike"
+	"golang.org/x/crypto/hkdf"
+)
+
+const (
+	// Size of seed for NewKeyFromSeed
+	KeySeedSize = 32
+
+	// Size of seed for EncapsulateTo.
+	EncapsulationSeedSize = 32
+
+	// Size of the established shared key.
+	SharedKeySize = sha256.Size
+
+	// Size of the encapsulated shared key.
+	CiphertextSize = csidh.PublicKeySize
+
+	// Size of a packed public key.
+	PublicKeySize = csidh.PublicKeySize
+
+	// Size of a packed private key.
+	PrivateKeySize = csidh.PrivateKeySize
+)
+
+var dh = csidh.Scheme()
+
+// Type of a CSIDH-512 public key
+type PublicKey struct {
+	pk nike.PublicKey
+}
+
+// Type of a CSIDH-512 private key
+type PrivateKey struct {
+	sk nike.PrivateKey
+}
+
+// NewKeyFromSeed derives a public/private key pair using the given seed.
+//
+// Panics if seed is not of length KeySeedSize.
+func NewKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey) {
+	if len(seed) != KeySeedSize {
+		panic("seed must be of length KeySeedSize")
+	}
+	pk, sk := dh.DeriveKeyPair(seed)
+	return &PublicKey{pk}, &PrivateKey{sk}
+}
+
+// GenerateKeyPair generates public and private keys using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKeyPair(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	var seed [KeySeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err := io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	pk, sk := NewKeyFromSeed(seed[:])
+	return pk, sk, nil
+}
+
+// combiner computes the shared key from the CSIDH shared secret z.
+func combiner(ss, z, ct []byte, pk nike.PublicKey) {
+	ppk, _ := pk.MarshalBinary()
+	salt := make([]byte, 0, CiphertextSize+PublicKeySize)
+	salt = append(append(salt, ct...), ppk...)
+	copy(ss, hkdf.Extract(sha256.New, z, salt))
+}
+
+// EncapsulateTo generates a shared key and ciphertext that contains it
+// for the public key using randomness from seed and writes the shared key
+// to ss and ciphertext to ct. Returns kem.ErrPubKey if the public key is
+// invalid.
+//
+// Panics if ss, ct or seed are not of length SharedKeySize, CiphertextSize
+// and EncapsulationSeedSize respectively.
+func (pk *PublicKey) EncapsulateTo(ct, ss, seed []byte) error {
+	if len(seed) != EncapsulationSeedSize {
+		panic("seed must be of length EncapsulationSeedSize")
+	}
+	if len(ct) != CiphertextSize {
+		panic("ct must be of length CiphertextSize")
+	}
+	if len(ss) != SharedKeySize {
+		panic("ss must be of length SharedKeySize")
+	}
+
+	epk, esk := dh.DeriveKeyPair(seed)
+	z, err := dh.DeriveSecret(esk, pk.pk)
+	if err != nil {
+		return kem.ErrPubKey
+	}
+	pct, _ := epk.MarshalBinary()
+	copy(ct, pct)
+	combiner(ss, z, ct, pk.pk)
+	return nil
+}
+
+// DecapsulateTo computes the shared key which is encapsulated in ct
+// for the private key and writes it to ss. Returns kem.ErrCipherText if
+// the ciphertext is invalid.
+//
+// Panics if ss or ct are not of length SharedKeySize and CiphertextSize
+// respectively.
+func (sk *PrivateKey) DecapsulateTo(ss, ct []byte) error {
+	if len(ct) != CiphertextSize {
+		panic("ct must be of length CiphertextSize")
+	}
+	if len(ss) != SharedKeySize {
+		panic("ss must be of length SharedKeySize")
+	}
+
+	epk, err := dh.UnmarshalBinaryPublicKey(ct)
+	if err != nil {
+		return kem.ErrCipherText
+	}
+	z, err := dh.DeriveSecret(sk.sk, epk)
+	if err != nil {
+		return kem.ErrCipherText
+	}
+	combiner(ss, z, ct, sk.sk.Public())
+	return nil
+}
+
+// Packs pk into the given buffer.
+//
+// Panics if buf is not of length PublicKeySize.
+func (pk *PublicKey) Pack(buf []byte) {
+	if len(buf) != PublicKeySize {
+		panic("buf must be of length PublicKeySize")
+	}
+	b, _ := pk.pk.MarshalBinary()
+	copy(buf, b)
+}
+
+// Unpacks pk from the given buffer. Returns kem.ErrPubKey if buf does not
+// encode a curve coefficient.
+//
+// Panics if buf is not of length PublicKeySize.
+func (pk *PublicKey) Unpack(buf []byte) error {
+	if len(buf) != PublicKeySize {
+		panic("buf must be of length PublicKeySize")
+	}
+	p, err := dh.UnmarshalBinaryPublicKey(buf)
+	if err != nil {
+		return kem.ErrPubKey
+	}
+	pk.pk = p
+	return nil
+}
+
+// Packs sk into the given buffer.
+//
+// Panics if buf is not of length PrivateKeySize.
+func (sk *PrivateKey) Pack(buf []byte) {
+	if len(buf) != PrivateKeySize {
+		panic("buf must be of length PrivateKeySize")
+	}
+	b, _ := sk.sk.MarshalBinary()
+	copy(buf, b)
+}
+
+// Unpacks sk from the given buffer, which requires computing the public
+// key. Returns kem.ErrPrivKey if an exponent is out of range.
+//
+// Panics if buf is not of length PrivateKeySize.
+func (sk *PrivateKey) Unpack(buf []byte) error {
+	if len(buf) != PrivateKeySize {
+		panic("buf must be of length PrivateKeySize")
+	}
+	s, err := dh.UnmarshalBinaryPrivateKey(buf)
+	if err != nil {
+		return kem.ErrPrivKey
+	}
+	sk.sk = s
+	return nil
+}
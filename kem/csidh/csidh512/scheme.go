@@ -0,0 +1,147 @@
+package csidh512
+
+import (
+	cryptoRand "crypto/rand"
+	"io"
+
+	"github.com/cloudflare/circl/kem"
+)
+
+// This file contains the boilerplate code to connect CSIDH-512 to the
+// generic KEM API.
+
+// Scheme returns a KEM interface.
+func Scheme() kem.Scheme { return scheme{} }
+
+type scheme struct{}
+
+func (scheme) Name() string               { return "CSIDH-512" }
+func (scheme) PublicKeySize() int         { return PublicKeySize }
+func (scheme) PrivateKeySize() int        { return PrivateKeySize }
+func (scheme) SeedSize() int              { return KeySeedSize }
+func (scheme) EncapsulationSeedSize() int { return EncapsulationSeedSize }
+func (scheme) SharedKeySize() int         { return SharedKeySize }
+func (scheme) CiphertextSize() int        { return CiphertextSize }
+func (*PrivateKey) Scheme() kem.Scheme    { return scheme{} }
+func (*PublicKey) Scheme() kem.Scheme     { return scheme{} }
+
+func (sch scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return sch.EncapsulateFrom(cryptoRand.Reader, pk)
+}
+
+func (sch scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error) {
+	var seed [EncapsulationSeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err = io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	return sch.EncapsulateDeterministically(pk, seed[:])
+}
+
+func (scheme) EncapsulateDeterministically(
+	pk kem.PublicKey, seed []byte,
+) ([]byte, []byte, error) {
+	if len(seed) != EncapsulationSeedSize {
+		return nil, nil, kem.ErrSeedSize
+	}
+	pub, ok := pk.(*PublicKey)
+	if !ok {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+	var (
+		ct [CiphertextSize]byte
+		ss [SharedKeySize]byte
+	)
+	if err := pub.EncapsulateTo(ct[:], ss[:], seed); err != nil {
+		return nil, nil, err
+	}
+	return ct[:], ss[:], nil
+}
+
+func (scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
+	var pk PublicKey
+	if len(buf) != PublicKeySize {
+		return nil, kem.ErrPubKeySize
+	}
+	if err := pk.Unpack(buf); err != nil {
+		return nil, err
+	}
+	return &pk, nil
+}
+
+func (scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
+	var sk PrivateKey
+	if len(buf) != PrivateKeySize {
+		return nil, kem.ErrPrivKeySize
+	}
+	if err := sk.Unpack(buf); err != nil {
+		return nil, err
+	}
+	return &sk, nil
+}
+
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	var ret [PrivateKeySize]byte
+	sk.Pack(ret[:])
+	return ret[:], nil
+}
+
+func (sk *PrivateKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return sk.sk.Equal(oth.sk)
+}
+
+func (sk *PrivateKey) Public() kem.PublicKey {
+	return &PublicKey{sk.sk.Public()}
+}
+
+func (pk *PublicKey) Equal(other kem.PublicKey) bool {
+	oth, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.pk.Equal(oth.pk)
+}
+
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	var ret [PublicKeySize]byte
+	pk.Pack(ret[:])
+	return ret[:], nil
+}
+
+func (scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != KeySeedSize {
+		panic(kem.ErrSeedSize)
+	}
+	return NewKeyFromSeed(seed)
+}
+
+func (scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(nil)
+}
+
+func (scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
+func (scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	if len(ct) != CiphertextSize {
+		return nil, kem.ErrCiphertextSize
+	}
+	priv, ok := sk.(*PrivateKey)
+	if !ok {
+		return nil, kem.ErrTypeMismatch
+	}
+	var ss [SharedKeySize]byte
+	if err := priv.DecapsulateTo(ss[:], ct); err != nil {
+		return nil, err
+	}
+	return ss[:], nil
+}
@@ -0,0 +1,76 @@
+package csidh_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/csidh"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/csidh/csidh512"
+	"golang.org/x/crypto/hkdf"
+)
+
+// TestCombiner checks the shared key against its definition from the
+// CSIDH NIKE.
+func TestCombiner(t *testing.T) {
+	sch := csidh512.Scheme()
+	dh := csidh.Scheme()
+
+	pk, sk := sch.DeriveKeyPair(make([]byte, sch.SeedSize()))
+	ct, ss, err := sch.Encapsulate(pk)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+
+	psk, _ := sk.MarshalBinary()
+	dhSk, err := dh.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	dhCt, err := dh.UnmarshalBinaryPublicKey(ct)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	z, err := dh.DeriveSecret(dhSk, dhCt)
+	test.CheckNoErr(t, err, "DeriveSecret failed")
+
+	ppk, _ := pk.MarshalBinary()
+	want := hkdf.Extract(sha256.New, z, append(append([]byte{}, ct...), ppk...))
+	if !bytes.Equal(ss, want) {
+		test.ReportError(t, ss, want)
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	sch := csidh512.Scheme()
+	_, sk, err := sch.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+
+	// A random coefficient defines an ordinary curve with overwhelming
+	// probability.
+	buf := make([]byte, csidh512.PublicKeySize)
+	for i := range buf[:len(buf)-1] {
+		buf[i] = byte(7*i + 1)
+	}
+
+	pk, err := sch.UnmarshalBinaryPublicKey(buf)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	if _, _, err = sch.Encapsulate(pk); err != kem.ErrPubKey {
+		test.ReportError(t, err, kem.ErrPubKey)
+	}
+	if _, err = sch.Decapsulate(sk, buf); err != kem.ErrCipherText {
+		test.ReportError(t, err, kem.ErrCipherText)
+	}
+
+	for i := range buf {
+		buf[i] = 0xFF
+	}
+	if _, err = sch.UnmarshalBinaryPublicKey(buf); err != kem.ErrPubKey {
+		test.ReportError(t, err, kem.ErrPubKey)
+	}
+	if _, err = sch.Decapsulate(sk, buf); err != kem.ErrCipherText {
+		test.ReportError(t, err, kem.ErrCipherText)
+	}
+
+	psk := make([]byte, csidh512.PrivateKeySize)
+	psk[0] = 0x08
+	if _, err = sch.UnmarshalBinaryPrivateKey(psk); err != kem.ErrPrivKey {
+		test.ReportError(t, err, kem.ErrPrivKey)
+	}
+}
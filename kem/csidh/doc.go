@@ -0,0 +1,10 @@
+// Package csidh provides a key encapsulation mechanism built from the
+// CSIDH non-interactive key exchange.
+//
+// The CSIDH group action is implemented in github.com/cloudflare/circl/dh/csidh,
+// which is highly experimental work and not suitable for securing systems.
+//
+// References:
+//  [1] CSIDH: https://ia.cr/2018/383
+//  [2] HKDF:  https://rfc-editor.org/rfc/rfc5869.txt
+package csidh
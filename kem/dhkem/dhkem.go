@@ -0,0 +1,351 @@
+// Package dhkem implements the DHKEM construction of RFC 9180 on top of
+// any non-interactive key exchange of github.com/cloudflare/circl/nike.
+//
+// The shared key is derived from the Diffie-Hellman shared secret with the
+// labeled HKDF functions of HPKE, and the ciphertext is an ephemeral public
+// key. Keys are derived from seeds with the DeriveKeyPair function of
+// RFC 9180, whose output is used as the seed of the DeriveKeyPair function
+// of the NIKE. Hence, the schemes
+//
+//  New("DHKEM(X25519, HKDF-SHA256)", x25519.Scheme(), 0x0020, crypto.SHA256)
+//  New("DHKEM(X448, HKDF-SHA512)", x448.Scheme(), 0x0021, crypto.SHA512)
+//
+// are the ones of HPKE, see github.com/cloudflare/circl/hpke.
+//
+// References:
+//  [1] RFC 9180: https://www.rfc-editor.org/rfc/rfc9180.html
+package dhkem
+
+import (
+	"crypto"
+	cryptoRand "crypto/rand"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/nike"
+	"golang.org/x/crypto/hkdf"
+)
+
+// ErrInvalidScheme is returned by New if the NIKE or the hash function is
+// missing, or if the seeds of the NIKE are too large to be derived with
+// HKDF-Expand.
+var ErrInvalidScheme = errors.New("invalid DHKEM parameters")
+
+const versionLabel = "HPKE-v1"
+
+type scheme struct {
+	name string
+	dh   nike.Scheme
+	id   uint16
+	hash crypto.Hash
+}
+
+type publicKey struct {
+	sch *scheme
+	pk  nike.PublicKey
+}
+
+type privateKey struct {
+	sch *scheme
+	sk  nike.PrivateKey
+}
+
+// New returns the DHKEM with the given name built on dh, with the KEM
+// identifier id and HKDF instantiated with hash.
+//
+// The identifier is only used for domain separation, and should be the
+// codepoint registered for HPKE, if any. Returns ErrInvalidScheme if dh is
+// nil, the name is empty, hash is not available, or the seeds of dh are
+// empty or larger than the output of HKDF-Expand.
+func New(name string, dh nike.Scheme, id uint16, hash crypto.Hash) (
+	kem.AuthScheme, error) {
+	if name == "" || dh == nil || !hash.Available() {
+		return nil, ErrInvalidScheme
+	}
+	if dh.SeedSize() <= 0 || dh.SeedSize() > 255*hash.Size() {
+		return nil, ErrInvalidScheme
+	}
+	return &scheme{name, dh, id, hash}, nil
+}
+
+func (s *scheme) Name() string               { return s.name }
+func (s *scheme) PublicKeySize() int         { return s.dh.PublicKeySize() }
+func (s *scheme) PrivateKeySize() int        { return s.dh.PrivateKeySize() }
+func (s *scheme) SeedSize() int              { return s.dh.SeedSize() }
+func (s *scheme) EncapsulationSeedSize() int { return s.dh.SeedSize() }
+func (s *scheme) SharedKeySize() int         { return s.hash.Size() }
+func (s *scheme) CiphertextSize() int        { return s.dh.PublicKeySize() }
+func (s *scheme) HPKEIdentifier() uint       { return uint(s.id) }
+
+func (pk *publicKey) Scheme() kem.Scheme  { return pk.sch }
+func (sk *privateKey) Scheme() kem.Scheme { return sk.sch }
+
+func (s *scheme) suiteID() (sid [5]byte) {
+	sid[0], sid[1], sid[2] = 'K', 'E', 'M'
+	binary.BigEndian.PutUint16(sid[3:5], s.id)
+	return
+}
+
+func (s *scheme) labeledExtract(salt, label, ikm []byte) []byte {
+	suiteID := s.suiteID()
+	labeledIKM := append(append(append(append(
+		make([]byte, 0, len(versionLabel)+len(suiteID)+len(label)+len(ikm)),
+		versionLabel...),
+		suiteID[:]...),
+		label...),
+		ikm...)
+	return hkdf.Extract(s.hash.New, labeledIKM, salt)
+}
+
+func (s *scheme) labeledExpand(prk, label, info []byte, l uint16) []byte {
+	suiteID := s.suiteID()
+	labeledInfo := make(
+		[]byte,
+		2,
+		2+len(versionLabel)+len(suiteID)+len(label)+len(info),
+	)
+	binary.BigEndian.PutUint16(labeledInfo[0:2], l)
+	labeledInfo = append(append(append(append(labeledInfo,
+		versionLabel...),
+		suiteID[:]...),
+		label...),
+		info...)
+	b := make([]byte, l)
+	rd := hkdf.Expand(s.hash.New, prk, labeledInfo)
+	if _, err := io.ReadFull(rd, b); err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func (s *scheme) extractAndExpand(dh, kemCtx []byte) []byte {
+	eaePrk := s.labeledExtract(nil, []byte("eae_prk"), dh)
+	return s.labeledExpand(
+		eaePrk,
+		[]byte("shared_secret"),
+		kemCtx,
+		uint16(s.hash.Size()),
+	)
+}
+
+func (s *scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != s.SeedSize() {
+		panic(kem.ErrSeedSize)
+	}
+	dkpPrk := s.labeledExtract(nil, []byte("dkp_prk"), seed)
+	ikm := s.labeledExpand(dkpPrk, []byte("sk"), nil, uint16(s.dh.SeedSize()))
+	pk, sk := s.dh.DeriveKeyPair(ikm)
+	return &publicKey{s, pk}, &privateKey{s, sk}
+}
+
+func (s *scheme) readSeed(rand io.Reader) ([]byte, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	seed := make([]byte, s.SeedSize())
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+func (s *scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	return s.GenerateKeyPairFrom(nil)
+}
+
+func (s *scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error) {
+	seed, err := s.readSeed(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, sk := s.DeriveKeyPair(seed)
+	return pk, sk, nil
+}
+
+func (s *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return s.EncapsulateFrom(nil, pk)
+}
+
+func (s *scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error) {
+	seed, err := s.readSeed(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.EncapsulateDeterministically(pk, seed)
+}
+
+func (s *scheme) EncapsulateDeterministically(pk kem.PublicKey, seed []byte) (
+	ct, ss []byte, err error) {
+	return s.encap(pk, nil, seed)
+}
+
+func (s *scheme) AuthEncapsulate(pkr kem.PublicKey, sks kem.PrivateKey) (
+	ct, ss []byte, err error) {
+	return s.AuthEncapsulateFrom(nil, pkr, sks)
+}
+
+func (s *scheme) AuthEncapsulateFrom(
+	rand io.Reader, pkr kem.PublicKey, sks kem.PrivateKey,
+) (ct, ss []byte, err error) {
+	seed, err := s.readSeed(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s.AuthEncapsulateDeterministically(pkr, sks, seed)
+}
+
+func (s *scheme) AuthEncapsulateDeterministically(
+	pkr kem.PublicKey, sks kem.PrivateKey, seed []byte,
+) (ct, ss []byte, err error) {
+	if sks == nil {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+	return s.encap(pkr, sks, seed)
+}
+
+// encap implements Encap of RFC 9180, and AuthEncap if sks is not nil.
+func (s *scheme) encap(pkr kem.PublicKey, sks kem.PrivateKey, seed []byte) (
+	ct, ss []byte, err error) {
+	if len(seed) != s.EncapsulationSeedSize() {
+		return nil, nil, kem.ErrSeedSize
+	}
+	pkR, ok := pkr.(*publicKey)
+	if !ok || pkR.sch != s {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+	var skS *privateKey
+	if sks != nil {
+		if skS, ok = sks.(*privateKey); !ok || skS.sch != s {
+			return nil, nil, kem.ErrTypeMismatch
+		}
+	}
+
+	pkE, skE := s.DeriveKeyPair(seed)
+	dh, err := s.dh.DeriveSecret(skE.(*privateKey).sk, pkR.pk)
+	if err != nil {
+		return nil, nil, kem.ErrPubKey
+	}
+	enc, _ := pkE.MarshalBinary()
+	pkRm, _ := pkR.pk.MarshalBinary()
+	kemCtx := append(append([]byte{}, enc...), pkRm...)
+
+	if skS != nil {
+		dhS, err := s.dh.DeriveSecret(skS.sk, pkR.pk)
+		if err != nil {
+			return nil, nil, kem.ErrPubKey
+		}
+		dh = append(dh, dhS...)
+		pkSm, _ := skS.sk.Public().MarshalBinary()
+		kemCtx = append(kemCtx, pkSm...)
+	}
+
+	return enc, s.extractAndExpand(dh, kemCtx), nil
+}
+
+func (s *scheme) Decapsulate(skr kem.PrivateKey, ct []byte) ([]byte, error) {
+	return s.decap(skr, ct, nil)
+}
+
+func (s *scheme) AuthDecapsulate(
+	skr kem.PrivateKey, ct []byte, pks kem.PublicKey,
+) ([]byte, error) {
+	if pks == nil {
+		return nil, kem.ErrTypeMismatch
+	}
+	return s.decap(skr, ct, pks)
+}
+
+// decap implements Decap of RFC 9180, and AuthDecap if pks is not nil.
+func (s *scheme) decap(skr kem.PrivateKey, ct []byte, pks kem.PublicKey) (
+	[]byte, error) {
+	if len(ct) != s.CiphertextSize() {
+		return nil, kem.ErrCiphertextSize
+	}
+	skR, ok := skr.(*privateKey)
+	if !ok || skR.sch != s {
+		return nil, kem.ErrTypeMismatch
+	}
+	var pkS *publicKey
+	if pks != nil {
+		if pkS, ok = pks.(*publicKey); !ok || pkS.sch != s {
+			return nil, kem.ErrTypeMismatch
+		}
+	}
+
+	pkE, err := s.dh.UnmarshalBinaryPublicKey(ct)
+	if err != nil {
+		return nil, kem.ErrCipherText
+	}
+	dh, err := s.dh.DeriveSecret(skR.sk, pkE)
+	if err != nil {
+		return nil, kem.ErrCipherText
+	}
+	pkRm, _ := skR.sk.Public().MarshalBinary()
+	kemCtx := append(append([]byte{}, ct...), pkRm...)
+
+	if pkS != nil {
+		dhS, err := s.dh.DeriveSecret(skR.sk, pkS.pk)
+		if err != nil {
+			return nil, kem.ErrPubKey
+		}
+		dh = append(dh, dhS...)
+		pkSm, _ := pkS.pk.MarshalBinary()
+		kemCtx = append(kemCtx, pkSm...)
+	}
+
+	return s.extractAndExpand(dh, kemCtx), nil
+}
+
+func (s *scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
+	if len(buf) != s.PublicKeySize() {
+		return nil, kem.ErrPubKeySize
+	}
+	pk, err := s.dh.UnmarshalBinaryPublicKey(buf)
+	if err != nil {
+		return nil, kem.ErrPubKey
+	}
+	return &publicKey{s, pk}, nil
+}
+
+func (s *scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
+	if len(buf) != s.PrivateKeySize() {
+		return nil, kem.ErrPrivKeySize
+	}
+	sk, err := s.dh.UnmarshalBinaryPrivateKey(buf)
+	if err != nil {
+		return nil, kem.ErrPrivKey
+	}
+	return &privateKey{s, sk}, nil
+}
+
+func (pk *publicKey) MarshalBinary() ([]byte, error) {
+	return pk.pk.MarshalBinary()
+}
+
+func (pk *publicKey) Equal(other kem.PublicKey) bool {
+	oth, ok := other.(*publicKey)
+	if !ok || oth.sch != pk.sch {
+		return false
+	}
+	return pk.pk.Equal(oth.pk)
+}
+
+func (sk *privateKey) MarshalBinary() ([]byte, error) {
+	return sk.sk.MarshalBinary()
+}
+
+func (sk *privateKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*privateKey)
+	if !ok || oth.sch != sk.sch {
+		return false
+	}
+	return sk.sk.Equal(oth.sk)
+}
+
+func (sk *privateKey) Public() kem.PublicKey {
+	return &publicKey{sk.sch, sk.sk.Public()}
+}
@@ -0,0 +1,181 @@
+package dhkem_test
+
+import (
+	"bytes"
+	"crypto"
+	_ "crypto/sha256"
+	_ "crypto/sha512"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/dh/x448"
+	"github.com/cloudflare/circl/hpke"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/dhkem"
+	"github.com/cloudflare/circl/nike"
+	nikeSchemes "github.com/cloudflare/circl/nike/schemes"
+)
+
+func TestNewErrors(t *testing.T) {
+	dh := x25519.Scheme()
+	for _, c := range []struct {
+		name string
+		dh   nike.Scheme
+		hash crypto.Hash
+	}{
+		{"", dh, crypto.SHA256},
+		{"DHKEM", nil, crypto.SHA256},
+		{"DHKEM", dh, crypto.MD4},
+	} {
+		if _, err := dhkem.New(c.name, c.dh, 0x0020, c.hash); err != dhkem.ErrInvalidScheme {
+			test.ReportError(t, err, dhkem.ErrInvalidScheme, c.name, c.hash)
+		}
+	}
+}
+
+func newReader(seed string) *sha3.State {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(seed))
+	return &h
+}
+
+func mustMarshal(t *testing.T, k interface{ MarshalBinary() ([]byte, error) }) []byte {
+	b, err := k.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	return b
+}
+
+// TestHPKE checks that the DHKEMs built on X25519 and X448 are the ones of
+// HPKE.
+func TestHPKE(t *testing.T) {
+	for _, c := range []struct {
+		dh   nike.Scheme
+		id   hpke.KEM
+		hash crypto.Hash
+	}{
+		{x25519.Scheme(), hpke.KEM_X25519_HKDF_SHA256, crypto.SHA256},
+		{x448.Scheme(), hpke.KEM_X448_HKDF_SHA512, crypto.SHA512},
+	} {
+		want := c.id.Scheme().(kem.AuthScheme)
+		got, err := dhkem.New(want.Name(), c.dh, uint16(c.id), c.hash)
+		test.CheckNoErr(t, err, "New failed")
+		if got.SeedSize() != want.SeedSize() ||
+			got.EncapsulationSeedSize() != want.EncapsulationSeedSize() ||
+			got.PublicKeySize() != want.PublicKeySize() ||
+			got.PrivateKeySize() != want.PrivateKeySize() ||
+			got.CiphertextSize() != want.CiphertextSize() ||
+			got.SharedKeySize() != want.SharedKeySize() {
+			t.Fatal("sizes do not match")
+		}
+
+		h := newReader(want.Name())
+		seedR := make([]byte, got.SeedSize())
+		seedS := make([]byte, got.SeedSize())
+		seedE := make([]byte, got.EncapsulationSeedSize())
+		_, _ = h.Read(seedR)
+		_, _ = h.Read(seedS)
+		_, _ = h.Read(seedE)
+
+		pkR, skR := got.DeriveKeyPair(seedR)
+		pkS, skS := got.DeriveKeyPair(seedS)
+		wantPkR, wantSkR := want.DeriveKeyPair(seedR)
+		wantPkS, wantSkS := want.DeriveKeyPair(seedS)
+		if !bytes.Equal(mustMarshal(t, pkR), mustMarshal(t, wantPkR)) ||
+			!bytes.Equal(mustMarshal(t, skR), mustMarshal(t, wantSkR)) ||
+			!bytes.Equal(mustMarshal(t, pkS), mustMarshal(t, wantPkS)) {
+			t.Fatal("DeriveKeyPair does not match")
+		}
+
+		ct, ss, err := got.EncapsulateDeterministically(pkR, seedE)
+		test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+		wantCt, wantSs, err := want.EncapsulateDeterministically(wantPkR, seedE)
+		test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+		if !bytes.Equal(ct, wantCt) || !bytes.Equal(ss, wantSs) {
+			t.Fatal("Encapsulate does not match")
+		}
+		ss2, err := got.Decapsulate(skR, ct)
+		test.CheckNoErr(t, err, "Decapsulate failed")
+		if !bytes.Equal(ss2, ss) {
+			t.Fatal("Decapsulate failed")
+		}
+
+		ct, ss, err = got.AuthEncapsulateDeterministically(pkR, skS, seedE)
+		test.CheckNoErr(t, err, "AuthEncapsulateDeterministically failed")
+		wantCt, wantSs, err = want.AuthEncapsulateDeterministically(wantPkR, wantSkS, seedE)
+		test.CheckNoErr(t, err, "AuthEncapsulateDeterministically failed")
+		if !bytes.Equal(ct, wantCt) || !bytes.Equal(ss, wantSs) {
+			t.Fatal("AuthEncapsulate does not match")
+		}
+		ss2, err = got.AuthDecapsulate(skR, ct, pkS)
+		test.CheckNoErr(t, err, "AuthDecapsulate failed")
+		if !bytes.Equal(ss2, ss) {
+			t.Fatal("AuthDecapsulate failed")
+		}
+		ss2, err = got.Decapsulate(skR, ct)
+		test.CheckNoErr(t, err, "Decapsulate failed")
+		if bytes.Equal(ss2, ss) {
+			t.Fatal("AuthEncapsulate does not authenticate the sender")
+		}
+	}
+}
+
+// TestAllNIKEs checks the DHKEM of every registered NIKE.
+func TestAllNIKEs(t *testing.T) {
+	for _, dh := range nikeSchemes.All() {
+		dh := dh
+		t.Run(dh.Name(), func(t *testing.T) {
+			sch, err := dhkem.New("DHKEM("+dh.Name()+")", dh, 0xFFFF, crypto.SHA256)
+			test.CheckNoErr(t, err, "New failed")
+
+			pkR, skR, err := sch.GenerateKeyPairFrom(newReader("R"))
+			test.CheckNoErr(t, err, "GenerateKeyPairFrom failed")
+			pkS, skS, err := sch.GenerateKeyPair()
+			test.CheckNoErr(t, err, "GenerateKeyPair failed")
+			if !skR.Public().Equal(pkR) || pkR.Equal(pkS) || skR.Equal(skS) {
+				t.Fatal("inconsistent key pairs")
+			}
+
+			ppk := mustMarshal(t, pkR)
+			psk := mustMarshal(t, skR)
+			if len(ppk) != sch.PublicKeySize() || len(psk) != sch.PrivateKeySize() {
+				t.Fatal("wrong key size")
+			}
+			pkR2, err := sch.UnmarshalBinaryPublicKey(ppk)
+			test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+			skR2, err := sch.UnmarshalBinaryPrivateKey(psk)
+			test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+			if !pkR.Equal(pkR2) || !skR.Equal(skR2) {
+				t.Fatal("keys do not round-trip")
+			}
+
+			ct, ss, err := sch.Encapsulate(pkR2)
+			test.CheckNoErr(t, err, "Encapsulate failed")
+			if len(ct) != sch.CiphertextSize() || len(ss) != sch.SharedKeySize() {
+				t.Fatal("wrong ciphertext or shared key size")
+			}
+			ss2, err := sch.Decapsulate(skR2, ct)
+			test.CheckNoErr(t, err, "Decapsulate failed")
+			if !bytes.Equal(ss, ss2) {
+				t.Fatal("shared keys do not match")
+			}
+
+			ct, ss, err = sch.AuthEncapsulate(pkR, skS)
+			test.CheckNoErr(t, err, "AuthEncapsulate failed")
+			ss2, err = sch.AuthDecapsulate(skR, ct, pkS)
+			test.CheckNoErr(t, err, "AuthDecapsulate failed")
+			if !bytes.Equal(ss, ss2) {
+				t.Fatal("shared keys do not match")
+			}
+
+			if _, err = sch.Decapsulate(skR, ct[1:]); err != kem.ErrCiphertextSize {
+				test.ReportError(t, err, kem.ErrCiphertextSize)
+			}
+			other, _ := dhkem.New(sch.Name(), dh, 0xFFFF, crypto.SHA256)
+			if _, _, err = other.Encapsulate(pkR); err != kem.ErrTypeMismatch {
+				test.ReportError(t, err, kem.ErrTypeMismatch)
+			}
+		})
+	}
+}
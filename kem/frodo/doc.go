@@ -1,9 +1,16 @@
+//go:generate go run gen.go
+
 // Package frodo provides the key encapsulation mechanism FrodoKEM.
 //
 // Compatible with the implementation submitted to round 3 of the
 // NIST PQC competition [1]. This implementation draws heavily from the PQClean
 // implementation [2].
 //
+// The parameter sets FrodoKEM-640, FrodoKEM-976 and FrodoKEM-1344 are
+// provided, each with the matrix A generated either with SHAKE128 or with
+// AES-128. The packages of each variant are generated from the templates
+// in the templates directory by gen.go.
+//
 // References:
 //  [1] https://frodokem.org/files/FrodoKEM-specification-20210604.pdf
 //  [2] https://github.com/PQClean/PQClean/tree/master/crypto_kem/frodokem640shake/clean
@@ -0,0 +1,130 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the packages of the FrodoKEM parameter sets from templates
+// to prevent too much duplicated code between them.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	// Number of rows of the matrix A, denoted by 'n' in the FrodoKEM spec.
+	N int
+	// Base-2 logarithm of the modulus q.
+	LogQ int
+	// Number of bits extracted per entry, denoted by 'B' in the FrodoKEM spec.
+	ExtractedBits int
+	// Security parameter in bytes. Denoted by 'len_sec' in the FrodoKEM spec.
+	SecBytes int
+	// Either "SHAKE" or "AES", the primitive used to generate A.
+	Variant  string
+	CDFTable []int
+}
+
+func (m Instance) Name() string {
+	return fmt.Sprintf("FrodoKEM-%d-%s", m.N, m.Variant)
+}
+
+func (m Instance) Pkg() string {
+	return fmt.Sprintf("frodo%d%s", m.N, strings.ToLower(m.Variant))
+}
+
+func (m Instance) AES() bool { return m.Variant == "AES" }
+
+// Xof returns the SHAKE function used for hashing and sampling: SHAKE128
+// for FrodoKEM-640, and SHAKE256 for the other parameter sets.
+func (m Instance) Xof() string {
+	if m.N == 640 {
+		return "Shake128"
+	}
+	return "Shake256"
+}
+
+func (m Instance) MessageSize() int { return m.ExtractedBits * 8 }
+
+func (m Instance) CiphertextSize() int {
+	return (m.LogQ*m.N*8 + m.LogQ*8*8) / 8
+}
+
+func (m Instance) PublicKeySize() int { return 16 + m.LogQ*m.N*8/8 }
+
+func (m Instance) PrivateKeySize() int {
+	return m.SecBytes + m.PublicKeySize() + 2*m.N*8 + m.SecBytes
+}
+
+func (m Instance) CDFTableString() string {
+	s := make([]string, len(m.CDFTable))
+	for i, v := range m.CDFTable {
+		s[i] = fmt.Sprint(v)
+	}
+	return strings.Join(s, ", ")
+}
+
+var (
+	cdf640  = []int{4643, 13363, 20579, 25843, 29227, 31145, 32103, 32525, 32689, 32745, 32762, 32766, 32767}
+	cdf976  = []int{5638, 15915, 23689, 28571, 31116, 32217, 32613, 32731, 32760, 32766, 32767}
+	cdf1344 = []int{9142, 23462, 30338, 32361, 32725, 32765, 32767}
+
+	Instances = []Instance{
+		{N: 640, LogQ: 15, ExtractedBits: 2, SecBytes: 16, Variant: "SHAKE", CDFTable: cdf640},
+		{N: 976, LogQ: 16, ExtractedBits: 3, SecBytes: 24, Variant: "SHAKE", CDFTable: cdf976},
+		{N: 1344, LogQ: 16, ExtractedBits: 4, SecBytes: 32, Variant: "SHAKE", CDFTable: cdf1344},
+		{N: 640, LogQ: 15, ExtractedBits: 2, SecBytes: 16, Variant: "AES", CDFTable: cdf640},
+		{N: 976, LogQ: 16, ExtractedBits: 3, SecBytes: 24, Variant: "AES", CDFTable: cdf976},
+		{N: 1344, LogQ: 16, ExtractedBits: 4, SecBytes: 32, Variant: "AES", CDFTable: cdf1344},
+	}
+	Templates = []string{
+		"frodo.templ.go",
+		"matrix.templ.go",
+		"noise.templ.go",
+		"util.templ.go",
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/name.go from templates/name.templ.go
+func generatePackageFiles() {
+	for _, file := range Templates {
+		tl, err := template.ParseFiles(path.Join("templates", file))
+		if err != nil {
+			panic(err)
+		}
+
+		for _, mode := range Instances {
+			buf := new(bytes.Buffer)
+			err := tl.Execute(buf, mode)
+			if err != nil {
+				panic(err)
+			}
+
+			// Formating output code
+			code, err := format.Source(buf.Bytes())
+			if err != nil {
+				panic(fmt.Sprintf("error formating code: %v", err))
+			}
+
+			res := string(code)
+			offset := strings.Index(res, TemplateWarning)
+			if offset == -1 {
+				panic("Missing template warning in " + file)
+			}
+			out := path.Join(mode.Pkg(), strings.ReplaceAll(file, ".templ", ""))
+			err = ioutil.WriteFile(out, []byte(res[offset:]), 0o644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
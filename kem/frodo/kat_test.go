@@ -30,6 +30,29 @@ func TestPQCgenKATKem(t *testing.T) {
 	}
 }
 
+// TestKATRegression checks the digests of the test vectors of the other
+// parameter sets, as generated by this package.  Unlike the one above,
+// they are not taken from the reference implementation, and only catch
+// changes to the output of this package.
+func TestKATRegression(t *testing.T) {
+	kats := []struct {
+		name string
+		want string
+	}{
+		{"FrodoKEM-976-SHAKE", "32b0ad60047273fb52696f0516acac7ed083e31f5478b416d579ae5e8d8e734c"},
+		{"FrodoKEM-1344-SHAKE", "591adc09a718afbc0ac36e1f57a191e557fe4eec7899e078104b9706b75e2f96"},
+		{"FrodoKEM-640-AES", "d1e69503e9042f9484b6e01a466865baa607471c63d7e45d2409f639ba161206"},
+		{"FrodoKEM-976-AES", "32ed6b1622c845b487c3170ce6878df7baae07e90bd2819a19e5960ce04a55f7"},
+		{"FrodoKEM-1344-AES", "9756f7c8cc88d7048ff6e81fa66425bb1392e35c1d30016c190dba17de15221a"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.name, kat.want)
+		})
+	}
+}
+
 func testPQCgenKATKem(t *testing.T, name, expected string) {
 	scheme := schemes.ByName(name)
 	if scheme == nil {
@@ -71,7 +94,7 @@ func testPQCgenKATKem(t *testing.T, name, expected string) {
 		fmt.Fprintf(f, "ct = %X\n", ct)
 		fmt.Fprintf(f, "ss = %X\n\n", ss)
 	}
-	if fmt.Sprintf("%x", f.Sum(nil)) != expected {
-		t.Fatal()
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("got %s, want %s", got, expected)
 	}
 }
@@ -0,0 +1,17 @@
+//go:generate go run gen.go
+
+// Package hqc provides the key encapsulation mechanism HQC.
+//
+// Compatible with the reference implementation of the version of
+// 2023-04-30 submitted to round 4 of the NIST PQC competition [1]. As in
+// that specification, decapsulation failures are handled by implicit
+// rejection: the shared key is then derived from the secret sigma in place
+// of the decrypted message, in constant time.
+//
+// The parameter sets HQC-128, HQC-192 and HQC-256 are provided. The
+// packages of each parameter set are generated from the templates in the
+// templates directory by gen.go.
+//
+// References:
+//  [1] https://pqc-hqc.org/doc/hqc-specification_2023-04-30.pdf
+package hqc
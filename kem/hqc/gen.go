@@ -0,0 +1,122 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the packages of the HQC parameter sets from templates to
+// prevent too much duplicated code between them.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Name string
+	// Length of the ambient space.
+	N int
+	// Lengths of the Reed-Solomon and the duplicated Reed-Muller codes.
+	N1, N2 int
+	// Weights of the secret key, of r1 and r2, and of e.
+	Omega, OmegaR, OmegaE int
+	// Correction capability of the Reed-Solomon code.
+	Delta int
+	// Size of the message in bytes.
+	K int
+}
+
+func (m Instance) Pkg() string {
+	return strings.ToLower(strings.ReplaceAll(m.Name, "-", ""))
+}
+
+// RSPoly returns the coefficients, from the constant term upwards, of the
+// generator polynomial of the Reed-Solomon code, whose roots are alpha^1,
+// ..., alpha^(2*delta) in GF(2^8) = GF(2)[x]/(x^8+x^4+x^3+x^2+1).
+func (m Instance) RSPoly() string {
+	mul := func(a, b int) int {
+		r := 0
+		for ; b != 0; b >>= 1 {
+			if b&1 == 1 {
+				r ^= a
+			}
+			a <<= 1
+			if a&0x100 != 0 {
+				a ^= 0x11D
+			}
+		}
+		return r
+	}
+	g := []int{1}
+	alpha := 1
+	for i := 0; i < 2*m.Delta; i++ {
+		alpha = mul(alpha, 2)
+		ng := make([]int, len(g)+1)
+		for j, c := range g {
+			ng[j+1] ^= c
+			ng[j] ^= mul(c, alpha)
+		}
+		g = ng
+	}
+	s := make([]string, len(g))
+	for i, c := range g {
+		s[i] = fmt.Sprint(c)
+	}
+	return strings.Join(s, ", ")
+}
+
+var (
+	Instances = []Instance{
+		{Name: "HQC-128", N: 17669, N1: 46, N2: 384, Omega: 66, OmegaR: 75, OmegaE: 75, Delta: 15, K: 16},
+		{Name: "HQC-192", N: 35851, N1: 56, N2: 640, Omega: 100, OmegaR: 114, OmegaE: 114, Delta: 16, K: 24},
+		{Name: "HQC-256", N: 57637, N1: 90, N2: 640, Omega: 131, OmegaR: 149, OmegaE: 149, Delta: 29, K: 32},
+	}
+	Templates = []string{
+		"code.templ.go",
+		"hqc.templ.go",
+		"vector.templ.go",
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/name.go from templates/name.templ.go
+func generatePackageFiles() {
+	for _, file := range Templates {
+		tl, err := template.ParseFiles(path.Join("templates", file))
+		if err != nil {
+			panic(err)
+		}
+
+		for _, mode := range Instances {
+			buf := new(bytes.Buffer)
+			err := tl.Execute(buf, mode)
+			if err != nil {
+				panic(err)
+			}
+
+			// Formating output code
+			code, err := format.Source(buf.Bytes())
+			if err != nil {
+				panic(fmt.Sprintf("error formating code: %v", err))
+			}
+
+			res := string(code)
+			offset := strings.Index(res, TemplateWarning)
+			if offset == -1 {
+				panic("Missing template warning in " + file)
+			}
+			out := path.Join(mode.Pkg(), strings.ReplaceAll(file, ".templ", ""))
+			err = ioutil.WriteFile(out, []byte(res[offset:]), 0o644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
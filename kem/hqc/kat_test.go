@@ -0,0 +1,88 @@
+package hqc
+
+// Code to generate the NIST "PQCgenKAT" test vectors.
+// See PQCgenKAT_kem.c and shake_prng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+// TestKATRegression checks the digests of the test vectors generated by
+// this package in the format of PQCgenKAT_kem.  They are not taken from the
+// reference implementation, and only catch changes to the output of this
+// package.
+func TestKATRegression(t *testing.T) {
+	kats := []struct {
+		name string
+		want string
+	}{
+		{"HQC-128", "b72bb96ba4a6f12252545d93b721c79c2e395ba24eb21f3a053d9582c7dde454"},
+		{"HQC-192", "7b6a8faa4acd6d6b54e2c3fe97af72870dc568732c97410dc484bb1772553711"},
+		{"HQC-256", "4b2f3f4c89506c50f1dbd6556c63171529c36940a0ce7b1560cda3abcb60202b"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.name, kat.want)
+		})
+	}
+}
+
+// newPRNG returns the randombytes of the reference implementation, which is
+// SHAKE256 of the entropy followed by a domain separator.
+func newPRNG(entropy []byte) *sha3.State {
+	h := sha3.NewShake256()
+	_, _ = h.Write(entropy)
+	_, _ = h.Write([]byte{1})
+	return &h
+}
+
+func testPQCgenKATKem(t *testing.T, name, expected string) {
+	scheme := schemes.ByName(name)
+	if scheme == nil {
+		t.Fatal()
+	}
+
+	var seed [48]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := newPRNG(seed[:])
+	fmt.Fprintf(f, "# %s\n\n", name)
+	for i := 0; i < 100; i++ {
+		_, _ = g.Read(seed[:])
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		g2 := newPRNG(seed[:])
+
+		pk, sk, err := scheme.GenerateKeyPairFrom(g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+
+		ct, ss, err := scheme.EncapsulateFrom(g2, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ss2, _ := scheme.Decapsulate(sk, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatal()
+		}
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "ct = %X\n", ct)
+		fmt.Fprintf(f, "ss = %X\n\n", ss)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("got %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,78 @@
+package hybrid
+
+import (
+	"crypto/sha512"
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// A Combiner computes the shared key of a hybrid KEM from the shared keys
+// of its components.
+type Combiner interface {
+	// SharedKeySize returns the size of the combined shared key given the
+	// sizes of the shared keys of the components.
+	SharedKeySize(first, second int) int
+
+	// Combine returns the shared key of the hybrid KEM named label from
+	// the shared keys ss1 and ss2 of the components and the hybrid
+	// ciphertext ct, which is the concatenation of the ciphertexts of the
+	// components.
+	Combine(label, ss1, ss2, ct []byte) []byte
+}
+
+// Concat returns the combiner of the predefined hybrids, which outputs the
+// concatenation ss1 ‖ ss2. The result must be hashed or otherwise used in
+// its entirety, see the package documentation.
+func Concat() Combiner { return concatCombiner{} }
+
+// KDF returns a combiner that outputs the 32-byte hash
+//
+//  SHA3-256(len(label) ‖ label ‖ len(ss1) ‖ ss1 ‖ len(ss2) ‖ ss2 ‖ ct)
+//
+// where lengths are encoded as 16-bit big-endian integers. Hashing the
+// ciphertext preserves the IND-CCA security of the hybrid as long as one
+// of the components is IND-CCA secure.
+func KDF() Combiner { return kdfCombiner{} }
+
+type concatCombiner struct{}
+
+func (concatCombiner) SharedKeySize(first, second int) int { return first + second }
+
+func (concatCombiner) Combine(_, ss1, ss2, _ []byte) []byte {
+	ss := make([]byte, 0, len(ss1)+len(ss2))
+	return append(append(ss, ss1...), ss2...)
+}
+
+type kdfCombiner struct{}
+
+const kdfSharedKeySize = 32
+
+func (kdfCombiner) SharedKeySize(_, _ int) int { return kdfSharedKeySize }
+
+func (kdfCombiner) Combine(label, ss1, ss2, ct []byte) []byte {
+	h := sha3.New256()
+	for _, b := range [][]byte{label, ss1, ss2} {
+		var l [2]byte
+		binary.BigEndian.PutUint16(l[:], uint16(len(b)))
+		_, _ = h.Write(l[:])
+		_, _ = h.Write(b)
+	}
+	_, _ = h.Write(ct)
+	ss := make([]byte, kdfSharedKeySize)
+	_, _ = h.Read(ss)
+	return ss
+}
+
+// sha512Combiner outputs SHA-512(ss1 ‖ ss2) as the sntrup761x25519-sha512
+// key exchange of OpenSSH.
+type sha512Combiner struct{}
+
+func (sha512Combiner) SharedKeySize(_, _ int) int { return sha512.Size }
+
+func (sha512Combiner) Combine(_, ss1, ss2, _ []byte) []byte {
+	h := sha512.New()
+	_, _ = h.Write(ss1)
+	_, _ = h.Write(ss2)
+	return h.Sum(nil)
+}
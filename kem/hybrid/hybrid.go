@@ -8,6 +8,13 @@
 //
 // Note that this is only fine if the shared secret is used in its entirety
 // in a next step, such as being hashed or used as key.
+// Sntrup761X25519 is an exception: it hashes the shared secrets with
+// SHA-512, as the sntrup761x25519-sha512 key exchange of OpenSSH.
+// The package github.com/cloudflare/circl/kem/xwing provides a hybrid KEM
+// whose shared secret is already the output of a combiner.
+//
+// Hybrids of other pairs of KEMs can be built with New, which also allows
+// replacing the concatenation of shared secrets by a KDF, see Combiner.
 //
 // For deriving a KEM keypair deterministically and encapsulating
 // deterministically, we expand a single seed to both using SHAKE256,
@@ -32,6 +39,7 @@ package hybrid
 
 import (
 	"errors"
+	"io"
 
 	"github.com/cloudflare/circl/hpke"
 	"github.com/cloudflare/circl/internal/sha3"
@@ -39,9 +47,43 @@ import (
 	"github.com/cloudflare/circl/kem/kyber/kyber1024"
 	"github.com/cloudflare/circl/kem/kyber/kyber512"
 	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/ntruprime/sntrup761"
 )
 
-var ErrUninitialized = errors.New("public or private key not initialized")
+var (
+	ErrUninitialized = errors.New("public or private key not initialized")
+
+	// ErrInvalidScheme is returned by New if a component or the combiner
+	// is missing, or if the seeds of a component are too large to be
+	// derived safely with SHAKE256.
+	ErrInvalidScheme = errors.New("invalid hybrid KEM parameters")
+)
+
+// maxSeedSize is the size of the internal state of SHAKE256, the largest
+// seed from which we derive the seeds of the components, see the package
+// documentation.
+const maxSeedSize = 200
+
+// New returns the hybrid KEM of first and second with the given name,
+// whose shared key is computed by combiner.
+//
+// Key pairs and encapsulations are derived from a single seed expanded
+// with SHAKE256 as for the predefined hybrids. Returns ErrInvalidScheme
+// if a component or the combiner is nil, the name is empty, or the key or
+// encapsulation seeds of a component are empty or larger than 200 bytes.
+func New(name string, first, second kem.Scheme, combiner Combiner) (kem.Scheme, error) {
+	if name == "" || first == nil || second == nil || combiner == nil {
+		return nil, ErrInvalidScheme
+	}
+	for _, s := range []kem.Scheme{first, second} {
+		if s.SeedSize() <= 0 || s.SeedSize() > maxSeedSize ||
+			s.EncapsulationSeedSize() <= 0 ||
+			s.EncapsulationSeedSize() > maxSeedSize {
+			return nil, ErrInvalidScheme
+		}
+	}
+	return &scheme{name, first, second, combiner}, nil
+}
 
 // Returns the hybrid KEM of Kyber512 and X25519.
 func Kyber512X25519() kem.Scheme { return kyber512X }
@@ -55,28 +97,49 @@ func Kyber768X448() kem.Scheme { return kyber768X4 }
 // Returns the hybrid KEM of Kyber1024 and X448.
 func Kyber1024X448() kem.Scheme { return kyber1024X }
 
+// Returns the hybrid KEM of sntrup761 and X25519 of the
+// sntrup761x25519-sha512 key exchange of OpenSSH.
+//
+// The public key and ciphertext are the concatenations of the ones of
+// sntrup761 and of raw X25519, and the shared key is the SHA-512 hash of
+// the concatenation of the sntrup761 shared key and of the X25519 shared
+// secret. OpenSSH uses the latter as the shared secret K, encoded as a
+// string.
+func Sntrup761X25519() kem.Scheme { return sntrup761X }
+
 var kyber512X kem.Scheme = &scheme{
 	"Kyber512-X25519",
 	kyber512.Scheme(),
 	hpke.KEM_X25519_HKDF_SHA256.Scheme(),
+	Concat(),
 }
 
 var kyber768X kem.Scheme = &scheme{
 	"Kyber768-X25519",
 	kyber768.Scheme(),
 	hpke.KEM_X25519_HKDF_SHA256.Scheme(),
+	Concat(),
 }
 
 var kyber768X4 kem.Scheme = &scheme{
 	"Kyber768-X448",
 	kyber768.Scheme(),
 	hpke.KEM_X448_HKDF_SHA512.Scheme(),
+	Concat(),
 }
 
 var kyber1024X kem.Scheme = &scheme{
 	"Kyber1024-X448",
 	kyber1024.Scheme(),
 	hpke.KEM_X448_HKDF_SHA512.Scheme(),
+	Concat(),
+}
+
+var sntrup761X kem.Scheme = &scheme{
+	"sntrup761x25519-sha512",
+	sntrup761.Scheme(),
+	x25519Kem,
+	sha512Combiner{},
 }
 
 // Public key of a hybrid KEM.
@@ -95,9 +158,10 @@ type privateKey struct {
 
 // Scheme for a hybrid KEM.
 type scheme struct {
-	name   string
-	first  kem.Scheme
-	second kem.Scheme
+	name     string
+	first    kem.Scheme
+	second   kem.Scheme
+	combiner Combiner
 }
 
 func (sch *scheme) Name() string { return sch.name }
@@ -120,7 +184,10 @@ func (sch *scheme) SeedSize() int {
 }
 
 func (sch *scheme) SharedKeySize() int {
-	return sch.first.SharedKeySize() + sch.second.SharedKeySize()
+	return sch.combiner.SharedKeySize(
+		sch.first.SharedKeySize(),
+		sch.second.SharedKeySize(),
+	)
 }
 
 func (sch *scheme) CiphertextSize() int {
@@ -203,11 +270,17 @@ func (pk *publicKey) MarshalBinary() ([]byte, error) {
 }
 
 func (sch *scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
-	pk1, sk1, err := sch.first.GenerateKeyPair()
+	return sch.GenerateKeyPairFrom(nil)
+}
+
+func (sch *scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error,
+) {
+	pk1, sk1, err := sch.first.GenerateKeyPairFrom(rand)
 	if err != nil {
 		return nil, nil, err
 	}
-	pk2, sk2, err := sch.second.GenerateKeyPair()
+	pk2, sk2, err := sch.second.GenerateKeyPairFrom(rand)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -233,22 +306,29 @@ func (sch *scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 }
 
 func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return sch.EncapsulateFrom(nil, pk)
+}
+
+func (sch *scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error,
+) {
 	pub, ok := pk.(*publicKey)
 	if !ok {
 		return nil, nil, kem.ErrTypeMismatch
 	}
 
-	ct1, ss1, err := sch.first.Encapsulate(pub.first)
+	ct1, ss1, err := sch.first.EncapsulateFrom(rand, pub.first)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	ct2, ss2, err := sch.second.Encapsulate(pub.second)
+	ct2, ss2, err := sch.second.EncapsulateFrom(rand, pub.second)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return append(ct1, ct2...), append(ss1, ss2...), nil
+	ct = append(ct1, ct2...)
+	return ct, sch.combine(ss1, ss2, ct), nil
 }
 
 func (sch *scheme) EncapsulateDeterministically(
@@ -278,7 +358,8 @@ func (sch *scheme) EncapsulateDeterministically(
 	if err != nil {
 		return nil, nil, err
 	}
-	return append(ct1, ct2...), append(ss1, ss2...), nil
+	ct = append(ct1, ct2...)
+	return ct, sch.combine(ss1, ss2, ct), nil
 }
 
 func (sch *scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
@@ -300,7 +381,7 @@ func (sch *scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
 	if err != nil {
 		return nil, err
 	}
-	return append(ss1, ss2...), nil
+	return sch.combine(ss1, ss2, ct), nil
 }
 
 func (sch *scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
@@ -334,3 +415,7 @@ func (sch *scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error)
 	}
 	return &privateKey{sch, sk1, sk2}, nil
 }
+
+func (sch *scheme) combine(ss1, ss2, ct []byte) []byte {
+	return sch.combiner.Combine([]byte(sch.name), ss1, ss2, ct)
+}
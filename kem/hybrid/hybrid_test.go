@@ -0,0 +1,364 @@
+package hybrid_test
+
+import (
+	"bytes"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/hybrid"
+	"github.com/cloudflare/circl/kem/ntruprime/sntrup761"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+func TestNewErrors(t *testing.T) {
+	first, second := schemes.ByName("Kyber768"), schemes.ByName("X-Wing")
+	for _, c := range []struct {
+		name          string
+		first, second kem.Scheme
+		combiner      hybrid.Combiner
+	}{
+		{"", first, second, hybrid.KDF()},
+		{"a", nil, second, hybrid.KDF()},
+		{"a", first, nil, hybrid.KDF()},
+		{"a", first, second, nil},
+		{"a", first, seedSizeScheme{second, 201}, hybrid.KDF()},
+		{"a", seedSizeScheme{first, 0}, second, hybrid.KDF()},
+	} {
+		_, err := hybrid.New(c.name, c.first, c.second, c.combiner)
+		if err != hybrid.ErrInvalidScheme {
+			test.ReportError(t, err, hybrid.ErrInvalidScheme, c.name, c.first, c.second)
+		}
+	}
+}
+
+// seedSizeScheme overrides the seed size of a KEM.
+type seedSizeScheme struct {
+	kem.Scheme
+	seedSize int
+}
+
+func (s seedSizeScheme) SeedSize() int { return s.seedSize }
+
+func TestPredefined(t *testing.T) {
+	// The predefined hybrids must stay byte compatible with hybrids built
+	// with New and the Concat combiner.
+	for _, c := range []struct{ name, first, second string }{
+		{"Kyber512-X25519", "Kyber512", "HPKE_KEM_X25519_HKDF_SHA256"},
+		{"Kyber768-X25519", "Kyber768", "HPKE_KEM_X25519_HKDF_SHA256"},
+		{"Kyber768-X448", "Kyber768", "HPKE_KEM_X448_HKDF_SHA512"},
+		{"Kyber1024-X448", "Kyber1024", "HPKE_KEM_X448_HKDF_SHA512"},
+	} {
+		want := schemes.ByName(c.name)
+		got, err := hybrid.New(c.name, schemes.ByName(c.first),
+			schemes.ByName(c.second), hybrid.Concat())
+		test.CheckNoErr(t, err, "New failed")
+		checkSameOutputs(t, got, want)
+	}
+}
+
+// TestSntrup761X25519 checks that the hybrid follows the
+// sntrup761x25519-sha512 key exchange of OpenSSH.
+func TestSntrup761X25519(t *testing.T) {
+	sch := hybrid.Sntrup761X25519()
+	pk, sk, err := sch.GenerateKeyPair()
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+	ct, ss, err := sch.Encapsulate(pk)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+
+	psk, _ := sk.MarshalBinary()
+	sk1, err := sntrup761.Scheme().UnmarshalBinaryPrivateKey(
+		psk[:sntrup761.PrivateKeySize])
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	ss1, err := sntrup761.Scheme().Decapsulate(sk1, ct[:sntrup761.CiphertextSize])
+	test.CheckNoErr(t, err, "Decapsulate failed")
+
+	var ss2, sk2, ct2 x25519.Key
+	copy(sk2[:], psk[sntrup761.PrivateKeySize:])
+	copy(ct2[:], ct[sntrup761.CiphertextSize:])
+	if !x25519.Shared(&ss2, &sk2, &ct2) {
+		t.Fatal("X25519 failed")
+	}
+
+	want := sha512.Sum512(append(ss1, ss2[:]...))
+	if !bytes.Equal(ss, want[:]) {
+		test.ReportError(t, ss, want)
+	}
+
+	// The X25519 ciphertext is a low-order point.
+	lowOrder, _ := hex.DecodeString(
+		"e0eb7a7c3b41b8ae1656e3faf19fc46ada098deb9c32b1fd866205165f49b800")
+	copy(ct[sntrup761.CiphertextSize:], lowOrder)
+	_, err = sch.Decapsulate(sk, ct)
+	test.CheckIsErr(t, err, "Decapsulate should fail")
+	ppk, _ := pk.MarshalBinary()
+	copy(ppk[sntrup761.PublicKeySize:], lowOrder)
+	pk, err = sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	_, _, err = sch.Encapsulate(pk)
+	test.CheckIsErr(t, err, "Encapsulate should fail")
+}
+
+// TestSntrup761X25519OpenSSH checks the hybrid against a key exchange with
+// OpenSSH, see testdata/sntrup761x25519-sha512.json.  As OpenSSH uses the
+// raw X25519 secret key, the key pair is regenerated from the sntrup761
+// key generation followed by 32 bytes of the same randomness.
+func TestSntrup761X25519OpenSSH(t *testing.T) {
+	var v struct {
+		Random            string `json:"random"`
+		Pk                string `json:"pk"`
+		Sk                string `json:"sk"`
+		EncapsulationSeed string `json:"encapsulation_seed"`
+		Ct                string `json:"ct"`
+		Ss                string `json:"ss"`
+	}
+	input, err := ioutil.ReadFile("testdata/sntrup761x25519-sha512.json")
+	test.CheckNoErr(t, err, "ReadFile failed")
+	err = json.Unmarshal(input, &v)
+	test.CheckNoErr(t, err, "Unmarshal failed")
+	dec := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		test.CheckNoErr(t, err, "DecodeString failed")
+		return b
+	}
+	random, ppk, psk := dec(v.Random), dec(v.Pk), dec(v.Sk)
+	eseed, ct, ss := dec(v.EncapsulationSeed), dec(v.Ct), dec(v.Ss)
+
+	h := sha3.NewShake256()
+	_, _ = h.Write(random)
+	_, sk1, err := sntrup761.Scheme().GenerateKeyPairFrom(&h)
+	test.CheckNoErr(t, err, "GenerateKeyPairFrom failed")
+	gsk, _ := sk1.MarshalBinary()
+	gsk = append(gsk, make([]byte, x25519.Size)...)
+	_, _ = h.Read(gsk[sntrup761.PrivateKeySize:])
+	if !bytes.Equal(gsk, psk) {
+		test.ReportError(t, gsk, psk)
+	}
+
+	sch := hybrid.Sntrup761X25519()
+	sk, err := sch.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	got, _ := sk.Public().MarshalBinary()
+	if !bytes.Equal(got, ppk) {
+		test.ReportError(t, got, ppk)
+	}
+
+	pk, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	gct, gss, err := sch.EncapsulateDeterministically(pk, eseed)
+	test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+	if !bytes.Equal(gct, ct) || !bytes.Equal(gss, ss) {
+		test.ReportError(t, gct, ct, gss, ss)
+	}
+
+	got, err = sch.Decapsulate(sk, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	if !bytes.Equal(got, ss) {
+		test.ReportError(t, got, ss)
+	}
+}
+
+// testSchemes returns the registered schemes, without the slow ones in
+// short mode.
+func testSchemes() []kem.Scheme {
+	var all []kem.Scheme
+	for _, sch := range schemes.All() {
+		if testing.Short() && isSlow(sch) {
+			continue
+		}
+		all = append(all, sch)
+	}
+	return all
+}
+
+// isSlow returns true for the schemes whose operations are too slow to be
+// tested in short mode.
+func isSlow(sch kem.Scheme) bool {
+	name := sch.Name()
+	return strings.HasPrefix(name, "mceliece") ||
+		strings.HasPrefix(name, "FrodoKEM-1344-") ||
+		name == "HQC-256" ||
+		name == "CSIDH-512"
+}
+
+var combiners = []struct {
+	name string
+	c    hybrid.Combiner
+}{{"concat", hybrid.Concat()}, {"kdf", hybrid.KDF()}}
+
+// TestAllSchemes runs all the checks on every registered scheme combined
+// with X25519, in both positions.
+func TestAllSchemes(t *testing.T) {
+	x25519 := schemes.ByName("HPKE_KEM_X25519_HKDF_SHA256")
+	for _, other := range testSchemes() {
+		for _, pair := range [][2]kem.Scheme{{other, x25519}, {x25519, other}} {
+			first, second := pair[0], pair[1]
+			for _, combiner := range combiners {
+				name := fmt.Sprintf("%v+%v/%v", first.Name(), second.Name(), combiner.name)
+				sch, err := hybrid.New(name, first, second, combiner.c)
+				test.CheckNoErr(t, err, name)
+				t.Run(name, func(t *testing.T) {
+					t.Parallel()
+					checkScheme(t, sch)
+				})
+			}
+		}
+	}
+}
+
+// TestAllPairs combines every pair of registered schemes. As key generation
+// dominates the running time of the large schemes, a key pair of each scheme
+// is generated once, and the hybrid keys are assembled from them.
+func TestAllPairs(t *testing.T) {
+	all := testSchemes()
+	pks := make(map[string][]byte, len(all))
+	sks := make(map[string][]byte, len(all))
+	for _, sch := range all {
+		pk, sk := sch.DeriveKeyPair(make([]byte, sch.SeedSize()))
+		ppk, err := pk.MarshalBinary()
+		test.CheckNoErr(t, err, "MarshalBinary failed")
+		psk, err := sk.MarshalBinary()
+		test.CheckNoErr(t, err, "MarshalBinary failed")
+		pks[sch.Name()], sks[sch.Name()] = ppk, psk
+	}
+
+	for _, first := range all {
+		for _, second := range all {
+			ppk := concat(pks[first.Name()], pks[second.Name()])
+			psk := concat(sks[first.Name()], sks[second.Name()])
+			for _, combiner := range combiners {
+				name := fmt.Sprintf("%v+%v/%v", first.Name(), second.Name(), combiner.name)
+				sch, err := hybrid.New(name, first, second, combiner.c)
+				test.CheckNoErr(t, err, name)
+				t.Run(name, func(t *testing.T) {
+					t.Parallel()
+					checkPair(t, sch, ppk, psk)
+				})
+			}
+		}
+	}
+}
+
+func concat(a, b []byte) []byte {
+	return append(append(make([]byte, 0, len(a)+len(b)), a...), b...)
+}
+
+// checkPair checks a hybrid with the given packed keys.
+func checkPair(t *testing.T, sch kem.Scheme, ppk, psk []byte) {
+	pk, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	sk, err := sch.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	ppk2, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	psk2, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	if !bytes.Equal(ppk, ppk2) || !bytes.Equal(psk, psk2) {
+		t.Fatal("keys do not round-trip")
+	}
+
+	ct, ss, err := sch.Encapsulate(pk)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	if len(ct) != sch.CiphertextSize() || len(ss) != sch.SharedKeySize() {
+		t.Fatal("wrong ciphertext or shared key size")
+	}
+	ss2, err := sch.Decapsulate(sk, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	if !bytes.Equal(ss, ss2) {
+		t.Fatal("shared keys do not match")
+	}
+}
+
+func checkScheme(t *testing.T, sch kem.Scheme) {
+	seed := make([]byte, sch.SeedSize())
+	eseed := make([]byte, sch.EncapsulationSeedSize())
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(sch.Name()))
+	_, _ = h.Read(seed)
+	_, _ = h.Read(eseed)
+
+	pk, sk := sch.DeriveKeyPair(seed)
+	pk2, sk2 := sch.DeriveKeyPair(seed)
+	if !pk.Equal(pk2) || !sk.Equal(sk2) {
+		t.Fatal("DeriveKeyPair is not deterministic")
+	}
+	if !sk.Public().Equal(pk) {
+		t.Fatal("Public does not match the public key")
+	}
+
+	ppk, err := pk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	psk, err := sk.MarshalBinary()
+	test.CheckNoErr(t, err, "MarshalBinary failed")
+	if len(ppk) != sch.PublicKeySize() || len(psk) != sch.PrivateKeySize() {
+		t.Fatal("wrong key size")
+	}
+	pk3, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	sk3, err := sch.UnmarshalBinaryPrivateKey(psk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	if !pk.Equal(pk3) || !sk.Equal(sk3) {
+		t.Fatal("keys do not round-trip")
+	}
+
+	ct, ss, err := sch.EncapsulateDeterministically(pk, eseed)
+	test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+	ct2, ss2, err := sch.EncapsulateDeterministically(pk3, eseed)
+	test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+	if !bytes.Equal(ct, ct2) || !bytes.Equal(ss, ss2) {
+		t.Fatal("EncapsulateDeterministically is not deterministic")
+	}
+	if len(ct) != sch.CiphertextSize() || len(ss) != sch.SharedKeySize() {
+		t.Fatal("wrong ciphertext or shared key size")
+	}
+	ss3, err := sch.Decapsulate(sk3, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	if !bytes.Equal(ss, ss3) {
+		t.Fatal("shared keys do not match")
+	}
+
+	ct, ss, err = sch.Encapsulate(pk)
+	test.CheckNoErr(t, err, "Encapsulate failed")
+	ss3, err = sch.Decapsulate(sk, ct)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	if !bytes.Equal(ss, ss3) {
+		t.Fatal("shared keys do not match")
+	}
+
+	_, err = sch.Decapsulate(sk, ct[1:])
+	if err != kem.ErrCiphertextSize {
+		test.ReportError(t, err, kem.ErrCiphertextSize)
+	}
+}
+
+func checkSameOutputs(t *testing.T, got, want kem.Scheme) {
+	seed := make([]byte, want.SeedSize())
+	eseed := make([]byte, want.EncapsulationSeedSize())
+	pk1, _ := got.DeriveKeyPair(seed)
+	pk2, sk2 := want.DeriveKeyPair(seed)
+	ppk1, _ := pk1.MarshalBinary()
+	ppk2, _ := pk2.MarshalBinary()
+	if !bytes.Equal(ppk1, ppk2) {
+		t.Fatalf("%v: public keys differ", want.Name())
+	}
+	ct1, ss1, err := got.EncapsulateDeterministically(pk1, eseed)
+	test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+	ct2, ss2, err := want.EncapsulateDeterministically(pk2, eseed)
+	test.CheckNoErr(t, err, "EncapsulateDeterministically failed")
+	if !bytes.Equal(ct1, ct2) || !bytes.Equal(ss1, ss2) {
+		t.Fatalf("%v: encapsulations differ", want.Name())
+	}
+	ss3, err := want.Decapsulate(sk2, ct1)
+	test.CheckNoErr(t, err, "Decapsulate failed")
+	if !bytes.Equal(ss1, ss3) {
+		t.Fatalf("%v: shared keys differ", want.Name())
+	}
+}
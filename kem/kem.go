@@ -7,7 +7,9 @@ package kem
 
 import (
 	"encoding"
+	"encoding/asn1"
 	"errors"
+	"io"
 )
 
 // A KEM public key
@@ -37,10 +39,19 @@ type Scheme interface {
 	// GenerateKeyPair creates a new key pair.
 	GenerateKeyPair() (PublicKey, PrivateKey, error)
 
+	// GenerateKeyPairFrom creates a new key pair using randomness read
+	// from rand. If rand is nil, crypto/rand.Reader is used.
+	GenerateKeyPairFrom(rand io.Reader) (PublicKey, PrivateKey, error)
+
 	// Encapsulate generates a shared key ss for the public key and
 	// encapsulates it into a ciphertext ct.
 	Encapsulate(pk PublicKey) (ct, ss []byte, err error)
 
+	// EncapsulateFrom generates a shared key ss for the public key using
+	// randomness read from rand, and encapsulates it into a ciphertext ct.
+	// If rand is nil, crypto/rand.Reader is used.
+	EncapsulateFrom(rand io.Reader, pk PublicKey) (ct, ss []byte, err error)
+
 	// Returns the shared key encapsulated in ciphertext ct for the
 	// private key sk.
 	Decapsulate(sk PrivateKey, ct []byte) ([]byte, error)
@@ -85,10 +96,32 @@ type Scheme interface {
 type AuthScheme interface {
 	Scheme
 	AuthEncapsulate(pkr PublicKey, sks PrivateKey) (ct, ss []byte, err error)
+	AuthEncapsulateFrom(rand io.Reader, pkr PublicKey, sks PrivateKey) (ct, ss []byte, err error)
 	AuthEncapsulateDeterministically(pkr PublicKey, sks PrivateKey, seed []byte) (ct, ss []byte, err error)
 	AuthDecapsulate(skr PrivateKey, ct []byte, pks PublicKey) ([]byte, error)
 }
 
+// CertificateScheme is implemented by schemes with an object identifier
+// for their public keys, as used in X.509 certificates.
+type CertificateScheme interface {
+	// Return the OID of the public keys of this scheme.
+	Oid() asn1.ObjectIdentifier
+}
+
+// TLSScheme is implemented by schemes that can be used as a key exchange
+// group in TLS, with the public key sent by the client and the ciphertext
+// by the server.
+type TLSScheme interface {
+	// Return the NamedGroup codepoint of this scheme.
+	TLSIdentifier() uint
+}
+
+// HPKEScheme is implemented by schemes that have a KEM identifier in HPKE.
+type HPKEScheme interface {
+	// Return the HPKE KEM identifier of this scheme.
+	HPKEIdentifier() uint
+}
+
 var (
 	// ErrTypeMismatch is the error used if types of, for instance, private
 	// and public keys don't match
@@ -113,6 +146,9 @@ var (
 	// ErrPubKey is the error used if the provided public key is invalid.
 	ErrPubKey = errors.New("invalid public key")
 
+	// ErrPrivKey is the error used if the provided private key is invalid.
+	ErrPrivKey = errors.New("invalid private key")
+
 	// ErrCipherText is the error used if the provided ciphertext is invalid.
 	ErrCipherText = errors.New("invalid ciphertext")
 )
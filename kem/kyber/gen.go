@@ -7,18 +7,67 @@ package main
 
 import (
 	"bytes"
+	"encoding/asn1"
+	"fmt"
 	"go/format"
 	"io/ioutil"
+	"path"
 	"strings"
 	"text/template"
 )
 
 type Instance struct {
 	Name string
+
+	// Identifiers assigned to the instance, or zero if there are none.
+	Oid      asn1.ObjectIdentifier
+	TLSGroup uint
+	HPKEID   uint
+}
+
+// OidCode returns the Go code for the OID of the instance.
+func (m Instance) OidCode() string {
+	ret := "asn1.ObjectIdentifier{"
+	for i, n := range m.Oid {
+		if i > 0 {
+			ret += ", "
+		}
+		ret += fmt.Sprintf("%d", n)
+	}
+	return ret + "}"
+}
+
+func (m Instance) KemName() string {
+	if m.NIST() {
+		return m.Name
+	}
+	return m.Name + ".CCAKEM"
+}
+
+// NIST returns whether the instance is the final FIPS 203 ML-KEM rather
+// than round 3 Kyber.
+func (m Instance) NIST() bool {
+	return strings.HasPrefix(m.Name, "ML-KEM")
+}
+
+// PkePkg returns the package of the underlying CPAPKE, which is shared
+// between Kyber and ML-KEM.
+func (m Instance) PkePkg() string {
+	if !m.NIST() {
+		return m.Pkg()
+	}
+	return strings.ReplaceAll(m.Pkg(), "mlkem", "kyber")
 }
 
 func (m Instance) Pkg() string {
-	return strings.ToLower(m.Name)
+	return strings.ToLower(strings.ReplaceAll(m.Name, "-", ""))
+}
+
+func (m Instance) PkgPath() string {
+	if m.NIST() {
+		return path.Join("..", "mlkem", m.Pkg())
+	}
+	return m.Pkg()
 }
 
 var (
@@ -26,6 +75,24 @@ var (
 		{Name: "Kyber512"},
 		{Name: "Kyber768"},
 		{Name: "Kyber1024"},
+		{
+			Name:     "ML-KEM-512",
+			Oid:      asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 1},
+			TLSGroup: 0x0200,
+			HPKEID:   0x0040,
+		},
+		{
+			Name:     "ML-KEM-768",
+			Oid:      asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2},
+			TLSGroup: 0x0201,
+			HPKEID:   0x0041,
+		},
+		{
+			Name:     "ML-KEM-1024",
+			Oid:      asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3},
+			TLSGroup: 0x0202,
+			HPKEID:   0x0042,
+		},
 	}
 	TemplateWarning = "// Code generated from"
 )
@@ -51,7 +118,7 @@ func generatePackageFiles() {
 		// Formating output code
 		code, err := format.Source(buf.Bytes())
 		if err != nil {
-			panic("error formating code")
+			panic(fmt.Sprintf("error formating code: %v", err))
 		}
 
 		res := string(code)
@@ -59,7 +126,7 @@ func generatePackageFiles() {
 		if offset == -1 {
 			panic("Missing template warning in pkg.templ.go")
 		}
-		err = ioutil.WriteFile(mode.Pkg()+"/kyber.go", []byte(res[offset:]), 0o644)
+		err = ioutil.WriteFile(mode.PkgPath()+"/kyber.go", []byte(res[offset:]), 0o644)
 		if err != nil {
 			panic(err)
 		}
@@ -333,6 +333,11 @@ func (*scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
 	return GenerateKeyPair(cryptoRand.Reader)
 }
 
+func (*scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
 func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	if len(seed) != KeySeedSize {
 		panic(kem.ErrSeedSize)
@@ -340,16 +345,20 @@ func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	return NewKeyFromSeed(seed[:])
 }
 
-func (*scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
-	ct = make([]byte, CiphertextSize)
-	ss = make([]byte, SharedKeySize)
+func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return sch.EncapsulateFrom(cryptoRand.Reader, pk)
+}
 
-	pub, ok := pk.(*PublicKey)
-	if !ok {
-		return nil, nil, kem.ErrTypeMismatch
+func (sch *scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error) {
+	var seed [EncapsulationSeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
 	}
-	pub.EncapsulateTo(ct, ss, nil)
-	return
+	if _, err = io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	return sch.EncapsulateDeterministically(pk, seed[:])
 }
 
 func (*scheme) EncapsulateDeterministically(pk kem.PublicKey, seed []byte) (
@@ -5,20 +5,27 @@
 // Code generated from pkg.templ.go. DO NOT EDIT.
 
 // Package {{.Pkg}} implements the IND-CCA2 secure key encapsulation mechanism
-// {{.Name}}.CCAKEM as submitted to round 3 of the NIST PQC competition and
+{{ if .NIST -}}
+// {{.KemName}} as defined in FIPS 203.
+{{- else -}}
+// {{.KemName}} as submitted to round 3 of the NIST PQC competition and
 // described in
 //
 // https://pq-crystals.org/kyber/data/kyber-specification-round3.pdf
+{{- end }}
 package {{.Pkg}}
 
 import (
 	"bytes"
 	"crypto/subtle"
+	{{- if .Oid }}
+	"encoding/asn1"
+	{{- end }}
 	"io"
 
 	"github.com/cloudflare/circl/internal/sha3"
 	"github.com/cloudflare/circl/kem"
-	cpapke "github.com/cloudflare/circl/pke/kyber/{{.Pkg}}"
+	cpapke "github.com/cloudflare/circl/pke/kyber/{{.PkePkg}}"
 	cryptoRand "crypto/rand"
 )
 
@@ -42,14 +49,14 @@ const (
 	PrivateKeySize = cpapke.PrivateKeySize + cpapke.PublicKeySize + 64
 )
 
-// Type of a {{.Name}}.CCAKEM public key
+// Type of a {{.KemName}} public key
 type PublicKey struct {
 	pk *cpapke.PublicKey
 
 	hpk [32]byte // H(pk)
 }
 
-// Type of a {{.Name}}.CCAKEM private key
+// Type of a {{.KemName}} private key
 type PrivateKey struct {
 	sk  *cpapke.PrivateKey
 	pk  *cpapke.PublicKey
@@ -69,7 +76,11 @@ func NewKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey) {
 		panic("seed must be of length KeySeedSize")
 	}
 
+	{{ if .NIST -}}
+	pk.pk, sk.sk = cpapke.NewKeyFromSeedMLKEM(seed[:cpapke.KeySeedSize])
+	{{- else -}}
 	pk.pk, sk.sk = cpapke.NewKeyFromSeed(seed[:cpapke.KeySeedSize])
+	{{- end }}
 	sk.pk = pk.pk
 	copy(sk.z[:], seed[cpapke.KeySeedSize:])
 
@@ -125,11 +136,16 @@ func (pk *PublicKey) EncapsulateTo(ct, ss []byte, seed []byte) {
 		panic("ss must be of length SharedKeySize")
 	}
 
+	{{ if .NIST -}}
+	var m [32]byte
+	copy(m[:], seed)
+	{{- else -}}
 	// m = H(seed)
 	var m [32]byte
 	h := sha3.New256()
 	h.Write(seed[:])
 	h.Read(m[:])
+	{{- end }}
 
 	// (K', r) = G(m ‖ H(pk))
 	var kr [64]byte
@@ -141,6 +157,10 @@ func (pk *PublicKey) EncapsulateTo(ct, ss []byte, seed []byte) {
 	// c = Kyber.CPAPKE.Enc(pk, m, r)
 	pk.pk.EncryptTo(ct, m[:], kr[32:])
 
+	{{ if .NIST -}}
+	// K = K'
+	copy(ss, kr[:SharedKeySize])
+	{{- else -}}
 	// Compute H(c) and put in second slot of kr, which will be (K', H(c)).
 	h.Reset()
 	h.Write(ct[:CiphertextSize])
@@ -150,6 +170,7 @@ func (pk *PublicKey) EncapsulateTo(ct, ss []byte, seed []byte) {
 	kdf := sha3.NewShake256()
 	kdf.Write(kr[:])
 	kdf.Read(ss[:SharedKeySize])
+	{{- end }}
 }
 
 // DecapsulateTo computes the shared key which is encapsulated in ct
@@ -181,6 +202,22 @@ func (sk *PrivateKey) DecapsulateTo(ss, ct []byte) {
 	var ct2 [CiphertextSize]byte
 	sk.pk.EncryptTo(ct2[:], m2[:], kr2[32:])
 
+	{{ if .NIST -}}
+	// Compute the implicit rejection key K̄ = J(z ‖ c).
+	var ss2 [SharedKeySize]byte
+	prf := sha3.NewShake256()
+	prf.Write(sk.z[:])
+	prf.Write(ct[:CiphertextSize])
+	prf.Read(ss2[:])
+
+	// Replace K̄ by K'' if c = c'.
+	subtle.ConstantTimeCopy(
+		subtle.ConstantTimeCompare(ct, ct2[:]),
+		ss2[:],
+		kr2[:SharedKeySize],
+	)
+	copy(ss, ss2[:])
+	{{- else -}}
 	// Compute H(c) and put in second slot of kr2, which will be (K'', H(c)).
 	h := sha3.New256()
 	h.Write(ct[:CiphertextSize])
@@ -197,6 +234,7 @@ func (sk *PrivateKey) DecapsulateTo(ss, ct []byte) {
 	kdf := sha3.NewShake256()
 	kdf.Write(kr2[:])
 	kdf.Read(ss[:SharedKeySize])
+	{{- end }}
 }
 
 // Packs sk to buf.
@@ -218,20 +256,42 @@ func (sk *PrivateKey) Pack(buf []byte) {
 
 // Unpacks sk from buf.
 //
+{{ if .NIST -}}
+// Returns an error if buf is not of size PrivateKeySize, or if the private
+// key does not pass the ML-KEM decapsulation key check.
+func (sk *PrivateKey) Unpack(buf []byte) error {
+	if len(buf) != PrivateKeySize {
+		return kem.ErrPrivKeySize
+	}
+{{- else -}}
 // Panics if buf is not of size PrivateKeySize.
 func (sk *PrivateKey) Unpack(buf []byte) {
 	if len(buf) != PrivateKeySize {
 		panic("buf must be of length PrivateKeySize")
 	}
+{{- end }}
 
 	sk.sk = new(cpapke.PrivateKey)
 	sk.sk.Unpack(buf[:cpapke.PrivateKeySize])
 	buf = buf[cpapke.PrivateKeySize:]
 	sk.pk = new(cpapke.PublicKey)
 	sk.pk.Unpack(buf[:cpapke.PublicKeySize])
+	{{ if .NIST -}}
+	// Decapsulation key check: the stored H(ek) must match ek.
+	var hpk [32]byte
+	h := sha3.New256()
+	h.Write(buf[:cpapke.PublicKeySize])
+	h.Read(hpk[:])
+	{{ end -}}
 	buf = buf[cpapke.PublicKeySize:]
 	copy(sk.hpk[:], buf[:32])
 	copy(sk.z[:], buf[32:])
+	{{- if .NIST }}
+	if !bytes.Equal(hpk[:], sk.hpk[:]) {
+		return kem.ErrPrivKey
+	}
+	return nil
+	{{- end }}
 }
 
 // Packs pk to buf.
@@ -247,6 +307,19 @@ func (pk *PublicKey) Pack(buf []byte) {
 
 // Unpacks pk from buf.
 //
+{{ if .NIST -}}
+// Returns an error if buf is not of size PublicKeySize, or if the public
+// key does not pass the ML-KEM encapsulation key check.
+func (pk *PublicKey) Unpack(buf []byte) error {
+	if len(buf) != PublicKeySize {
+		return kem.ErrPubKeySize
+	}
+
+	pk.pk = new(cpapke.PublicKey)
+	if pk.pk.UnpackMLKEM(buf) != nil {
+		return kem.ErrPubKey
+	}
+{{- else -}}
 // Panics if buf is not of size PublicKeySize.
 func (pk *PublicKey) Unpack(buf []byte) {
 	if len(buf) != PublicKeySize {
@@ -255,11 +328,15 @@ func (pk *PublicKey) Unpack(buf []byte) {
 
 	pk.pk = new(cpapke.PublicKey)
 	pk.pk.Unpack(buf)
+{{- end }}
 
 	// Compute cached H(pk)
 	h := sha3.New256()
 	h.Write(buf)
 	h.Read(pk.hpk[:])
+	{{- if .NIST }}
+	return nil
+	{{- end }}
 }
 
 // Boilerplate down below for the KEM scheme API.
@@ -278,6 +355,17 @@ func (*scheme) SeedSize() int              { return KeySeedSize }
 func (*scheme) SharedKeySize() int         { return SharedKeySize }
 func (*scheme) CiphertextSize() int        { return CiphertextSize }
 func (*scheme) EncapsulationSeedSize() int { return EncapsulationSeedSize }
+{{- if .TLSGroup }}
+func (*scheme) TLSIdentifier() uint         { return {{printf "0x%04x" .TLSGroup}} }
+{{- end }}
+{{- if .HPKEID }}
+func (*scheme) HPKEIdentifier() uint        { return {{printf "0x%04x" .HPKEID}} }
+{{- end }}
+{{- if .Oid }}
+func (*scheme) Oid() asn1.ObjectIdentifier {
+	return {{.OidCode}}
+}
+{{- end }}
 
 func (sk *PrivateKey) Scheme() kem.Scheme { return sch }
 func (pk *PublicKey) Scheme() kem.Scheme  { return sch }
@@ -337,6 +425,11 @@ func (*scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
 	return GenerateKeyPair(cryptoRand.Reader)
 }
 
+func (*scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
 func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	if len(seed) != KeySeedSize {
 		panic(kem.ErrSeedSize)
@@ -344,16 +437,20 @@ func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	return NewKeyFromSeed(seed[:])
 }
 
-func (*scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
-	ct = make([]byte, CiphertextSize)
-	ss = make([]byte, SharedKeySize)
+func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return sch.EncapsulateFrom(cryptoRand.Reader, pk)
+}
 
-	pub, ok := pk.(*PublicKey)
-	if !ok {
-		return nil, nil, kem.ErrTypeMismatch
+func (sch *scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error) {
+	var seed [EncapsulationSeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
 	}
-	pub.EncapsulateTo(ct, ss, nil)
-	return
+	if _, err = io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
+	return sch.EncapsulateDeterministically(pk, seed[:])
 }
 
 func (*scheme) EncapsulateDeterministically(pk kem.PublicKey, seed []byte) (
@@ -392,7 +489,13 @@ func (*scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
 		return nil, kem.ErrPubKeySize
 	}
 	var ret PublicKey
+	{{ if .NIST -}}
+	if err := ret.Unpack(buf); err != nil {
+		return nil, err
+	}
+	{{- else -}}
 	ret.Unpack(buf)
+	{{- end }}
 	return &ret, nil
 }
 
@@ -401,6 +504,12 @@ func (*scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
 		return nil, kem.ErrPrivKeySize
 	}
 	var ret PrivateKey
+	{{ if .NIST -}}
+	if err := ret.Unpack(buf); err != nil {
+		return nil, err
+	}
+	{{- else -}}
 	ret.Unpack(buf)
+	{{- end }}
 	return &ret, nil
 }
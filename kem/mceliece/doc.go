@@ -0,0 +1,21 @@
+//go:generate go run gen.go
+
+// Package mceliece provides the key encapsulation mechanism Classic McEliece.
+//
+// Compatible with the implementation submitted to round 4 of the NIST PQC
+// competition [1].
+//
+// The parameter sets mceliece348864 and mceliece6960119 are provided, each
+// with the variant f whose key generation is faster. The packages of each
+// parameter set are generated from the templates in the templates directory
+// by gen.go.
+//
+// The public keys are large: 261120 bytes for mceliece348864 and 1047319
+// bytes for mceliece6960119. Besides the usual kem.Scheme API, the packages
+// provide PublicKey.WriteTo and PublicKey.ReadFrom, which do not copy the
+// public key, and EncapsulateStream, which reads the public key row by row
+// as it encapsulates.
+//
+// References:
+//  [1] https://classic.mceliece.org/mceliece-spec-20221023.pdf
+package mceliece
@@ -0,0 +1,86 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the packages of the Classic McEliece parameter sets from
+// templates to prevent too much duplicated code between them.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type Instance struct {
+	Name string
+	// Degree of the extension field GF(2^m).
+	GFBits int
+	// Length of the code, and degree of the Goppa polynomial.
+	SysN, SysT int
+	// Whether the public key is generated in semi-systematic form.
+	Fast bool
+}
+
+func (m Instance) Pkg() string { return m.Name }
+
+// Unaligned returns whether the rows of the public key and the ciphertext
+// have padding bits, as the number of rows of the parity-check matrix is
+// not a multiple of 8.
+func (m Instance) Unaligned() bool { return (m.SysT*m.GFBits)%8 != 0 }
+
+var (
+	Instances = []Instance{
+		{Name: "mceliece348864", GFBits: 12, SysN: 3488, SysT: 64},
+		{Name: "mceliece348864f", GFBits: 12, SysN: 3488, SysT: 64, Fast: true},
+		{Name: "mceliece6960119", GFBits: 13, SysN: 6960, SysT: 119},
+		{Name: "mceliece6960119f", GFBits: 13, SysN: 6960, SysT: 119, Fast: true},
+	}
+	Templates = []string{
+		"gf.templ.go",
+		"mceliece.templ.go",
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generatePackageFiles()
+}
+
+// Generates instance/name.go from templates/name.templ.go
+func generatePackageFiles() {
+	for _, file := range Templates {
+		tl, err := template.ParseFiles(path.Join("templates", file))
+		if err != nil {
+			panic(err)
+		}
+
+		for _, mode := range Instances {
+			buf := new(bytes.Buffer)
+			err := tl.Execute(buf, mode)
+			if err != nil {
+				panic(err)
+			}
+
+			// Formating output code
+			code, err := format.Source(buf.Bytes())
+			if err != nil {
+				panic(fmt.Sprintf("error formating code: %v", err))
+			}
+
+			res := string(code)
+			offset := strings.Index(res, TemplateWarning)
+			if offset == -1 {
+				panic("Missing template warning in " + file)
+			}
+			out := path.Join(mode.Pkg(), strings.ReplaceAll(file, ".templ", ""))
+			err = ioutil.WriteFile(out, []byte(res[offset:]), 0o644)
+			if err != nil {
+				panic(err)
+			}
+		}
+	}
+}
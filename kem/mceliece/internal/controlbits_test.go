@@ -0,0 +1,38 @@
+package internal
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestControlBits(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for w := 1; w <= 13; w++ {
+		n := 1 << w
+		pi := make([]int16, n)
+		for i, v := range r.Perm(n) {
+			pi[i] = int16(v)
+		}
+		// Panics if the control bits do not implement pi.
+		ControlBitsFromPermutation(make([]byte, ((2*w-1)*n/2+7)/8), pi, w)
+	}
+}
+
+func TestSort(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for n := 0; n < 100; n++ {
+		x := make([]int32, n)
+		y := make([]uint64, n)
+		for i := range x {
+			x[i] = r.Int31() - r.Int31()
+			y[i] = uint64(r.Int63())
+		}
+		SortInt32(x)
+		SortUint64(y)
+		for i := 1; i < n; i++ {
+			if x[i-1] > x[i] || y[i-1] > y[i] {
+				t.Fatalf("not sorted: %v %v", x, y)
+			}
+		}
+	}
+}
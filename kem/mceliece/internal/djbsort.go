@@ -0,0 +1,97 @@
+package internal
+
+// Constant-time sorting networks of djbsort, see https://sorting.cr.yp.to/.
+
+// SortInt32 sorts x in constant time.
+func SortInt32(x []int32) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	top := 1
+	for top < n-top {
+		top += top
+	}
+	for p := top; p > 0; p >>= 1 {
+		for i := 0; i < n-p; i++ {
+			if i&p == 0 {
+				minmaxInt32(&x[i], &x[i+p])
+			}
+		}
+		i := 0
+		for q := top; q > p; q >>= 1 {
+			for ; i < n-q; i++ {
+				if i&p == 0 {
+					a := x[i+p]
+					for r := q; r > p; r >>= 1 {
+						minmaxInt32(&a, &x[i+r])
+					}
+					x[i+p] = a
+				}
+			}
+		}
+	}
+}
+
+// SortUint64 sorts x in constant time. The entries of x must be smaller
+// than 2^63.
+func SortUint64(x []uint64) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	top := 1
+	for top < n-top {
+		top += top
+	}
+	for p := top; p > 0; p >>= 1 {
+		for i := 0; i < n-p; i++ {
+			if i&p == 0 {
+				minmaxUint64(&x[i], &x[i+p])
+			}
+		}
+		i := 0
+		for q := top; q > p; q >>= 1 {
+			for ; i < n-q; i++ {
+				if i&p == 0 {
+					a := x[i+p]
+					for r := q; r > p; r >>= 1 {
+						minmaxUint64(&a, &x[i+r])
+					}
+					x[i+p] = a
+				}
+			}
+		}
+	}
+}
+
+// minmaxInt32 sets (a, b) to (min(a, b), max(a, b)) in constant time.
+func minmaxInt32(a, b *int32) {
+	ab := *b ^ *a
+	c := int32(int64(*b) - int64(*a))
+	c ^= ab & (c ^ *b)
+	c >>= 31
+	c &= ab
+	*a ^= c
+	*b ^= c
+}
+
+// minmaxUint64 sets (a, b) to (min(a, b), max(a, b)) in constant time.
+func minmaxUint64(a, b *uint64) {
+	c := *b - *a
+	c >>= 63
+	c = -c
+	c &= *a ^ *b
+	*a ^= c
+	*b ^= c
+}
+
+// minInt32 returns min(a, b) in constant time.
+func minInt32(a, b int32) int32 {
+	ab := b ^ a
+	c := int32(int64(b) - int64(a))
+	c ^= ab & (c ^ b)
+	c >>= 31
+	c &= ab
+	return a ^ c
+}
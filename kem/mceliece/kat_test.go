@@ -0,0 +1,86 @@
+package mceliece
+
+// Code to generate the NIST "PQCgenKAT" test vectors.
+// See PQCgenKAT_kem.c and randombytes.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+// TestKATRegression checks the digests of the test vectors generated by
+// this package in the format of PQCgenKAT_kem.  They are not taken from the
+// reference implementation, and only catch changes to the output of this
+// package.
+func TestKATRegression(t *testing.T) {
+	kats := []struct {
+		name string
+		want string
+	}{
+		{"mceliece348864", "76351ed2e95a616ca76230bac579cead21012d89181c7398381d0bbe904ab92c"},
+		{"mceliece348864f", "d0d5ea348a181740862dcc8476ff7d00ce44d1c6e36b2145289d97f580f2cd7d"},
+		{"mceliece6960119", "e4d608fa9795c1a1704709ab9df3940ae1dbf0f708cc0dbdf76c8f3173088e46"},
+		{"mceliece6960119f", "d6d3e929ff505108fd545d14df5f5bac234cd6d882f0eed3fd628f122e3093c6"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.name, func(t *testing.T) {
+			if testing.Short() && kat.name[len(kat.name)-1] != 'f' {
+				t.Skip("key generation is too slow for -short")
+			}
+			testPQCgenKATKem(t, kat.name, kat.want)
+		})
+	}
+}
+
+// The reference implementation only generates the first ten test vectors,
+// as the public keys are large. The DRBG is passed to GenerateKeyPairFrom
+// and EncapsulateFrom, which call it as randombytes.
+func testPQCgenKATKem(t *testing.T, name, expected string) {
+	scheme := schemes.ByName(name)
+	if scheme == nil {
+		t.Fatal()
+	}
+
+	var seed [48]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	fmt.Fprintf(f, "# kem/%s\n\n", name)
+	for i := 0; i < 10; i++ {
+		g.Fill(seed[:])
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		g2 := nist.NewDRBG(&seed)
+
+		pk, sk, err := scheme.GenerateKeyPairFrom(&g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+
+		ct, ss, err := scheme.EncapsulateFrom(&g2, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ss2, _ := scheme.Decapsulate(sk, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatal()
+		}
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "ct = %X\n", ct)
+		fmt.Fprintf(f, "ss = %X\n\n", ss)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("got %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,84 @@
+package mceliece
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119"
+)
+
+func TestStream(t *testing.T) {
+	pk, sk, err := mceliece348864f.GenerateKeyPair(nil)
+	test.CheckNoErr(t, err, "GenerateKeyPair failed")
+
+	// Send the public key through a pipe, and encapsulate as it arrives.
+	r, w := io.Pipe()
+	go func() {
+		_, err := pk.WriteTo(w)
+		_ = w.CloseWithError(err)
+	}()
+	seed := make([]byte, mceliece348864f.EncapsulationSeedSize)
+	ct := make([]byte, mceliece348864f.CiphertextSize)
+	ss := make([]byte, mceliece348864f.SharedKeySize)
+	err = mceliece348864f.EncapsulateStream(ct, ss, seed, r)
+	test.CheckNoErr(t, err, "EncapsulateStream failed")
+
+	ct2 := make([]byte, mceliece348864f.CiphertextSize)
+	ss2 := make([]byte, mceliece348864f.SharedKeySize)
+	pk.EncapsulateTo(ct2, ss2, seed)
+	if !bytes.Equal(ct, ct2) || !bytes.Equal(ss, ss2) {
+		t.Fatal("EncapsulateStream differs from EncapsulateTo")
+	}
+	sk.DecapsulateTo(ss2, ct)
+	if !bytes.Equal(ss, ss2) {
+		t.Fatal("shared keys do not match")
+	}
+
+	var buf bytes.Buffer
+	_, err = pk.WriteTo(&buf)
+	test.CheckNoErr(t, err, "WriteTo failed")
+	var pk2 mceliece348864f.PublicKey
+	n, err := pk2.ReadFrom(&buf)
+	test.CheckNoErr(t, err, "ReadFrom failed")
+	if n != mceliece348864f.PublicKeySize || !pk.Equal(&pk2) {
+		t.Fatal("public key does not round-trip")
+	}
+
+	// A truncated public key.
+	ppk, _ := pk.MarshalBinary()
+	err = mceliece348864f.EncapsulateStream(ct, ss, seed, bytes.NewReader(ppk[1:]))
+	test.CheckIsErr(t, err, "EncapsulateStream should fail")
+}
+
+func TestPadding(t *testing.T) {
+	// The rows of the public key and the ciphertext of mceliece6960119 end
+	// with padding bits, which must be zero.
+	sch := mceliece6960119.Scheme()
+	ppk := make([]byte, sch.PublicKeySize())
+	_, err := sch.UnmarshalBinaryPublicKey(ppk)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	ppk[len(ppk)-1] = 0x80
+	_, err = sch.UnmarshalBinaryPublicKey(ppk)
+	if err != kem.ErrPubKey {
+		test.ReportError(t, err, kem.ErrPubKey)
+	}
+	seed := make([]byte, mceliece6960119.EncapsulationSeedSize)
+	ct := make([]byte, mceliece6960119.CiphertextSize)
+	ss := make([]byte, mceliece6960119.SharedKeySize)
+	err = mceliece6960119.EncapsulateStream(ct, ss, seed, bytes.NewReader(ppk))
+	if err != kem.ErrPubKey {
+		test.ReportError(t, err, kem.ErrPubKey)
+	}
+
+	sk, err := sch.UnmarshalBinaryPrivateKey(make([]byte, sch.PrivateKeySize()))
+	test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+	ct[len(ct)-1] = 0x80
+	_, err = sch.Decapsulate(sk, ct)
+	if err != kem.ErrCipherText {
+		test.ReportError(t, err, kem.ErrCipherText)
+	}
+}
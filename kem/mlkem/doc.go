@@ -0,0 +1,13 @@
+// Package mlkem implements the IND-CCA2 secure ML-KEM key encapsulation
+// mechanism (KEM) as defined in FIPS 203
+//
+//  https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.203.pdf
+//
+// ML-KEM is the standardized version of CRYSTALS-Kyber, which is available
+// in the package github.com/cloudflare/circl/kem/kyber. The two are not
+// interoperable: ML-KEM adds domain separation to key generation, drops the
+// hashing of the encapsulation randomness and of the ciphertext, and
+// requires checks on the encapsulation and decapsulation keys.
+//
+// The packages of each parameter set are generated by ../kyber/gen.go.
+package mlkem
@@ -0,0 +1,65 @@
+package mlkem
+
+import (
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+var names = []string{"ML-KEM-512", "ML-KEM-768", "ML-KEM-1024"}
+
+func TestEncapsulationKeyCheck(t *testing.T) {
+	for _, name := range names {
+		scheme := schemes.ByName(name)
+		pk, _, err := scheme.GenerateKeyPair()
+		test.CheckNoErr(t, err, "keygen failed")
+		ppk, err := pk.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+
+		_, err = scheme.UnmarshalBinaryPublicKey(ppk)
+		test.CheckNoErr(t, err, "valid public key rejected")
+
+		// Sets the first coefficient to q, which is not reduced.
+		ppk[0] = 0x01
+		ppk[1] = (ppk[1] & 0xf0) | 0x0d
+		_, err = scheme.UnmarshalBinaryPublicKey(ppk)
+		if err != kem.ErrPubKey {
+			test.ReportError(t, err, kem.ErrPubKey, name)
+		}
+
+		_, err = scheme.UnmarshalBinaryPublicKey(ppk[1:])
+		if err != kem.ErrPubKeySize {
+			test.ReportError(t, err, kem.ErrPubKeySize, name)
+		}
+	}
+}
+
+func TestDecapsulationKeyCheck(t *testing.T) {
+	for _, name := range names {
+		scheme := schemes.ByName(name)
+		_, sk, err := scheme.GenerateKeyPair()
+		test.CheckNoErr(t, err, "keygen failed")
+		psk, err := sk.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+
+		sk2, err := scheme.UnmarshalBinaryPrivateKey(psk)
+		test.CheckNoErr(t, err, "valid private key rejected")
+		if !sk.Equal(sk2) {
+			t.Fatalf("%v: private key does not round-trip", name)
+		}
+
+		// Flips a bit of H(ek), which sits right before z.
+		psk[len(psk)-33] ^= 1
+		_, err = scheme.UnmarshalBinaryPrivateKey(psk)
+		if err != kem.ErrPrivKey {
+			test.ReportError(t, err, kem.ErrPrivKey, name)
+		}
+
+		_, err = scheme.UnmarshalBinaryPrivateKey(psk[1:])
+		if err != kem.ErrPrivKeySize {
+			test.ReportError(t, err, kem.ErrPrivKeySize, name)
+		}
+	}
+}
@@ -0,0 +1,10 @@
+// Package ntruprime provides the key encapsulation mechanism Streamlined
+// NTRU Prime.
+//
+// Compatible with the implementation submitted to round 3 of the NIST PQC
+// competition [1], which is also the one used by OpenSSH [2].
+//
+// References:
+//  [1] https://ntruprime.cr.yp.to/nist/ntruprime-20201007.pdf
+//  [2] https://github.com/openssh/openssh-portable/blob/master/sntrup761.c
+package ntruprime
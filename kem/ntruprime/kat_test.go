@@ -0,0 +1,80 @@
+package ntruprime
+
+// Code to generate the NIST "PQCgenKAT" test vectors.
+// See PQCgenKAT_kem.c and randombytes.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/kem/schemes"
+)
+
+// TestKATRegression checks the digest of the test vectors generated by
+// this package in the format of PQCgenKAT_kem.  It is not taken from the
+// reference implementation, and only catches changes to the output of this
+// package.
+func TestKATRegression(t *testing.T) {
+	kats := []struct {
+		name string
+		want string
+	}{
+		{"sntrup761", "88d9f5a108ff49078e0ad191c510e883558c131d8a825363b3327e610b22e93d"},
+	}
+	for _, kat := range kats {
+		kat := kat
+		t.Run(kat.name, func(t *testing.T) {
+			testPQCgenKATKem(t, kat.name, kat.want)
+		})
+	}
+}
+
+// The randomness of key generation and encapsulation is not of fixed size
+// as the key generation may be retried, and so the DRBG is passed to
+// GenerateKeyPairFrom and EncapsulateFrom, which call it as randombytes.
+func testPQCgenKATKem(t *testing.T, name, expected string) {
+	scheme := schemes.ByName(name)
+	if scheme == nil {
+		t.Fatal()
+	}
+
+	var seed [48]byte
+	for i := 0; i < 48; i++ {
+		seed[i] = byte(i)
+	}
+	f := sha256.New()
+	g := nist.NewDRBG(&seed)
+	fmt.Fprintf(f, "# %s\n\n", name)
+	for i := 0; i < 100; i++ {
+		g.Fill(seed[:])
+		fmt.Fprintf(f, "count = %d\n", i)
+		fmt.Fprintf(f, "seed = %X\n", seed)
+		g2 := nist.NewDRBG(&seed)
+
+		pk, sk, err := scheme.GenerateKeyPairFrom(&g2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ppk, _ := pk.MarshalBinary()
+		psk, _ := sk.MarshalBinary()
+
+		ct, ss, err := scheme.EncapsulateFrom(&g2, pk)
+		if err != nil {
+			t.Fatal(err)
+		}
+		ss2, _ := scheme.Decapsulate(sk, ct)
+		if !bytes.Equal(ss, ss2) {
+			t.Fatal()
+		}
+		fmt.Fprintf(f, "pk = %X\n", ppk)
+		fmt.Fprintf(f, "sk = %X\n", psk)
+		fmt.Fprintf(f, "ct = %X\n", ct)
+		fmt.Fprintf(f, "ss = %X\n\n", ss)
+	}
+	if got := fmt.Sprintf("%x", f.Sum(nil)); got != expected {
+		t.Fatalf("got %s, want %s", got, expected)
+	}
+}
@@ -0,0 +1,116 @@
+package sntrup761
+
+// Constant-time integer arithmetic, following the reference implementation.
+
+// Returns x/m and x mod m. The divisor m must be in 1...16383 and is not
+// treated as secret.
+func uint32DivmodUint14(x uint32, m uint16) (uint32, uint16) {
+	v := uint32(0x80000000) / uint32(m)
+
+	qpart := uint32((uint64(x) * uint64(v)) >> 31)
+	x -= qpart * uint32(m)
+	quo := qpart
+
+	qpart = uint32((uint64(x) * uint64(v)) >> 31)
+	x -= qpart * uint32(m)
+	quo += qpart
+
+	x -= uint32(m)
+	quo++
+	mask := -(x >> 31)
+	x += mask & uint32(m)
+	quo += mask
+
+	return quo, uint16(x)
+}
+
+func uint32ModUint14(x uint32, m uint16) uint16 {
+	_, r := uint32DivmodUint14(x, m)
+	return r
+}
+
+// Returns x mod m in 0...m-1 for a signed x.
+func int32ModUint14(x int32, m uint16) uint16 {
+	_, ur := uint32DivmodUint14(0x80000000+uint32(x), m)
+	_, ur2 := uint32DivmodUint14(0x80000000, m)
+	ur -= ur2
+	mask := -(ur >> 15)
+	ur += mask & m
+	return ur
+}
+
+// Returns 0 if x is zero, and -1 otherwise.
+func int16NonzeroMask(x int16) int {
+	v := uint32(uint16(x))
+	v = -v
+	v >>= 31
+	return -int(v)
+}
+
+// Returns -1 if x is negative, and 0 otherwise.
+func int16NegativeMask(x int16) int {
+	u := uint16(x) >> 15
+	return -int(u)
+}
+
+// Elements of F3 are represented by -1, 0 and 1.
+func f3Freeze(x int32) int8 {
+	return int8(int32ModUint14(x+1, 3)) - 1
+}
+
+// Elements of Fq are represented by -q12...q12.
+func fqFreeze(x int32) int16 {
+	return int16(int32ModUint14(x+q12, q)) - q12
+}
+
+// Returns 1/a in Fq, computed as a^(q-2).
+func fqRecip(a int16) int16 {
+	ai := int16(1)
+	for e := uint(q - 2); e > 0; e >>= 1 {
+		if e&1 == 1 {
+			ai = fqFreeze(int32(ai) * int32(a))
+		}
+		a = fqFreeze(int32(a) * int32(a))
+	}
+	return ai
+}
+
+// sortUint32 sorts x in constant time with the sorting network of djbsort.
+func sortUint32(x []uint32) {
+	n := len(x)
+	if n < 2 {
+		return
+	}
+	top := 1
+	for top < n-top {
+		top += top
+	}
+	for s := top; s > 0; s >>= 1 {
+		for i := 0; i < n-s; i++ {
+			if i&s == 0 {
+				minmax(&x[i], &x[i+s])
+			}
+		}
+		i := 0
+		for t := top; t > s; t >>= 1 {
+			for ; i < n-t; i++ {
+				if i&s == 0 {
+					a := x[i+s]
+					for r := t; r > s; r >>= 1 {
+						minmax(&a, &x[i+r])
+					}
+					x[i+s] = a
+				}
+			}
+		}
+	}
+}
+
+// minmax sets (a, b) to (min(a, b), max(a, b)) in constant time.
+func minmax(a, b *uint32) {
+	ab := *a ^ *b
+	c := uint64(*b) - uint64(*a)
+	mask := uint32(c>>32) & ab
+	*a ^= mask
+	*b ^= mask
+}
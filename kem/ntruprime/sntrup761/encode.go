@@ -0,0 +1,165 @@
+package sntrup761
+
+// encode appends to out the encoding of the sequence R, where each R[i] is
+// in 0...M[i]-1. Pairs of entries are merged recursively, and the bytes
+// of the merged values are emitted as soon as the range allows it.
+func encode(out []byte, R, M []uint16) []byte {
+	if len(R) == 1 {
+		r, m := R[0], M[0]
+		for m > 1 {
+			out = append(out, byte(r))
+			r >>= 8
+			m = (m + 255) >> 8
+		}
+		return out
+	}
+
+	n := len(R)
+	R2 := make([]uint16, (n+1)/2)
+	M2 := make([]uint16, (n+1)/2)
+	i := 0
+	for ; i < n-1; i += 2 {
+		m0 := uint32(M[i])
+		r := uint32(R[i]) + uint32(R[i+1])*m0
+		m := uint32(M[i+1]) * m0
+		for m >= 16384 {
+			out = append(out, byte(r))
+			r >>= 8
+			m = (m + 255) >> 8
+		}
+		R2[i/2] = uint16(r)
+		M2[i/2] = uint16(m)
+	}
+	if i < n {
+		R2[i/2] = R[i]
+		M2[i/2] = M[i]
+	}
+	return encode(out, R2, M2)
+}
+
+// decode is the inverse of encode. The output entries are always reduced
+// modulo M[i], even if S is not a valid encoding. Returns the unread part
+// of S.
+func decode(out []uint16, S []byte, M []uint16) []byte {
+	if len(M) == 1 {
+		switch {
+		case M[0] == 1:
+			out[0] = 0
+		case M[0] <= 256:
+			out[0] = uint32ModUint14(uint32(S[0]), M[0])
+			S = S[1:]
+		default:
+			out[0] = uint32ModUint14(uint32(S[0])+(uint32(S[1])<<8), M[0])
+			S = S[2:]
+		}
+		return S
+	}
+
+	n := len(M)
+	R2 := make([]uint16, (n+1)/2)
+	M2 := make([]uint16, (n+1)/2)
+	bottomr := make([]uint16, n/2)
+	bottomt := make([]uint32, n/2)
+	i := 0
+	for ; i < n-1; i += 2 {
+		m := uint32(M[i]) * uint32(M[i+1])
+		switch {
+		case m > 256*16383:
+			bottomt[i/2] = 256 * 256
+			bottomr[i/2] = uint16(S[0]) + 256*uint16(S[1])
+			S = S[2:]
+			M2[i/2] = uint16((((m + 255) >> 8) + 255) >> 8)
+		case m >= 16384:
+			bottomt[i/2] = 256
+			bottomr[i/2] = uint16(S[0])
+			S = S[1:]
+			M2[i/2] = uint16((m + 255) >> 8)
+		default:
+			bottomt[i/2] = 1
+			bottomr[i/2] = 0
+			M2[i/2] = uint16(m)
+		}
+	}
+	if i < n {
+		M2[i/2] = M[i]
+	}
+	S = decode(R2, S, M2)
+	for i = 0; i < n-1; i += 2 {
+		r := uint32(bottomr[i/2])
+		r += bottomt[i/2] * uint32(R2[i/2])
+		r1, r0 := uint32DivmodUint14(r, M[i])
+		// Only needed for invalid inputs.
+		r1 = uint32(uint32ModUint14(r1, M[i+1]))
+		out[i] = r0
+		out[i+1] = uint16(r1)
+	}
+	if i < n {
+		out[i] = R2[i/2]
+	}
+	return S
+}
+
+func smallEncode(s []byte, f *small) {
+	for i := 0; i < p/4; i++ {
+		x := f[4*i] + 1
+		x += (f[4*i+1] + 1) << 2
+		x += (f[4*i+2] + 1) << 4
+		x += (f[4*i+3] + 1) << 6
+		s[i] = byte(x)
+	}
+	s[p/4] = byte(f[p-1] + 1)
+}
+
+func smallDecode(f *small, s []byte) {
+	for i := 0; i < p/4; i++ {
+		x := s[i]
+		f[4*i] = int8(x&3) - 1
+		x >>= 2
+		f[4*i+1] = int8(x&3) - 1
+		x >>= 2
+		f[4*i+2] = int8(x&3) - 1
+		x >>= 2
+		f[4*i+3] = int8(x&3) - 1
+	}
+	f[p-1] = int8(s[p/4]&3) - 1
+}
+
+func rqEncode(s []byte, r *fqPoly) {
+	var R, M [p]uint16
+	for i := range r {
+		R[i] = uint16(r[i] + q12)
+		M[i] = q
+	}
+	copy(s, encode(make([]byte, 0, rqBytes), R[:], M[:]))
+}
+
+func rqDecode(r *fqPoly, s []byte) {
+	var R, M [p]uint16
+	for i := range M {
+		M[i] = q
+	}
+	decode(R[:], s, M[:])
+	for i := range R {
+		r[i] = int16(R[i]) - q12
+	}
+}
+
+func roundedEncode(s []byte, r *fqPoly) {
+	var R, M [p]uint16
+	for i := range r {
+		R[i] = uint16(((int32(r[i]) + q12) * 10923) >> 15)
+		M[i] = (q + 2) / 3
+	}
+	copy(s, encode(make([]byte, 0, roundedBytes), R[:], M[:]))
+}
+
+func roundedDecode(r *fqPoly, s []byte) {
+	var R, M [p]uint16
+	for i := range M {
+		M[i] = (q + 2) / 3
+	}
+	decode(R[:], s, M[:])
+	for i := range R {
+		r[i] = int16(R[i])*3 - q12
+	}
+}
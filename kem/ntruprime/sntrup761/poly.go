@@ -0,0 +1,220 @@
+package sntrup761
+
+// Polynomials in R3 = F3[x]/(x^p-x-1) and Rq = Fq[x]/(x^p-x-1), where
+// small polynomials have coefficients in -1, 0 and 1.
+type (
+	small   [p]int8
+	fqPoly  [p]int16
+	r3Extra [p + 1]int8
+	rqExtra [p + 1]int16
+)
+
+// Returns 0 if r has weight w, and -1 otherwise.
+func weightwMask(r *small) int {
+	weight := 0
+	for i := range r {
+		weight += int(r[i] & 1)
+	}
+	return int16NonzeroMask(int16(weight - w))
+}
+
+// r3FromRq sets out = r mod 3.
+func r3FromRq(out *small, r *fqPoly) {
+	for i := range r {
+		out[i] = f3Freeze(int32(r[i]))
+	}
+}
+
+// r3Mult sets h = f*g in R3.
+func r3Mult(h, f, g *small) {
+	var fg [p + p - 1]int8
+
+	// Sums of at most p products of small coefficients do not overflow,
+	// so that they are reduced only once.
+	for i := 0; i < p; i++ {
+		var result int32
+		for j := 0; j <= i; j++ {
+			result += int32(f[j]) * int32(g[i-j])
+		}
+		fg[i] = f3Freeze(result)
+	}
+	for i := p; i < p+p-1; i++ {
+		var result int32
+		for j := i - p + 1; j < p; j++ {
+			result += int32(f[j]) * int32(g[i-j])
+		}
+		fg[i] = f3Freeze(result)
+	}
+
+	for i := p + p - 2; i >= p; i-- {
+		fg[i-p] = f3Freeze(int32(fg[i-p]) + int32(fg[i]))
+		fg[i-p+1] = f3Freeze(int32(fg[i-p+1]) + int32(fg[i]))
+	}
+
+	copy(h[:], fg[:p])
+}
+
+// r3Recip sets out = 1/in in R3. Returns 0 on success, and -1 if in is not
+// invertible.
+func r3Recip(out, in *small) int {
+	var f, g, v, r r3Extra
+
+	r[0] = 1
+	f[0] = 1
+	f[p-1] = -1
+	f[p] = -1
+	for i := 0; i < p; i++ {
+		g[p-1-i] = in[i]
+	}
+
+	delta := 1
+	for loop := 0; loop < 2*p-1; loop++ {
+		copy(v[1:], v[:p])
+		v[0] = 0
+
+		sign := -int32(g[0]) * int32(f[0])
+		swap := int16NegativeMask(int16(-delta)) & int16NonzeroMask(int16(g[0]))
+		delta ^= swap & (delta ^ -delta)
+		delta++
+
+		for i := range f {
+			t := int8(swap) & (f[i] ^ g[i])
+			f[i] ^= t
+			g[i] ^= t
+			t = int8(swap) & (v[i] ^ r[i])
+			v[i] ^= t
+			r[i] ^= t
+		}
+
+		for i := range g {
+			g[i] = f3Freeze(int32(g[i]) + sign*int32(f[i]))
+		}
+		for i := range r {
+			r[i] = f3Freeze(int32(r[i]) + sign*int32(v[i]))
+		}
+
+		copy(g[:p], g[1:])
+		g[p] = 0
+	}
+
+	sign := f[0]
+	for i := 0; i < p; i++ {
+		out[i] = sign * v[p-1-i]
+	}
+
+	return int16NonzeroMask(int16(delta))
+}
+
+// rqMultSmall sets h = f*g in Rq.
+func rqMultSmall(h, f *fqPoly, g *small) {
+	var fg [p + p - 1]int16
+
+	// Sums of at most p products of a coefficient of f by a small
+	// coefficient do not overflow, so that they are reduced only once.
+	for i := 0; i < p; i++ {
+		var result int32
+		for j := 0; j <= i; j++ {
+			result += int32(f[j]) * int32(g[i-j])
+		}
+		fg[i] = fqFreeze(result)
+	}
+	for i := p; i < p+p-1; i++ {
+		var result int32
+		for j := i - p + 1; j < p; j++ {
+			result += int32(f[j]) * int32(g[i-j])
+		}
+		fg[i] = fqFreeze(result)
+	}
+
+	for i := p + p - 2; i >= p; i-- {
+		fg[i-p] = fqFreeze(int32(fg[i-p]) + int32(fg[i]))
+		fg[i-p+1] = fqFreeze(int32(fg[i-p+1]) + int32(fg[i]))
+	}
+
+	copy(h[:], fg[:p])
+}
+
+// rqMult3 sets h = 3*f in Rq.
+func rqMult3(h, f *fqPoly) {
+	for i := range f {
+		h[i] = fqFreeze(3 * int32(f[i]))
+	}
+}
+
+// rqRecip3 sets out = 1/(3*in) in Rq. Returns 0 on success, and -1 if in
+// is not invertible, which never happens for q prime and x^p-x-1
+// irreducible modulo q.
+func rqRecip3(out *fqPoly, in *small) int {
+	var f, g, v, r rqExtra
+
+	r[0] = fqRecip(3)
+	f[0] = 1
+	f[p-1] = -1
+	f[p] = -1
+	for i := 0; i < p; i++ {
+		g[p-1-i] = int16(in[i])
+	}
+
+	delta := 1
+	for loop := 0; loop < 2*p-1; loop++ {
+		copy(v[1:], v[:p])
+		v[0] = 0
+
+		swap := int16NegativeMask(int16(-delta)) & int16NonzeroMask(g[0])
+		delta ^= swap & (delta ^ -delta)
+		delta++
+
+		for i := range f {
+			t := int16(swap) & (f[i] ^ g[i])
+			f[i] ^= t
+			g[i] ^= t
+			t = int16(swap) & (v[i] ^ r[i])
+			v[i] ^= t
+			r[i] ^= t
+		}
+
+		f0 := int32(f[0])
+		g0 := int32(g[0])
+		for i := range g {
+			g[i] = fqFreeze(f0*int32(g[i]) - g0*int32(f[i]))
+		}
+		for i := range r {
+			r[i] = fqFreeze(f0*int32(r[i]) - g0*int32(v[i]))
+		}
+
+		copy(g[:p], g[1:])
+		g[p] = 0
+	}
+
+	scale := int32(fqRecip(f[0]))
+	for i := 0; i < p; i++ {
+		out[i] = fqFreeze(scale * int32(v[p-1-i]))
+	}
+
+	return int16NonzeroMask(int16(delta))
+}
+
+// round sets out to a with each coefficient rounded to the nearest
+// multiple of 3.
+func round(out, a *fqPoly) {
+	for i := range a {
+		out[i] = a[i] - int16(f3Freeze(int32(a[i])))
+	}
+}
+
+// shortFromList sets out to the short polynomial, with exactly w nonzero
+// coefficients, determined by the random words in.
+func shortFromList(out *small, in *[p]uint32) {
+	var L [p]uint32
+
+	for i := 0; i < w; i++ {
+		L[i] = in[i] &^ 1
+	}
+	for i := w; i < p; i++ {
+		L[i] = (in[i] &^ 2) | 1
+	}
+	sortUint32(L[:])
+	for i := range L {
+		out[i] = int8(L[i]&3) - 1
+	}
+}
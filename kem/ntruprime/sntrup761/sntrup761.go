@@ -0,0 +1,446 @@
+// Package sntrup761 implements the IND-CCA2 secure key encapsulation
+// mechanism sntrup761 of Streamlined NTRU Prime as submitted to round 3 of
+// the NIST PQC competition and described in
+//
+//  https://ntruprime.cr.yp.to/nist/ntruprime-20201007.pdf
+//
+// This is the KEM used by the sntrup761x25519-sha512 key exchange of
+// OpenSSH, see github.com/cloudflare/circl/kem/hybrid.
+package sntrup761
+
+import (
+	"bytes"
+	cryptoRand "crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem"
+)
+
+const (
+	p   = 761
+	q   = 4591
+	w   = 286
+	q12 = (q - 1) / 2
+
+	rqBytes      = 1158
+	roundedBytes = 1007
+	smallBytes   = (p + 3) / 4
+	inputsBytes  = smallBytes
+	hashBytes    = 32
+	confirmBytes = 32
+
+	// Size of the encoding of (f, 1/g).
+	secretKeysBytes = 2 * smallBytes
+)
+
+const (
+	// Size of seed for DeriveKeyPair.
+	KeySeedSize = 32
+
+	// Size of seed for EncapsulateDeterministically.
+	EncapsulationSeedSize = 32
+
+	// Size of the established shared key.
+	SharedKeySize = 32
+
+	// Size of the encapsulated shared key.
+	CiphertextSize = roundedBytes + confirmBytes
+
+	// Size of a packed public key.
+	PublicKeySize = rqBytes
+
+	// Size of a packed private key.
+	PrivateKeySize = secretKeysBytes + PublicKeySize + inputsBytes + hashBytes
+)
+
+// Type of a sntrup761 public key.
+type PublicKey struct {
+	h      fqPoly
+	packed [PublicKeySize]byte
+
+	// Hash4(packed), cached for encapsulation.
+	cache [hashBytes]byte
+}
+
+// Type of a sntrup761 private key.
+type PrivateKey struct {
+	f    small
+	ginv small
+	pk   *PublicKey
+
+	// Returned in place of the decrypted input on decapsulation failure.
+	rho [inputsBytes]byte
+}
+
+// hashPrefix returns the first 32 bytes of SHA-512(b ‖ in...).
+func hashPrefix(out *[hashBytes]byte, b byte, in ...[]byte) {
+	h := sha512.New()
+	_, _ = h.Write([]byte{b})
+	for _, x := range in {
+		_, _ = h.Write(x)
+	}
+	var sum [sha512.Size]byte
+	copy(out[:], h.Sum(sum[:0]))
+}
+
+// urandom32 returns a random word read from rand with a single call.
+func urandom32(rand io.Reader) (uint32, error) {
+	var c [4]byte
+	if _, err := io.ReadFull(rand, c[:]); err != nil {
+		return 0, err
+	}
+	return uint32(c[0]) | uint32(c[1])<<8 | uint32(c[2])<<16 | uint32(c[3])<<24, nil
+}
+
+func shortRandom(out *small, rand io.Reader) error {
+	var L [p]uint32
+	for i := range L {
+		r, err := urandom32(rand)
+		if err != nil {
+			return err
+		}
+		L[i] = r
+	}
+	shortFromList(out, &L)
+	return nil
+}
+
+func smallRandom(out *small, rand io.Reader) error {
+	for i := range out {
+		r, err := urandom32(rand)
+		if err != nil {
+			return err
+		}
+		out[i] = int8(((r&0x3fffffff)*3)>>30) - 1
+	}
+	return nil
+}
+
+// generateKeyPair generates a key pair with randomness from rand. In order
+// to reproduce the KATs of the reference implementation, rand is read in
+// the same sequence of calls as randombytes: one for each random word, and
+// a last one for rho.
+func generateKeyPair(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	var g small
+	var finv fqPoly
+	pk := new(PublicKey)
+	sk := &PrivateKey{pk: pk}
+
+	for {
+		if err := smallRandom(&g, rand); err != nil {
+			return nil, nil, err
+		}
+		if r3Recip(&sk.ginv, &g) == 0 {
+			break
+		}
+	}
+	if err := shortRandom(&sk.f, rand); err != nil {
+		return nil, nil, err
+	}
+	rqRecip3(&finv, &sk.f) // always works
+	rqMultSmall(&pk.h, &finv, &g)
+
+	rqEncode(pk.packed[:], &pk.h)
+	hashPrefix(&pk.cache, 4, pk.packed[:])
+
+	if _, err := io.ReadFull(rand, sk.rho[:]); err != nil {
+		return nil, nil, err
+	}
+	return pk, sk, nil
+}
+
+// hide computes the ciphertext of the input r, and its encoding rEnc.
+func (pk *PublicKey) hide(ct []byte, rEnc *[inputsBytes]byte, r *small) {
+	var hr, c fqPoly
+	var x [2 * hashBytes]byte
+	var confirm [hashBytes]byte
+
+	smallEncode(rEnc[:], r)
+
+	rqMultSmall(&hr, &pk.h, r)
+	round(&c, &hr)
+	roundedEncode(ct[:roundedBytes], &c)
+
+	// HashConfirm(r, pk) = Hash2(Hash3(r) ‖ Hash4(pk))
+	var hr3 [hashBytes]byte
+	hashPrefix(&hr3, 3, rEnc[:])
+	copy(x[:hashBytes], hr3[:])
+	copy(x[hashBytes:], pk.cache[:])
+	hashPrefix(&confirm, 2, x[:])
+	copy(ct[roundedBytes:], confirm[:])
+}
+
+// hashSession sets ss = Hash_b(Hash3(rEnc) ‖ ct).
+func hashSession(ss []byte, b byte, rEnc *[inputsBytes]byte, ct []byte) {
+	var hr3, k [hashBytes]byte
+	hashPrefix(&hr3, 3, rEnc[:])
+	hashPrefix(&k, b, hr3[:], ct)
+	copy(ss, k[:])
+}
+
+// encapsulateTo generates a shared key and a ciphertext containing said
+// key with randomness from rand, which is read in the same sequence of
+// calls as randombytes in the reference implementation.
+func (pk *PublicKey) encapsulateTo(ct, ss []byte, rand io.Reader) error {
+	var r small
+	var rEnc [inputsBytes]byte
+
+	if err := shortRandom(&r, rand); err != nil {
+		return err
+	}
+	pk.hide(ct, &rEnc, &r)
+	hashSession(ss, 1, &rEnc, ct)
+	return nil
+}
+
+// decapsulateTo computes the shared key that is encapsulated in ct.
+func (sk *PrivateKey) decapsulateTo(ss, ct []byte) {
+	var c, cf, cf3 fqPoly
+	var e, ev, r small
+	var rEnc [inputsBytes]byte
+	var cnew [CiphertextSize]byte
+
+	// Decrypt
+	roundedDecode(&c, ct[:roundedBytes])
+	rqMultSmall(&cf, &c, &sk.f)
+	rqMult3(&cf3, &cf)
+	r3FromRq(&e, &cf3)
+	r3Mult(&ev, &e, &sk.ginv)
+
+	mask := int8(weightwMask(&ev)) // 0 if weight w, else -1
+	for i := 0; i < w; i++ {
+		r[i] = ((ev[i] ^ 1) &^ mask) ^ 1
+	}
+	for i := w; i < p; i++ {
+		r[i] = ev[i] &^ mask
+	}
+
+	// Re-encrypt, and use rho in place of r if ct does not match.
+	sk.pk.hide(cnew[:], &rEnc, &r)
+	eq := subtle.ConstantTimeCompare(ct, cnew[:])
+	subtle.ConstantTimeCopy(1-eq, rEnc[:], sk.rho[:])
+	hashSession(ss, byte(eq), &rEnc, ct)
+}
+
+// Packs pk to buf.
+//
+// Panics if buf is not of size PublicKeySize.
+func (pk *PublicKey) Pack(buf []byte) {
+	if len(buf) != PublicKeySize {
+		panic("buf must be of length PublicKeySize")
+	}
+	copy(buf, pk.packed[:])
+}
+
+// Unpacks pk from buf.
+//
+// Panics if buf is not of size PublicKeySize.
+func (pk *PublicKey) Unpack(buf []byte) {
+	if len(buf) != PublicKeySize {
+		panic("buf must be of length PublicKeySize")
+	}
+	copy(pk.packed[:], buf)
+	rqDecode(&pk.h, pk.packed[:])
+	hashPrefix(&pk.cache, 4, pk.packed[:])
+}
+
+// Packs sk to buf.
+//
+// Panics if buf is not of size PrivateKeySize.
+func (sk *PrivateKey) Pack(buf []byte) {
+	if len(buf) != PrivateKeySize {
+		panic("buf must be of length PrivateKeySize")
+	}
+	smallEncode(buf[:smallBytes], &sk.f)
+	buf = buf[smallBytes:]
+	smallEncode(buf[:smallBytes], &sk.ginv)
+	buf = buf[smallBytes:]
+	sk.pk.Pack(buf[:PublicKeySize])
+	buf = buf[PublicKeySize:]
+	copy(buf[:inputsBytes], sk.rho[:])
+	buf = buf[inputsBytes:]
+	copy(buf, sk.pk.cache[:])
+}
+
+// Unpacks sk from buf.
+//
+// Panics if buf is not of size PrivateKeySize.
+func (sk *PrivateKey) Unpack(buf []byte) {
+	if len(buf) != PrivateKeySize {
+		panic("buf must be of length PrivateKeySize")
+	}
+	smallDecode(&sk.f, buf[:smallBytes])
+	buf = buf[smallBytes:]
+	smallDecode(&sk.ginv, buf[:smallBytes])
+	buf = buf[smallBytes:]
+	sk.pk = new(PublicKey)
+	sk.pk.Unpack(buf[:PublicKeySize])
+	buf = buf[PublicKeySize:]
+	copy(sk.rho[:], buf[:inputsBytes])
+	// The cached hash of the public key is recomputed by Unpack.
+}
+
+// Boilerplate down below for the KEM scheme API.
+
+type scheme struct{}
+
+var sch kem.Scheme = &scheme{}
+
+// Scheme returns a KEM interface.
+func Scheme() kem.Scheme { return sch }
+
+func (*scheme) Name() string                { return "sntrup761" }
+func (*scheme) PublicKeySize() int         { return PublicKeySize }
+func (*scheme) PrivateKeySize() int        { return PrivateKeySize }
+func (*scheme) SeedSize() int              { return KeySeedSize }
+func (*scheme) SharedKeySize() int         { return SharedKeySize }
+func (*scheme) CiphertextSize() int        { return CiphertextSize }
+func (*scheme) EncapsulationSeedSize() int { return EncapsulationSeedSize }
+
+func (sk *PrivateKey) Scheme() kem.Scheme { return sch }
+func (pk *PublicKey) Scheme() kem.Scheme  { return sch }
+
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	var ret [PrivateKeySize]byte
+	sk.Pack(ret[:])
+	return ret[:], nil
+}
+
+func (sk *PrivateKey) Equal(other kem.PrivateKey) bool {
+	oth, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	if sk.pk == nil && oth.pk == nil {
+		return true
+	}
+	if sk.pk == nil || oth.pk == nil {
+		return false
+	}
+	var a, b [PrivateKeySize]byte
+	sk.Pack(a[:])
+	oth.Pack(b[:])
+	return subtle.ConstantTimeCompare(a[:], b[:]) == 1
+}
+
+func (sk *PrivateKey) Public() kem.PublicKey {
+	return sk.pk
+}
+
+func (pk *PublicKey) Equal(other kem.PublicKey) bool {
+	oth, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return bytes.Equal(pk.packed[:], oth.packed[:])
+}
+
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	var ret [PublicKeySize]byte
+	pk.Pack(ret[:])
+	return ret[:], nil
+}
+
+func (*scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
+	return generateKeyPair(cryptoRand.Reader)
+}
+
+// GenerateKeyPairFrom generates a key pair reading randomness from rand in
+// the same sequence of calls as the reference implementation, so that a
+// NIST KAT DRBG reproduces the reference KATs.
+func (*scheme) GenerateKeyPairFrom(rand io.Reader) (
+	kem.PublicKey, kem.PrivateKey, error,
+) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	return generateKeyPair(rand)
+}
+
+// DeriveKeyPair derives a key pair from seed, which is expanded with
+// SHAKE256 into the randomness of the key generation.
+func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
+	if len(seed) != KeySeedSize {
+		panic(kem.ErrSeedSize)
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	pk, sk, err := generateKeyPair(&h)
+	if err != nil {
+		panic(err)
+	}
+	return pk, sk
+}
+
+func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct, ss []byte, err error) {
+	return sch.EncapsulateFrom(cryptoRand.Reader, pk)
+}
+
+// EncapsulateFrom encapsulates reading randomness from rand in the same
+// sequence of calls as the reference implementation.
+func (*scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (
+	ct, ss []byte, err error,
+) {
+	pub, ok := pk.(*PublicKey)
+	if !ok {
+		return nil, nil, kem.ErrTypeMismatch
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+
+	ct = make([]byte, CiphertextSize)
+	ss = make([]byte, SharedKeySize)
+	if err = pub.encapsulateTo(ct, ss, rand); err != nil {
+		return nil, nil, err
+	}
+	return ct, ss, nil
+}
+
+// EncapsulateDeterministically encapsulates using the seed expanded with
+// SHAKE256 as randomness.
+func (sch *scheme) EncapsulateDeterministically(
+	pk kem.PublicKey, seed []byte,
+) (ct, ss []byte, err error) {
+	if len(seed) != EncapsulationSeedSize {
+		return nil, nil, kem.ErrSeedSize
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	return sch.EncapsulateFrom(&h, pk)
+}
+
+func (*scheme) Decapsulate(sk kem.PrivateKey, ct []byte) ([]byte, error) {
+	if len(ct) != CiphertextSize {
+		return nil, kem.ErrCiphertextSize
+	}
+
+	priv, ok := sk.(*PrivateKey)
+	if !ok {
+		return nil, kem.ErrTypeMismatch
+	}
+	ss := make([]byte, SharedKeySize)
+	priv.decapsulateTo(ss, ct)
+	return ss, nil
+}
+
+func (*scheme) UnmarshalBinaryPublicKey(buf []byte) (kem.PublicKey, error) {
+	if len(buf) != PublicKeySize {
+		return nil, kem.ErrPubKeySize
+	}
+	var ret PublicKey
+	ret.Unpack(buf)
+	return &ret, nil
+}
+
+func (*scheme) UnmarshalBinaryPrivateKey(buf []byte) (kem.PrivateKey, error) {
+	if len(buf) != PrivateKeySize {
+		return nil, kem.ErrPrivKeySize
+	}
+	var ret PrivateKey
+	ret.Unpack(buf)
+	return &ret, nil
+}
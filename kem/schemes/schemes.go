@@ -4,39 +4,115 @@
 //
 // Based on standard elliptic curves:
 //  HPKE_KEM_P256_HKDF_SHA256, HPKE_KEM_P384_HKDF_SHA384, HPKE_KEM_P521_HKDF_SHA512
+//  HPKE_KEM_K256_HKDF_SHA256
 // Based on standard Diffie-Hellman functions:
 //  HPKE_KEM_X25519_HKDF_SHA256, HPKE_KEM_X448_HKDF_SHA512
 // Post-quantum kems:
-//  FrodoKEM-640-SHAKE
+//  CSIDH-512
+//  FrodoKEM-640-SHAKE, FrodoKEM-976-SHAKE, FrodoKEM-1344-SHAKE
+//  FrodoKEM-640-AES, FrodoKEM-976-AES, FrodoKEM-1344-AES
+//  HQC-128, HQC-192, HQC-256
 //  Kyber512, Kyber768, Kyber1024
+//  ML-KEM-512, ML-KEM-768, ML-KEM-1024
+//  mceliece348864, mceliece348864f, mceliece6960119, mceliece6960119f
+//  sntrup761
 //  SIKEp434, SIKEp503, SIKEp751
+// Hybrid KEMs:
+//  Kyber512-X25519, Kyber768-X25519, Kyber768-X448, Kyber1024-X448
+//  X-Wing
+//  sntrup761x25519-sha512
+//
+// Identifiers
+//
+// Schemes with an OID, a TLS NamedGroup codepoint or an HPKE KEM identifier
+// implement kem.CertificateScheme, kem.TLSScheme or kem.HPKEScheme
+// respectively, and can be looked up with ByOID, ByTLSGroup and ByHPKEID.
+// These are:
+//  HPKE_KEM_P256_HKDF_SHA256, HPKE_KEM_P384_HKDF_SHA384,
+//  HPKE_KEM_P521_HKDF_SHA512: HPKE 0x0010, 0x0011, 0x0012
+//  HPKE_KEM_X25519_HKDF_SHA256: HPKE 0x0020, OID 1.3.101.110
+//  HPKE_KEM_X448_HKDF_SHA512: HPKE 0x0021, OID 1.3.101.111
+//  ML-KEM-512, ML-KEM-768, ML-KEM-1024: HPKE 0x0040, 0x0041, 0x0042,
+//    TLS 0x0200, 0x0201, 0x0202, OID 2.16.840.1.101.3.4.4.{1,2,3}
+//  X-Wing: HPKE 0x647a, OID 1.3.6.1.4.1.62253.25722
+//  HPKE_KEM_K256_HKDF_SHA256: HPKE 0x0030, see below
+//
+// HPKE_KEM_K256_HKDF_SHA256 reports the identifier under which package hpke
+// implements it, which is not registered for this KEM: IANA assigns 0x0030
+// to X25519Kyber768Draft00.
+//
+// The other schemes have no assigned identifier that matches their
+// encoding. FrodoKEM, HQC, Classic McEliece, Kyber, sntrup761, SIKE and
+// CSIDH have no OID, TLS group or HPKE KEM identifier. The Kyber hybrids do
+// not match the X25519Kyber768Draft00 codepoints of TLS and HPKE, which put
+// X25519 first and do not hash its shared secret with HKDF.
+// sntrup761x25519-sha512 is only identified in SSH, by its name. The
+// public keys of the NIST curves are identified in X.509 by id-ecPublicKey
+// and a curve parameter, which does not fit kem.CertificateScheme.
 package schemes
 
 import (
+	"encoding/asn1"
 	"strings"
 
 	"github.com/cloudflare/circl/hpke"
 	"github.com/cloudflare/circl/kem"
+	"github.com/cloudflare/circl/kem/csidh/csidh512"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo1344shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo640aes"
 	"github.com/cloudflare/circl/kem/frodo/frodo640shake"
+	"github.com/cloudflare/circl/kem/frodo/frodo976aes"
+	"github.com/cloudflare/circl/kem/frodo/frodo976shake"
+	"github.com/cloudflare/circl/kem/hqc/hqc128"
+	"github.com/cloudflare/circl/kem/hqc/hqc192"
+	"github.com/cloudflare/circl/kem/hqc/hqc256"
 	"github.com/cloudflare/circl/kem/hybrid"
 	"github.com/cloudflare/circl/kem/kyber/kyber1024"
 	"github.com/cloudflare/circl/kem/kyber/kyber512"
 	"github.com/cloudflare/circl/kem/kyber/kyber768"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece348864f"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119"
+	"github.com/cloudflare/circl/kem/mceliece/mceliece6960119f"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem1024"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem512"
+	"github.com/cloudflare/circl/kem/mlkem/mlkem768"
+	"github.com/cloudflare/circl/kem/ntruprime/sntrup761"
 	"github.com/cloudflare/circl/kem/sike/sikep434"
 	"github.com/cloudflare/circl/kem/sike/sikep503"
 	"github.com/cloudflare/circl/kem/sike/sikep751"
+	"github.com/cloudflare/circl/kem/xwing"
 )
 
 var allSchemes = [...]kem.Scheme{
 	hpke.KEM_P256_HKDF_SHA256.Scheme(),
 	hpke.KEM_P384_HKDF_SHA384.Scheme(),
 	hpke.KEM_P521_HKDF_SHA512.Scheme(),
+	hpke.KEM_K256_HKDF_SHA256.Scheme(),
 	hpke.KEM_X25519_HKDF_SHA256.Scheme(),
 	hpke.KEM_X448_HKDF_SHA512.Scheme(),
+	csidh512.Scheme(),
 	frodo640shake.Scheme(),
+	frodo976shake.Scheme(),
+	frodo1344shake.Scheme(),
+	frodo640aes.Scheme(),
+	frodo976aes.Scheme(),
+	frodo1344aes.Scheme(),
+	hqc128.Scheme(),
+	hqc192.Scheme(),
+	hqc256.Scheme(),
 	kyber512.Scheme(),
 	kyber768.Scheme(),
 	kyber1024.Scheme(),
+	mlkem512.Scheme(),
+	mlkem768.Scheme(),
+	mlkem1024.Scheme(),
+	mceliece348864.Scheme(),
+	mceliece348864f.Scheme(),
+	mceliece6960119.Scheme(),
+	mceliece6960119f.Scheme(),
+	sntrup761.Scheme(),
 	sikep434.Scheme(),
 	sikep503.Scheme(),
 	sikep751.Scheme(),
@@ -44,14 +120,33 @@ var allSchemes = [...]kem.Scheme{
 	hybrid.Kyber768X25519(),
 	hybrid.Kyber768X448(),
 	hybrid.Kyber1024X448(),
+	xwing.Scheme(),
+	hybrid.Sntrup761X25519(),
 }
 
-var allSchemeNames map[string]kem.Scheme
+var (
+	allSchemeNames  map[string]kem.Scheme
+	allSchemesByOID map[string]kem.Scheme
+	allSchemesByTLS map[uint]kem.Scheme
+	allSchemesByID  map[uint]kem.Scheme
+)
 
 func init() {
 	allSchemeNames = make(map[string]kem.Scheme)
+	allSchemesByOID = make(map[string]kem.Scheme)
+	allSchemesByTLS = make(map[uint]kem.Scheme)
+	allSchemesByID = make(map[uint]kem.Scheme)
 	for _, scheme := range allSchemes {
 		allSchemeNames[strings.ToLower(scheme.Name())] = scheme
+		if cert, ok := scheme.(kem.CertificateScheme); ok {
+			allSchemesByOID[cert.Oid().String()] = scheme
+		}
+		if tlsScheme, ok := scheme.(kem.TLSScheme); ok {
+			allSchemesByTLS[tlsScheme.TLSIdentifier()] = scheme
+		}
+		if hpkeScheme, ok := scheme.(kem.HPKEScheme); ok {
+			allSchemesByID[hpkeScheme.HPKEIdentifier()] = scheme
+		}
 	}
 }
 
@@ -63,5 +158,19 @@ func ByName(name string) kem.Scheme {
 	return allSchemeNames[strings.ToLower(name)]
 }
 
+// ByOID returns the scheme whose public keys have the given OID and nil if
+// it is not supported.
+func ByOID(oid asn1.ObjectIdentifier) kem.Scheme {
+	return allSchemesByOID[oid.String()]
+}
+
+// ByTLSGroup returns the scheme with the given TLS NamedGroup codepoint
+// and nil if it is not supported.
+func ByTLSGroup(id uint) kem.Scheme { return allSchemesByTLS[id] }
+
+// ByHPKEID returns the scheme with the given HPKE KEM identifier and nil if
+// it is not supported.
+func ByHPKEID(id uint) kem.Scheme { return allSchemesByID[id] }
+
 // All returns all KEM schemes supported.
 func All() []kem.Scheme { a := allSchemes; return a[:] }
@@ -2,9 +2,13 @@ package schemes_test
 
 import (
 	"bytes"
+	"encoding/asn1"
+	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/kem"
 	"github.com/cloudflare/circl/kem/schemes"
 )
 
@@ -14,6 +18,72 @@ func TestCaseSensitivity(t *testing.T) {
 	}
 }
 
+func TestIdentifiers(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		oid  asn1.ObjectIdentifier
+		tls  uint
+		hpke uint
+	}{
+		{"HPKE_KEM_P256_HKDF_SHA256", nil, 0, 0x0010},
+		{"HPKE_KEM_P384_HKDF_SHA384", nil, 0, 0x0011},
+		{"HPKE_KEM_P521_HKDF_SHA512", nil, 0, 0x0012},
+		{"HPKE_KEM_K256_HKDF_SHA256", nil, 0, 0x0030},
+		{"HPKE_KEM_X25519_HKDF_SHA256", asn1.ObjectIdentifier{1, 3, 101, 110}, 0, 0x0020},
+		{"HPKE_KEM_X448_HKDF_SHA512", asn1.ObjectIdentifier{1, 3, 101, 111}, 0, 0x0021},
+		{"ML-KEM-512", asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 1}, 0x0200, 0x0040},
+		{"ML-KEM-768", asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 2}, 0x0201, 0x0041},
+		{"ML-KEM-1024", asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 4, 3}, 0x0202, 0x0042},
+		{"X-Wing", asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 62253, 25722}, 0, 0x647a},
+	} {
+		want := schemes.ByName(c.name)
+		if want == nil {
+			t.Fatalf("%v is not registered", c.name)
+		}
+		if c.oid != nil && schemes.ByOID(c.oid) != want {
+			t.Fatalf("ByOID(%v) is not %v", c.oid, c.name)
+		}
+		if c.tls != 0 && schemes.ByTLSGroup(c.tls) != want {
+			t.Fatalf("ByTLSGroup(0x%04x) is not %v", c.tls, c.name)
+		}
+		if c.hpke != 0 && schemes.ByHPKEID(c.hpke) != want {
+			t.Fatalf("ByHPKEID(0x%04x) is not %v", c.hpke, c.name)
+		}
+	}
+
+	// Identifiers must be unique and resolve to the scheme they come from.
+	for _, sch := range schemes.All() {
+		if s, ok := sch.(kem.CertificateScheme); ok && schemes.ByOID(s.Oid()) != sch {
+			t.Fatalf("OID of %v is not unique", sch.Name())
+		}
+		if s, ok := sch.(kem.TLSScheme); ok && schemes.ByTLSGroup(s.TLSIdentifier()) != sch {
+			t.Fatalf("TLS identifier of %v is not unique", sch.Name())
+		}
+		if s, ok := sch.(kem.HPKEScheme); ok && schemes.ByHPKEID(s.HPKEIdentifier()) != sch {
+			t.Fatalf("HPKE identifier of %v is not unique", sch.Name())
+		}
+	}
+
+	// Schemes without an assigned identifier.
+	for _, name := range []string{
+		"FrodoKEM-640-SHAKE", "HQC-128", "mceliece348864", "Kyber768",
+		"sntrup761", "Kyber768-X25519", "sntrup761x25519-sha512",
+	} {
+		sch := schemes.ByName(name)
+		_, isCert := sch.(kem.CertificateScheme)
+		_, isTLS := sch.(kem.TLSScheme)
+		_, isHPKE := sch.(kem.HPKEScheme)
+		if isCert || isTLS || isHPKE {
+			t.Fatalf("%v has an identifier", name)
+		}
+	}
+
+	if schemes.ByOID(asn1.ObjectIdentifier{1, 2, 3}) != nil ||
+		schemes.ByTLSGroup(0xFFFF) != nil || schemes.ByHPKEID(0xFFFF) != nil {
+		t.Fatal("unknown identifiers should not resolve")
+	}
+}
+
 func BenchmarkGenerateKeyPair(b *testing.B) {
 	allSchemes := schemes.All()
 	for _, scheme := range allSchemes {
@@ -139,6 +209,95 @@ func TestApi(t *testing.T) {
 	}
 }
 
+func newReader(seed string) *sha3.State {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(seed))
+	return &h
+}
+
+type failingReader struct{}
+
+func (failingReader) Read([]byte) (int, error) { return 0, errors.New("no randomness") }
+
+func TestRandomnessFrom(t *testing.T) {
+	for _, scheme := range schemes.All() {
+		scheme := scheme
+		t.Run(scheme.Name(), func(t *testing.T) {
+			pk1, sk1, err := scheme.GenerateKeyPairFrom(newReader("keygen"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			pk2, sk2, err := scheme.GenerateKeyPairFrom(newReader("keygen"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !pk1.Equal(pk2) || !sk1.Equal(sk2) {
+				t.Fatal("keys generated from the same randomness differ")
+			}
+			pk3, _, err := scheme.GenerateKeyPairFrom(newReader("other"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if pk1.Equal(pk3) {
+				t.Fatal("keys generated from different randomness are equal")
+			}
+			if _, _, err = scheme.GenerateKeyPairFrom(nil); err != nil {
+				t.Fatal(err)
+			}
+
+			ct1, ss1, err := scheme.EncapsulateFrom(newReader("encap"), pk1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ct2, ss2, err := scheme.EncapsulateFrom(newReader("encap"), pk1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ct1, ct2) || !bytes.Equal(ss1, ss2) {
+				t.Fatal("encapsulations from the same randomness differ")
+			}
+			ss3, err := scheme.Decapsulate(sk2, ct1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(ss1, ss3) {
+				t.Fatal("decapsulation failed")
+			}
+			if _, _, err = scheme.EncapsulateFrom(nil, pk1); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, _, err = scheme.GenerateKeyPairFrom(failingReader{}); err == nil {
+				t.Fatal("reader error was not reported")
+			}
+			if _, _, err = scheme.EncapsulateFrom(failingReader{}, pk1); err == nil {
+				t.Fatal("reader error was not reported")
+			}
+
+			if auth, ok := scheme.(kem.AuthScheme); ok {
+				ct4, ss4, err := auth.AuthEncapsulateFrom(newReader("encap"), pk1, sk1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				ct5, ss5, err := auth.AuthEncapsulateFrom(newReader("encap"), pk1, sk1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(ct4, ct5) || !bytes.Equal(ss4, ss5) {
+					t.Fatal("encapsulations from the same randomness differ")
+				}
+				ss6, err := auth.AuthDecapsulate(sk1, ct4, pk1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !bytes.Equal(ss4, ss6) {
+					t.Fatal("decapsulation failed")
+				}
+			}
+		})
+	}
+}
+
 func Example_schemes() {
 	// import "github.com/cloudflare/circl/kem/schemes"
 
@@ -149,12 +308,30 @@ func Example_schemes() {
 	// HPKE_KEM_P256_HKDF_SHA256
 	// HPKE_KEM_P384_HKDF_SHA384
 	// HPKE_KEM_P521_HKDF_SHA512
+	// HPKE_KEM_K256_HKDF_SHA256
 	// HPKE_KEM_X25519_HKDF_SHA256
 	// HPKE_KEM_X448_HKDF_SHA512
+	// CSIDH-512
 	// FrodoKEM-640-SHAKE
+	// FrodoKEM-976-SHAKE
+	// FrodoKEM-1344-SHAKE
+	// FrodoKEM-640-AES
+	// FrodoKEM-976-AES
+	// FrodoKEM-1344-AES
+	// HQC-128
+	// HQC-192
+	// HQC-256
 	// Kyber512
 	// Kyber768
 	// Kyber1024
+	// ML-KEM-512
+	// ML-KEM-768
+	// ML-KEM-1024
+	// mceliece348864
+	// mceliece348864f
+	// mceliece6960119
+	// mceliece6960119f
+	// sntrup761
 	// SIKEp434
 	// SIKEp503
 	// SIKEp751
@@ -162,4 +339,6 @@ func Example_schemes() {
 	// Kyber768-X25519
 	// Kyber768-X448
 	// Kyber1024-X448
+	// X-Wing
+	// sntrup761x25519-sha512
 }
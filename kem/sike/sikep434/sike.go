@@ -87,8 +87,13 @@ func (pk *PublicKey) MarshalBinary() ([]byte, error) {
 	return ret, nil
 }
 
+// GenerateKeyPair generates public and private keys using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
 func GenerateKeyPair(rand io.Reader) (kem.PublicKey, kem.PrivateKey, error) {
 	sk := sidh.NewPrivateKey(sidh.Fp434, sidh.KeyVariantSike)
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
 
 	if err := sk.Generate(rand); err != nil {
 		return nil, nil, err
@@ -102,6 +107,10 @@ func (*scheme) GenerateKeyPair() (kem.PublicKey, kem.PrivateKey, error) {
 	return GenerateKeyPair(cryptoRand.Reader)
 }
 
+func (*scheme) GenerateKeyPairFrom(rand io.Reader) (kem.PublicKey, kem.PrivateKey, error) {
+	return GenerateKeyPair(rand)
+}
+
 func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 	if len(seed) != SeedSize {
 		panic(kem.ErrSeedSize)
@@ -118,8 +127,17 @@ func (*scheme) DeriveKeyPair(seed []byte) (kem.PublicKey, kem.PrivateKey) {
 }
 
 func (sch *scheme) Encapsulate(pk kem.PublicKey) (ct []byte, ss []byte, err error) {
+	return sch.EncapsulateFrom(cryptoRand.Reader, pk)
+}
+
+func (sch *scheme) EncapsulateFrom(rand io.Reader, pk kem.PublicKey) (ct []byte, ss []byte, err error) {
 	var seed [EncapsulationSeedSize]byte
-	cryptoRand.Read(seed[:])
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	if _, err = io.ReadFull(rand, seed[:]); err != nil {
+		return nil, nil, err
+	}
 	return sch.EncapsulateDeterministically(pk, seed[:])
 }
 
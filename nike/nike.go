@@ -0,0 +1,110 @@
+// Package nike provides a unified interface for non-interactive key
+// exchange (NIKE) schemes, that is, Diffie-Hellman-like functions where two
+// parties derive a shared secret from their own private key and the public
+// key of the other party.
+//
+// The interface is implemented by the schemes returned by the Scheme
+// functions of the packages
+//
+//  github.com/cloudflare/circl/dh/csidh
+//  github.com/cloudflare/circl/dh/curve4q
+//  github.com/cloudflare/circl/dh/x25519
+//  github.com/cloudflare/circl/dh/x448
+//
+// A register of schemes is available in the package
+//
+//  github.com/cloudflare/circl/nike/schemes
+//
+// and the package github.com/cloudflare/circl/kem/dhkem turns any of them
+// into a KEM.
+package nike
+
+import (
+	"encoding"
+	"errors"
+	"io"
+)
+
+// A NIKE public key
+type PublicKey interface {
+	// Returns the scheme for this public key
+	Scheme() Scheme
+
+	encoding.BinaryMarshaler
+	Equal(PublicKey) bool
+}
+
+// A NIKE private key
+type PrivateKey interface {
+	// Returns the scheme for this private key
+	Scheme() Scheme
+
+	encoding.BinaryMarshaler
+	Equal(PrivateKey) bool
+	Public() PublicKey
+}
+
+// A Scheme represents a specific instance of a NIKE.
+type Scheme interface {
+	// Name of the scheme
+	Name() string
+
+	// GenerateKeyPair creates a new key pair.
+	GenerateKeyPair() (PublicKey, PrivateKey, error)
+
+	// GenerateKeyPairFrom creates a new key pair using randomness read
+	// from rand. If rand is nil, crypto/rand.Reader is used.
+	GenerateKeyPairFrom(rand io.Reader) (PublicKey, PrivateKey, error)
+
+	// DeriveKeyPair deterministically derives a pair of keys from a seed.
+	// Panics if the length of seed is not equal to the value returned by
+	// SeedSize.
+	DeriveKeyPair(seed []byte) (PublicKey, PrivateKey)
+
+	// DeriveSecret returns the secret shared between the owner of the
+	// private key sk and the owner of the public key pk. Returns ErrPubKey
+	// if pk is invalid, for instance, a low-order point.
+	DeriveSecret(sk PrivateKey, pk PublicKey) ([]byte, error)
+
+	// Unmarshals a PublicKey from the provided buffer.
+	UnmarshalBinaryPublicKey([]byte) (PublicKey, error)
+
+	// Unmarshals a PrivateKey from the provided buffer.
+	UnmarshalBinaryPrivateKey([]byte) (PrivateKey, error)
+
+	// Size of packed public keys.
+	PublicKeySize() int
+
+	// Size of packed private keys.
+	PrivateKeySize() int
+
+	// Size of shared secrets.
+	SharedSecretSize() int
+
+	// Size of seed used in DeriveKeyPair.
+	SeedSize() int
+}
+
+var (
+	// ErrTypeMismatch is the error used if types of, for instance, private
+	// and public keys don't match
+	ErrTypeMismatch = errors.New("types mismatch")
+
+	// ErrSeedSize is the error used if the provided seed is of the wrong
+	// size.
+	ErrSeedSize = errors.New("wrong seed size")
+
+	// ErrPubKeySize is the error used if the provided public key is of
+	// the wrong size.
+	ErrPubKeySize = errors.New("wrong size for public key")
+
+	// ErrPrivKeySize is the error used if the provided private key is of
+	// the wrong size.
+	ErrPrivKeySize = errors.New("wrong size for private key")
+
+	// ErrPubKey is the error used if the provided public key is invalid.
+	ErrPubKey = errors.New("invalid public key")
+
+	// ErrPrivKey is the error used if the provided private key is invalid.
+	ErrPrivKey = errors.New("invalid private key")
+)
@@ -0,0 +1,164 @@
+package schemes_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/csidh"
+	"github.com/cloudflare/circl/dh/curve4q"
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/dh/x448"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/nike"
+	"github.com/cloudflare/circl/nike/schemes"
+)
+
+func TestCaseSensitivity(t *testing.T) {
+	if schemes.ByName("x25519") != schemes.ByName("X25519") {
+		t.Fatal()
+	}
+}
+
+func newReader(seed string) *sha3.State {
+	h := sha3.NewShake256()
+	_, _ = h.Write([]byte(seed))
+	return &h
+}
+
+func TestApi(t *testing.T) {
+	allSchemes := schemes.All()
+	for _, sch := range allSchemes {
+		sch := sch
+		t.Run(sch.Name(), func(t *testing.T) {
+			pkA, skA, err := sch.GenerateKeyPair()
+			test.CheckNoErr(t, err, "GenerateKeyPair failed")
+			pkB, skB, err := sch.GenerateKeyPairFrom(newReader("B"))
+			test.CheckNoErr(t, err, "GenerateKeyPairFrom failed")
+			pkB2, skB2, err := sch.GenerateKeyPairFrom(newReader("B"))
+			test.CheckNoErr(t, err, "GenerateKeyPairFrom failed")
+			if !pkB.Equal(pkB2) || !skB.Equal(skB2) {
+				t.Fatal("keys generated from the same randomness differ")
+			}
+			if pkA.Equal(pkB) || skA.Equal(skB) {
+				t.Fatal("keys generated from different randomness are equal")
+			}
+			if !skA.Public().Equal(pkA) || skA.Scheme() != sch || pkA.Scheme() != sch {
+				t.Fatal("inconsistent key pair")
+			}
+
+			seed := make([]byte, sch.SeedSize())
+			pkC, skC := sch.DeriveKeyPair(seed)
+			pkC2, skC2 := sch.DeriveKeyPair(seed)
+			if !pkC.Equal(pkC2) || !skC.Equal(skC2) || !skC.Public().Equal(pkC) {
+				t.Fatal("DeriveKeyPair is not deterministic")
+			}
+			seed[1] = 1
+			if pkC3, _ := sch.DeriveKeyPair(seed); pkC3.Equal(pkC) {
+				t.Fatal("keys derived from different seeds are equal")
+			}
+
+			ssA, err := sch.DeriveSecret(skA, pkB)
+			test.CheckNoErr(t, err, "DeriveSecret failed")
+			ssB, err := sch.DeriveSecret(skB, pkA)
+			test.CheckNoErr(t, err, "DeriveSecret failed")
+			if !bytes.Equal(ssA, ssB) {
+				t.Fatal("shared secrets do not match")
+			}
+			if len(ssA) != sch.SharedSecretSize() {
+				t.Fatal("wrong shared secret size")
+			}
+
+			ppk, err := pkA.MarshalBinary()
+			test.CheckNoErr(t, err, "MarshalBinary failed")
+			psk, err := skA.MarshalBinary()
+			test.CheckNoErr(t, err, "MarshalBinary failed")
+			if len(ppk) != sch.PublicKeySize() || len(psk) != sch.PrivateKeySize() {
+				t.Fatal("wrong key size")
+			}
+			pkA2, err := sch.UnmarshalBinaryPublicKey(ppk)
+			test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+			skA2, err := sch.UnmarshalBinaryPrivateKey(psk)
+			test.CheckNoErr(t, err, "UnmarshalBinaryPrivateKey failed")
+			if !pkA.Equal(pkA2) || !skA.Equal(skA2) || !skA2.Public().Equal(pkA) {
+				t.Fatal("keys do not round-trip")
+			}
+
+			_, err = sch.UnmarshalBinaryPublicKey(ppk[1:])
+			if err != nike.ErrPubKeySize {
+				test.ReportError(t, err, nike.ErrPubKeySize)
+			}
+			_, err = sch.UnmarshalBinaryPrivateKey(psk[1:])
+			if err != nike.ErrPrivKeySize {
+				test.ReportError(t, err, nike.ErrPrivKeySize)
+			}
+			if _, _, err = sch.GenerateKeyPairFrom(bytes.NewReader(nil)); err == nil {
+				t.Fatal("reader error was not reported")
+			}
+
+			for _, other := range allSchemes {
+				if other == sch {
+					continue
+				}
+				otherPk, _, _ := other.GenerateKeyPairFrom(newReader("B"))
+				if _, err = sch.DeriveSecret(skA, otherPk); err != nike.ErrTypeMismatch {
+					test.ReportError(t, err, nike.ErrTypeMismatch)
+				}
+				if pkA.Equal(otherPk) {
+					t.Fatal("keys of different schemes are equal")
+				}
+			}
+		})
+	}
+}
+
+func TestInvalidPublicKey(t *testing.T) {
+	for _, sch := range []nike.Scheme{x25519.Scheme(), x448.Scheme(), curve4q.Scheme()} {
+		_, sk, _ := sch.GenerateKeyPair()
+		pk, err := sch.UnmarshalBinaryPublicKey(make([]byte, sch.PublicKeySize()))
+		test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+		if _, err = sch.DeriveSecret(sk, pk); err != nike.ErrPubKey {
+			test.ReportError(t, err, nike.ErrPubKey, sch.Name())
+		}
+	}
+
+	sch := csidh.Scheme()
+	buf := make([]byte, sch.PublicKeySize())
+	for i := range buf {
+		buf[i] = 0xFF
+	}
+	if _, err := sch.UnmarshalBinaryPublicKey(buf); err != nike.ErrPubKey {
+		test.ReportError(t, err, nike.ErrPubKey)
+	}
+
+	// Random coefficients define ordinary curves with overwhelming
+	// probability.
+	_, _ = newReader("ordinary").Read(buf)
+	buf[len(buf)-1] = 0
+	pk, err := sch.UnmarshalBinaryPublicKey(buf)
+	test.CheckNoErr(t, err, "UnmarshalBinaryPublicKey failed")
+	_, sk, _ := sch.GenerateKeyPair()
+	if _, err = sch.DeriveSecret(sk, pk); err != nike.ErrPubKey {
+		test.ReportError(t, err, nike.ErrPubKey)
+	}
+
+	psk := make([]byte, sch.PrivateKeySize())
+	psk[3] = 0x60
+	if _, err = sch.UnmarshalBinaryPrivateKey(psk); err != nike.ErrPrivKey {
+		test.ReportError(t, err, nike.ErrPrivKey)
+	}
+}
+
+func Example_schemes() {
+	// import "github.com/cloudflare/circl/nike/schemes"
+
+	for _, sch := range schemes.All() {
+		fmt.Println(sch.Name())
+	}
+	// Output:
+	// X25519
+	// X448
+	// FourQ
+	// CSIDH-512
+}
@@ -8,3 +8,14 @@
 // The related key encapsulation mechanism (KEM) CRYSTALS-Kyber.CCAKEM can
 // be found in the package github.com/cloudflare/circl/kem/kyber.
 package kyber
+
+import "errors"
+
+var (
+	// ErrPubKeySize is the error used if the provided public key is of
+	// the wrong size.
+	ErrPubKeySize = errors.New("kyber: wrong size for public key")
+
+	// ErrPubKey is the error used if the provided public key is invalid.
+	ErrPubKey = errors.New("kyber: invalid public key")
+)
@@ -1,6 +1,8 @@
 package internal
 
 import (
+	"bytes"
+
 	"github.com/cloudflare/circl/internal/sha3"
 	"github.com/cloudflare/circl/pke/kyber/internal/common"
 )
@@ -44,6 +46,17 @@ func (pk *PublicKey) Unpack(buf []byte) {
 	pk.aT.Derive(&pk.rho, true)
 }
 
+// Unpacks the public key from buf and returns whether its coefficients are
+// reduced modulo q, as required by the encapsulation key check of ML-KEM
+// (FIPS 203, §7.2).
+func (pk *PublicKey) UnpackMLKEM(buf []byte) bool {
+	pk.Unpack(buf)
+
+	var buf2 [K * common.PolySize]byte
+	pk.th.Pack(buf2[:])
+	return bytes.Equal(buf[:len(buf2)], buf2[:])
+}
+
 // Derives a new Kyber.CPAPKE keypair from the given seed.
 func NewKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey) {
 	var pk PublicKey
@@ -11,6 +11,7 @@ import (
 	cryptoRand "crypto/rand"
 	"io"
 
+	"github.com/cloudflare/circl/pke/kyber"
 	"github.com/cloudflare/circl/pke/kyber/kyber512/internal"
 )
 
@@ -57,6 +58,9 @@ func GenerateKey(rand io.Reader) (*PublicKey, *PrivateKey, error) {
 
 // NewKeyFromSeed derives a public/private key pair using the given seed.
 //
+// Note: does not include the domain separation of ML-KEM (line 1 of
+// algorithm 13 of FIPS 203). For that use NewKeyFromSeedMLKEM().
+//
 // Panics if seed is not of length KeySeedSize.
 func NewKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey) {
 	if len(seed) != KeySeedSize {
@@ -66,6 +70,21 @@ func NewKeyFromSeed(seed []byte) (*PublicKey, *PrivateKey) {
 	return (*PublicKey)(pk), (*PrivateKey)(sk)
 }
 
+// NewKeyFromSeedMLKEM derives a public/private key pair using the given seed
+// using the domain separation of ML-KEM.
+//
+// Panics if seed is not of length KeySeedSize.
+func NewKeyFromSeedMLKEM(seed []byte) (*PublicKey, *PrivateKey) {
+	if len(seed) != KeySeedSize {
+		panic("seed must be of length KeySeedSize")
+	}
+	var seed2 [KeySeedSize + 1]byte
+	copy(seed2[:KeySeedSize], seed)
+	seed2[KeySeedSize] = byte(internal.K)
+	pk, sk := internal.NewKeyFromSeed(seed2[:])
+	return (*PublicKey)(pk), (*PrivateKey)(sk)
+}
+
 // EncryptTo encrypts message pt for the public key and writes the ciphertext
 // to ct using randomness from seed.
 //
@@ -129,6 +148,20 @@ func (pk *PublicKey) Unpack(buf []byte) {
 	(*internal.PublicKey)(pk).Unpack(buf)
 }
 
+// Unpacks pk from the given buffer.
+//
+// Returns an error if buf is not of length PublicKeySize, or if the public
+// key does not pass the ML-KEM encapsulation key check.
+func (pk *PublicKey) UnpackMLKEM(buf []byte) error {
+	if len(buf) != PublicKeySize {
+		return kyber.ErrPubKeySize
+	}
+	if !(*internal.PublicKey)(pk).UnpackMLKEM(buf) {
+		return kyber.ErrPubKey
+	}
+	return nil
+}
+
 // Unpacks sk from the given buffer.
 //
 // Panics if buf is not of length PrivateKeySize.
@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	allSchemesByOID map[string]sign.Scheme
-	allSchemesByTLS map[uint]sign.Scheme
+	allSchemesByOID   map[string]sign.Scheme
+	allSchemesByAlgID map[string]sign.Scheme
+	allSchemesByTLS   map[uint]sign.Scheme
 )
 
 type pkixPrivKey struct {
@@ -24,9 +25,15 @@ type pkixPrivKey struct {
 
 func init() {
 	allSchemesByOID = make(map[string]sign.Scheme)
+	allSchemesByAlgID = make(map[string]sign.Scheme)
 	allSchemesByTLS = make(map[uint]sign.Scheme)
 	for _, scheme := range schemes.All() {
-		if cert, ok := scheme.(CertificateScheme); ok {
+		if algID, ok := scheme.(AlgorithmIdentifierScheme); ok {
+			// Several of these schemes may share an OID, as RSA-PSS ones
+			// do, so that only the full identifiers tell them apart.
+			allSchemesByAlgID[algIDKey(algID.KeyAlgorithmIdentifier())] = scheme
+			allSchemesByAlgID[algIDKey(algID.SignatureAlgorithmIdentifier())] = scheme
+		} else if cert, ok := scheme.(CertificateScheme); ok {
 			allSchemesByOID[cert.Oid().String()] = scheme
 		}
 		if tlsScheme, ok := scheme.(TLSScheme); ok {
@@ -35,7 +42,30 @@ func init() {
 	}
 }
 
-func SchemeByOid(oid asn1.ObjectIdentifier) sign.Scheme { return allSchemesByOID[oid.String()] }
+// Returns the key of an AlgorithmIdentifier in allSchemesByAlgID: its OID
+// followed by the DER encoding of its parameters.
+func algIDKey(ai pkix.AlgorithmIdentifier) string {
+	return ai.Algorithm.String() + " " + string(ai.Parameters.FullBytes)
+}
+
+// SchemeByOid returns the scheme identified by oid without parameters, or
+// nil if there is none.  Use SchemeByAlgorithmIdentifier for the schemes
+// that are identified with parameters.
+func SchemeByOid(oid asn1.ObjectIdentifier) sign.Scheme {
+	if scheme, ok := allSchemesByOID[oid.String()]; ok {
+		return scheme
+	}
+	return allSchemesByAlgID[algIDKey(pkix.AlgorithmIdentifier{Algorithm: oid})]
+}
+
+// SchemeByAlgorithmIdentifier returns the scheme of the keys or of the
+// signatures identified by ai, or nil if there is none.
+func SchemeByAlgorithmIdentifier(ai pkix.AlgorithmIdentifier) sign.Scheme {
+	if scheme, ok := allSchemesByAlgID[algIDKey(ai)]; ok {
+		return scheme
+	}
+	return allSchemesByOID[ai.Algorithm.String()]
+}
 
 func SchemeByTLSID(id uint) sign.Scheme { return allSchemesByTLS[id] }
 
@@ -47,6 +77,32 @@ type CertificateScheme interface {
 	Oid() asn1.ObjectIdentifier
 }
 
+// Additional methods when the signature scheme is identified in X509 by an
+// AlgorithmIdentifier with parameters, or by different ones for its keys and
+// its signatures, such as ECDSA and RSASSA-PSS.  The keys are still encoded
+// in their packed form, as returned by MarshalBinary.
+type AlgorithmIdentifierScheme interface {
+	CertificateScheme
+
+	// Return the AlgorithmIdentifier of the keys, as used in
+	// SubjectPublicKeyInfo and PrivateKeyInfo.
+	KeyAlgorithmIdentifier() pkix.AlgorithmIdentifier
+
+	// Return the AlgorithmIdentifier of the signatures, whose algorithm
+	// is Oid().
+	SignatureAlgorithmIdentifier() pkix.AlgorithmIdentifier
+}
+
+// Returns the AlgorithmIdentifier of the keys of scheme.
+func keyAlgorithmIdentifier(scheme sign.Scheme) pkix.AlgorithmIdentifier {
+	if algID, ok := scheme.(AlgorithmIdentifierScheme); ok {
+		return algID.KeyAlgorithmIdentifier()
+	}
+	return pkix.AlgorithmIdentifier{
+		Algorithm: scheme.(CertificateScheme).Oid(),
+	}
+}
+
 // Additional methods when the signature scheme is supported in TLS.
 type TLSScheme interface {
 	TLSIdentifier() uint
@@ -75,7 +131,7 @@ func UnmarshalPKIXPublicKey(data []byte) (sign.PublicKey, error) {
 	} else if len(rest) != 0 {
 		return nil, errors.New("trailing data")
 	}
-	scheme := SchemeByOid(pkix.Algorithm.Algorithm)
+	scheme := SchemeByAlgorithmIdentifier(pkix.Algorithm)
 	if scheme == nil {
 		return nil, errors.New("unsupported public key algorithm")
 	}
@@ -101,7 +157,7 @@ func UnmarshalPKIXPrivateKey(data []byte) (sign.PrivateKey, error) {
 	} else if len(rest) != 0 {
 		return nil, errors.New("trailing data")
 	}
-	scheme := SchemeByOid(pkix.Algorithm.Algorithm)
+	scheme := SchemeByAlgorithmIdentifier(pkix.Algorithm)
 	if scheme == nil {
 		return nil, errors.New("unsupported public key algorithm")
 	}
@@ -137,9 +193,7 @@ func MarshalPKIXPublicKey(pk sign.PublicKey) ([]byte, error) {
 		pkix.AlgorithmIdentifier
 		asn1.BitString
 	}{
-		pkix.AlgorithmIdentifier{
-			Algorithm: scheme.(CertificateScheme).Oid(),
-		},
+		keyAlgorithmIdentifier(scheme),
 		asn1.BitString{
 			Bytes:     data,
 			BitLength: len(data) * 8,
@@ -174,9 +228,7 @@ func MarshalPKIXPrivateKey(sk sign.PrivateKey) ([]byte, error) {
 	scheme := sk.Scheme()
 	return asn1.Marshal(pkixPrivKey{
 		0,
-		pkix.AlgorithmIdentifier{
-			Algorithm: scheme.(CertificateScheme).Oid(),
-		},
+		keyAlgorithmIdentifier(scheme),
 		data,
 	})
 }
@@ -0,0 +1,390 @@
+// Package bip340 implements the Schnorr signatures over secp256k1 of
+// BIP-340, as used by Taproot.
+//
+// Public keys are the 32-byte x-coordinate of a point whose y-coordinate
+// is even, and signatures are the concatenation of the x-coordinate of the
+// nonce point R with the 32-byte big-endian scalar s.  Messages are signed
+// directly, without being hashed first, and can be of any length.  Nonces
+// are derived with tagged hashes from the private key, the message and 32
+// bytes of auxiliary randomness, which protect against side-channel
+// attacks but are not needed for security: signing remains secure when
+// they are fixed.
+//
+// Batches of signatures can be verified faster than one by one with
+// VerifyBatch.
+//
+// References:
+//   - BIP-340: Schnorr Signatures for secp256k1.
+//     https://github.com/bitcoin/bips/blob/master/bip-0340.mediawiki
+package bip340
+
+import (
+	"bytes"
+	"crypto"
+	cryptoRand "crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/ecc/secp256k1"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/sign"
+)
+
+const (
+	// SeedSize is the size of seeds for NewKeyFromSeed.
+	SeedSize = 32
+
+	// PublicKeySize is the size of a packed x-only public key.
+	PublicKeySize = 32
+
+	// PrivateKeySize is the size of a packed private key.
+	PrivateKeySize = secp256k1.ScalarSize
+
+	// SignatureSize is the size of a signature.
+	SignatureSize = 64
+
+	// AuxRandSize is the size of the auxiliary randomness of signing.
+	AuxRandSize = 32
+)
+
+var (
+	// ErrInvalidPrivateKey is returned when unpacking a private key that
+	// is not in [1, n-1].
+	ErrInvalidPrivateKey = errors.New("bip340: invalid private key")
+
+	// ErrInvalidPublicKey is returned when unpacking a public key that is
+	// not the x-coordinate of a point of the curve.
+	ErrInvalidPublicKey = errors.New("bip340: invalid public key")
+)
+
+// PublicKey is the type of BIP-340 public keys.
+type PublicKey struct {
+	p secp256k1.Point // has an even y-coordinate.
+	x [PublicKeySize]byte
+}
+
+// PrivateKey is the type of BIP-340 private keys.
+type PrivateKey struct {
+	d  secp256k1.Scalar // as packed.
+	d0 secp256k1.Scalar // d or -d, such that d0*G has an even y-coordinate.
+	pk PublicKey
+}
+
+func newPrivateKey(d *secp256k1.Scalar) *PrivateKey {
+	sk := &PrivateKey{d: *d, d0: *d}
+	var P secp256k1.Point
+	P.ScalarBaseMult(d)
+	odd := P.IsYOdd()
+	sk.d0.CondNeg(odd)
+	if odd {
+		P.Neg(&P)
+	}
+	sk.pk.setPoint(&P)
+	return sk
+}
+
+func (pk *PublicKey) setPoint(P *secp256k1.Point) {
+	x, err := P.XBytes()
+	if err != nil {
+		panic(err)
+	}
+	pk.p = *P
+	copy(pk.x[:], x)
+}
+
+// GenerateKey generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	var b [PrivateKeySize]byte
+	var d secp256k1.Scalar
+	for {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, nil, err
+		}
+		if d.SetBytes(b[:]) && !d.IsZero() {
+			break
+		}
+	}
+	sk := newPrivateKey(&d)
+	return &sk.pk, sk, nil
+}
+
+// NewKeyFromSeed derives a public/private key pair from the given seed.
+// The private key is sampled in [1, n-1] by rejection from the output of
+// SHAKE-256 on the seed, as DeriveKey does in package ecdsa.
+func NewKeyFromSeed(seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed[:])
+	var b [PrivateKeySize]byte
+	var d secp256k1.Scalar
+	for {
+		_, _ = h.Read(b[:])
+		if d.SetBytes(b[:]) && !d.IsZero() {
+			break
+		}
+	}
+	sk := newPrivateKey(&d)
+	return &sk.pk, sk
+}
+
+// Tags of the hashes of BIP-340.
+const (
+	tagAux       = "BIP0340/aux"
+	tagNonce     = "BIP0340/nonce"
+	tagChallenge = "BIP0340/challenge"
+)
+
+// taggedHash returns SHA-256(SHA-256(tag) || SHA-256(tag) || data).
+func taggedHash(tag string, data ...[]byte) []byte {
+	t := sha256.Sum256([]byte(tag))
+	h := sha256.New()
+	_, _ = h.Write(t[:])
+	_, _ = h.Write(t[:])
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// challenge sets e to the challenge of the signature with nonce point r
+// by the public key x on msg.
+func challenge(e *secp256k1.Scalar, r, x, msg []byte) {
+	e.SetBytes(taggedHash(tagChallenge, r, x, msg))
+}
+
+// SignTo signs msg with the auxiliary randomness auxRand, and writes the
+// signature into signature.  Signing is deterministic for a given auxRand,
+// which should be fresh randomness where possible, or can be all zeros
+// otherwise.
+//
+// It will panic if signature is not of length SignatureSize.
+func SignTo(sk *PrivateKey, msg []byte, auxRand *[AuxRandSize]byte, signature []byte) {
+	if len(signature) != SignatureSize {
+		panic("bip340: signature must be of SignatureSize bytes")
+	}
+
+	t := sk.d0.Bytes()
+	a := taggedHash(tagAux, auxRand[:])
+	for i := range t {
+		t[i] ^= a[i]
+	}
+
+	var k, e, s secp256k1.Scalar
+	k.SetBytes(taggedHash(tagNonce, t, sk.pk.x[:], msg))
+	if k.IsZero() {
+		// Happens with negligible probability.
+		panic("bip340: nonce is zero")
+	}
+	var R secp256k1.Point
+	R.ScalarBaseMult(&k)
+	k.CondNeg(R.IsYOdd())
+	r, _ := R.XBytes()
+
+	// s = k + e*d
+	challenge(&e, r, sk.pk.x[:], msg)
+	s.Mul(&e, &sk.d0)
+	s.Add(&s, &k)
+
+	copy(signature[:32], r)
+	copy(signature[32:], s.Bytes())
+}
+
+// Sign signs msg with auxiliary randomness read from rand, and returns the
+// signature.  If rand is nil, crypto/rand.Reader will be used.
+func Sign(rand io.Reader, sk *PrivateKey, msg []byte) ([]byte, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	var aux [AuxRandSize]byte
+	if _, err := io.ReadFull(rand, aux[:]); err != nil {
+		return nil, err
+	}
+	sig := make([]byte, SignatureSize)
+	SignTo(sk, msg, &aux, sig)
+	return sig, nil
+}
+
+// parseSignature lifts the nonce point R from the first half of sig, and
+// parses s, checking that it is smaller than n.
+func parseSignature(R *secp256k1.Point, s *secp256k1.Scalar, sig []byte) bool {
+	return len(sig) == SignatureSize && liftX(R, sig[:32]) &&
+		s.SetBytes(sig[32:])
+}
+
+// liftX sets P to the point of even y-coordinate whose x-coordinate is x.
+func liftX(P *secp256k1.Point, x []byte) bool {
+	var enc [secp256k1.CompressedSize]byte
+	enc[0] = 0x02
+	copy(enc[1:], x)
+	return P.UnmarshalBinary(enc[:]) == nil
+}
+
+// Verify checks whether the given signature by pk on msg is valid.
+func Verify(pk *PublicKey, msg, signature []byte) bool {
+	if len(signature) != SignatureSize {
+		return false
+	}
+	var s, e secp256k1.Scalar
+	if !s.SetBytes(signature[32:]) {
+		return false
+	}
+	r := signature[:32]
+	challenge(&e, r, pk.x[:], msg)
+	e.Neg(&e)
+
+	// R = s*G - e*P
+	var R secp256k1.Point
+	R.CombinedMult(&s, &e, &pk.p)
+	if R.IsIdentity() || R.IsYOdd() {
+		return false
+	}
+	x, _ := R.XBytes()
+	return bytes.Equal(x, r)
+}
+
+// VerifyBatch checks whether all signatures[i] by publicKeys[i] on
+// messages[i] are valid, and returns false if any of them is not, or if
+// the slices have different lengths.
+//
+// It checks a random linear combination of the verification equations
+// with a single multi-scalar multiplication, which is faster than calling
+// Verify for each signature.  Random coefficients are read from
+// crypto/rand.Reader.
+func VerifyBatch(publicKeys []*PublicKey, messages, signatures [][]byte) bool {
+	u := len(publicKeys)
+	if len(messages) != u || len(signatures) != u {
+		return false
+	}
+	if u == 0 {
+		return true
+	}
+
+	// Checks that (s_1 + a_2 s_2 + ... + a_u s_u) G equals
+	// R_1 + a_2 R_2 + ... + a_u R_u + e_1 P_1 + a_2 e_2 P_2 + ... + a_u e_u P_u,
+	// where a_1 = 1 and a_2, ..., a_u are random.
+	scalars := make([]secp256k1.Scalar, 2*u+1)
+	points := make([]secp256k1.Point, 2*u+1)
+	k := make([]*secp256k1.Scalar, 2*u+1)
+	Q := make([]*secp256k1.Point, 2*u+1)
+
+	var a, s, e secp256k1.Scalar
+	var buf [secp256k1.ScalarSize]byte
+	sum := &scalars[2*u]
+	points[2*u] = *secp256k1.Generator()
+	for i := 0; i < u; i++ {
+		R, aR := &points[2*i], &scalars[2*i]
+		P, aeP := &points[2*i+1], &scalars[2*i+1]
+		if !parseSignature(R, &s, signatures[i]) {
+			return false
+		}
+		P.Set(&publicKeys[i].p)
+		challenge(&e, signatures[i][:32], publicKeys[i].x[:], messages[i])
+
+		if i == 0 {
+			a.SetUint64(1)
+		} else {
+			if _, err := io.ReadFull(cryptoRand.Reader, buf[:]); err != nil {
+				panic(err)
+			}
+			a.SetBytes(buf[:])
+		}
+
+		// Negates a*R and a*e*P, so that the sum must be the identity.
+		aR.Neg(&a)
+		aeP.Mul(&a, &e)
+		aeP.Neg(aeP)
+		s.Mul(&s, &a)
+		sum.Add(sum, &s)
+	}
+	for i := range k {
+		k[i], Q[i] = &scalars[i], &points[i]
+	}
+
+	var T secp256k1.Point
+	T.MultiScalarMult(k, Q)
+	return T.IsIdentity()
+}
+
+// Public returns the public key corresponding to this private key.
+//
+// Returns a *PublicKey.  The type crypto.PublicKey is used to make
+// PrivateKey implement the crypto.Signer interface.
+func (sk *PrivateKey) Public() crypto.PublicKey {
+	pk := sk.pk
+	return &pk
+}
+
+// Sign signs the given message with auxiliary randomness read from rand,
+// and returns the signature.  If rand is nil, crypto/rand.Reader will be
+// used.  As in Ed25519, the message is not hashed beforehand, so
+// opts.HashFunc() must return zero.
+//
+// This function is used to make PrivateKey implement the crypto.Signer
+// interface.
+func (sk *PrivateKey) Sign(rand io.Reader, message []byte, opts crypto.SignerOpts) (
+	signature []byte, err error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) {
+		return nil, errors.New("bip340: cannot sign hashed message")
+	}
+	return Sign(rand, sk, message)
+}
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	castOther, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return sk.d.IsEqual(&castOther.d)
+}
+
+// MarshalBinary returns the private key as a 32-byte big-endian integer.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) { return sk.d.Bytes(), nil }
+
+// UnmarshalBinary sets sk to the private key encoded as a 32-byte
+// big-endian integer, which must be in [1, n-1].
+func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
+	if len(data) != PrivateKeySize {
+		return sign.ErrPrivKeySize
+	}
+	var d secp256k1.Scalar
+	if !d.SetBytes(data) || d.IsZero() {
+		return ErrInvalidPrivateKey
+	}
+	*sk = *newPrivateKey(&d)
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	castOther, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.x == castOther.x
+}
+
+// MarshalBinary returns the 32-byte x-coordinate of the public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	x := pk.x
+	return x[:], nil
+}
+
+// UnmarshalBinary sets pk to the x-only public key in data, which must be
+// the x-coordinate of a point of the curve.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	if len(data) != PublicKeySize {
+		return sign.ErrPubKeySize
+	}
+	var P secp256k1.Point
+	if !liftX(&P, data) {
+		return ErrInvalidPublicKey
+	}
+	pk.p = P
+	copy(pk.x[:], data)
+	return nil
+}
@@ -0,0 +1,194 @@
+package bip340_test
+
+import (
+	"bytes"
+	"crypto"
+	"encoding/csv"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/bip340"
+)
+
+type vector struct {
+	index, comment                  string
+	sk, pk, auxRand, msg, signature []byte
+	result                          bool
+}
+
+func readVectors(t *testing.T) []vector {
+	f, err := os.Open("testdata/test-vectors.csv")
+	test.CheckNoErr(t, err, "open failed")
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	test.CheckNoErr(t, err, "csv failed")
+
+	vs := make([]vector, 0, len(records)-1)
+	for _, r := range records[1:] {
+		v := vector{index: r[0], result: r[6] == "TRUE", comment: r[7]}
+		for i, b := range []*[]byte{&v.sk, &v.pk, &v.auxRand, &v.msg, &v.signature} {
+			*b, err = hex.DecodeString(r[i+1])
+			test.CheckNoErr(t, err, "hex failed")
+		}
+		vs = append(vs, v)
+	}
+	return vs
+}
+
+func TestVectors(t *testing.T) {
+	for _, v := range readVectors(t) {
+		var pk bip340.PublicKey
+		if err := pk.UnmarshalBinary(v.pk); err != nil {
+			test.CheckOk(!v.result, "valid public key rejected: "+v.index, t)
+			continue
+		}
+
+		if len(v.sk) != 0 {
+			var sk bip340.PrivateKey
+			test.CheckNoErr(t, sk.UnmarshalBinary(v.sk), "unmarshal failed")
+			test.CheckOk(sk.Public().(*bip340.PublicKey).Equal(&pk),
+				"wrong public key: "+v.index, t)
+
+			var aux [bip340.AuxRandSize]byte
+			copy(aux[:], v.auxRand)
+			sig := make([]byte, bip340.SignatureSize)
+			bip340.SignTo(&sk, v.msg, &aux, sig)
+			if !bytes.Equal(sig, v.signature) {
+				test.ReportError(t, sig, v.signature, v.index)
+			}
+		}
+
+		if got := bip340.Verify(&pk, v.msg, v.signature); got != v.result {
+			test.ReportError(t, got, v.result, v.index, v.comment)
+		}
+		got := bip340.VerifyBatch([]*bip340.PublicKey{&pk}, [][]byte{v.msg}, [][]byte{v.signature})
+		if got != v.result {
+			test.ReportError(t, got, v.result, v.index, v.comment)
+		}
+	}
+}
+
+func TestVerifyBatch(t *testing.T) {
+	const u = 16
+	pks := make([]*bip340.PublicKey, u)
+	msgs := make([][]byte, u)
+	sigs := make([][]byte, u)
+	for i := range pks {
+		pk, sk, err := bip340.GenerateKey(nil)
+		test.CheckNoErr(t, err, "keygen failed")
+		pks[i], msgs[i] = pk, []byte{byte(i)}
+		sigs[i], err = bip340.Sign(nil, sk, msgs[i])
+		test.CheckNoErr(t, err, "sign failed")
+	}
+	test.CheckOk(bip340.VerifyBatch(pks, msgs, sigs), "batch rejected", t)
+	test.CheckOk(bip340.VerifyBatch(nil, nil, nil), "empty batch rejected", t)
+	test.CheckOk(!bip340.VerifyBatch(pks, msgs[1:], sigs), "mismatched lengths accepted", t)
+
+	for i := 0; i < u; i++ {
+		msgs[i][0] ^= 0xff
+		test.CheckOk(!bip340.VerifyBatch(pks, msgs, sigs), "wrong message accepted", t)
+		msgs[i][0] ^= 0xff
+
+		sigs[i][63] ^= 1
+		test.CheckOk(!bip340.VerifyBatch(pks, msgs, sigs), "wrong signature accepted", t)
+		sigs[i][63] ^= 1
+	}
+
+	// Swapping the signatures of two messages by the same key keeps the
+	// sum of the equations, but not a random linear combination of them.
+	_, sk, _ := bip340.GenerateKey(nil)
+	pk := sk.Public().(*bip340.PublicKey)
+	m0, m1 := []byte("m0"), []byte("m1")
+	s0, _ := bip340.Sign(nil, sk, m0)
+	s1, _ := bip340.Sign(nil, sk, m1)
+	test.CheckOk(!bip340.VerifyBatch(
+		[]*bip340.PublicKey{pk, pk}, [][]byte{m0, m1}, [][]byte{s1, s0},
+	), "swapped signatures accepted", t)
+}
+
+func TestKeys(t *testing.T) {
+	var sk bip340.PrivateKey
+	test.CheckIsErr(t, sk.UnmarshalBinary(make([]byte, 32)), "zero key accepted")
+	test.CheckIsErr(t, sk.UnmarshalBinary(make([]byte, 31)), "short key accepted")
+
+	var seed [bip340.SeedSize]byte
+	seed[31] = 3
+	pk, _ := bip340.NewKeyFromSeed(&seed)
+
+	var pk2 bip340.PublicKey
+	x, _ := pk.MarshalBinary()
+	test.CheckNoErr(t, pk2.UnmarshalBinary(x), "unmarshal failed")
+	test.CheckOk(pk2.Equal(pk), "roundtrip failed", t)
+	test.CheckIsErr(t, pk2.UnmarshalBinary(x[1:]), "short key accepted")
+}
+
+func TestNewKeyFromSeed(t *testing.T) {
+	// The private key is the first 32 bytes of SHAKE-256(seed), which are
+	// smaller than n for these seeds.
+	for _, v := range []struct {
+		seed byte
+		sk   string
+	}{
+		{0, "f5977c8283546a63723bc31d2619124f11db4658643336741df81757d5ad3062"},
+		{3, "072ea34d97caa2c5c2f8d4488e2a43f551451b40bbf8dd7c9570f58175f93dfb"},
+	} {
+		var seed [bip340.SeedSize]byte
+		seed[31] = v.seed
+		pk, sk := bip340.NewKeyFromSeed(&seed)
+		enc, err := sk.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+		want, _ := hex.DecodeString(v.sk)
+		if !bytes.Equal(enc, want) {
+			test.ReportError(t, enc, want, v.seed)
+		}
+		test.CheckOk(sk.Public().(*bip340.PublicKey).Equal(pk),
+			"public key mismatch", t)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	pk, sk, err := bip340.GenerateKey(nil)
+	test.CheckNoErr(t, err, "keygen failed")
+	msg := []byte("message")
+
+	var signer crypto.Signer = sk
+	sig, err := signer.Sign(nil, msg, crypto.Hash(0))
+	test.CheckNoErr(t, err, "sign failed")
+	test.CheckOk(bip340.Verify(pk, msg, sig), "verify failed", t)
+
+	_, err = signer.Sign(nil, msg, crypto.SHA256)
+	test.CheckIsErr(t, err, "hashed message accepted")
+}
+
+func BenchmarkBIP340(b *testing.B) {
+	const u = 64
+	pks := make([]*bip340.PublicKey, u)
+	msgs := make([][]byte, u)
+	sigs := make([][]byte, u)
+	for i := range pks {
+		pk, sk, _ := bip340.GenerateKey(nil)
+		pks[i], msgs[i] = pk, []byte{byte(i)}
+		sigs[i], _ = bip340.Sign(nil, sk, msgs[i])
+	}
+	_, sk, _ := bip340.GenerateKey(nil)
+	var aux [bip340.AuxRandSize]byte
+	sig := make([]byte, bip340.SignatureSize)
+
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bip340.SignTo(sk, msgs[0], &aux, sig)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = bip340.Verify(pks[0], msgs[0], sigs[0])
+		}
+	})
+	b.Run("VerifyBatch64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = bip340.VerifyBatch(pks, msgs, sigs)
+		}
+	})
+}
@@ -24,6 +24,11 @@
 // implement such hybrids of Dilithium2 with Ed25519 respectively and
 // Dilithium3 with Ed448.  These packages are a drop in replacements for the
 // mode subpackages of this package.
+//
+// The final version of Dilithium standardized in FIPS 204, ML-DSA, is not
+// compatible with these modes and is implemented in
+//
+//  github.com/cloudflare/circl/sign/mldsa
 package dilithium
 
 import (
@@ -7,6 +7,7 @@ package main
 
 import (
 	"bytes"
+	"encoding/asn1"
 	"fmt"
 	"go/format"
 	"io/ioutil"
@@ -15,7 +16,7 @@ import (
 	"strings"
 	"text/template"
 
-	"github.com/cloudflare/circl/sign/dilithium/internal/common/params"
+	"github.com/cloudflare/circl/sign/internal/dilithium/params"
 )
 
 type Mode struct {
@@ -29,21 +30,54 @@ type Mode struct {
 	Tau           int
 	Gamma1Bits    int
 	Gamma2        int
+	TRSize        int
+	CTildeSize    int
+	Oid           asn1.ObjectIdentifier
+	TLSIdentifier uint
 }
 
 func (m Mode) Pkg() string {
 	return strings.ToLower(m.Mode())
 }
 
+// Path of the package relative to this directory.
+func (m Mode) PkgPath() string {
+	if m.NIST() {
+		return path.Join("..", "mldsa", m.Pkg())
+	}
+	return m.Pkg()
+}
+
 func (m Mode) Impl() string {
 	return "impl" + m.Mode()
 }
 
 func (m Mode) Mode() string {
+	if m.NIST() {
+		return strings.ReplaceAll(m.Name, "-", "")
+	}
 	return strings.ReplaceAll(strings.ReplaceAll(m.Name,
 		"Dilithium", "Mode"), "-AES", "AES")
 }
 
+// Whether this is a parameter set of ML-DSA as standardized in FIPS 204,
+// instead of round 3 Dilithium.
+func (m Mode) NIST() bool {
+	return strings.HasPrefix(m.Name, "ML-DSA-")
+}
+
+// Returns Go code for the OID of the scheme.
+func (m Mode) OidCode() string {
+	ret := "asn1.ObjectIdentifier{"
+	for i, v := range m.Oid {
+		if i > 0 {
+			ret += ", "
+		}
+		ret += fmt.Sprintf("%d", v)
+	}
+	return ret + "}"
+}
+
 var (
 	Modes = []Mode{
 		{
@@ -57,6 +91,10 @@ var (
 			Tau:           39,
 			Gamma1Bits:    17,
 			Gamma2:        (params.Q - 1) / 88,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 4, 4},
+			TLSIdentifier: 0xfea0,
 		},
 		{
 			Name:          "Dilithium2-AES",
@@ -69,6 +107,10 @@ var (
 			Tau:           39,
 			Gamma1Bits:    17,
 			Gamma2:        (params.Q - 1) / 88,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 4, 4},
+			TLSIdentifier: 0xfea7,
 		},
 		{
 			Name:          "Dilithium3",
@@ -81,6 +123,10 @@ var (
 			Tau:           49,
 			Gamma1Bits:    19,
 			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 6, 5},
+			TLSIdentifier: 0xfea3,
 		},
 		{
 			Name:          "Dilithium3-AES",
@@ -93,6 +139,10 @@ var (
 			Tau:           49,
 			Gamma1Bits:    19,
 			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 6, 5},
+			TLSIdentifier: 0xfeaa,
 		},
 		{
 			Name:          "Dilithium5",
@@ -105,6 +155,10 @@ var (
 			Tau:           60,
 			Gamma1Bits:    19,
 			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 7, 8, 7},
+			TLSIdentifier: 0xfea5,
 		},
 		{
 			Name:          "Dilithium5-AES",
@@ -117,6 +171,55 @@ var (
 			Tau:           60,
 			Gamma1Bits:    19,
 			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        32,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 8, 7},
+			TLSIdentifier: 0xfeac,
+		},
+		{
+			Name:          "ML-DSA-44",
+			UseAES:        false,
+			K:             4,
+			L:             4,
+			Eta:           2,
+			DoubleEtaBits: 3,
+			Omega:         80,
+			Tau:           39,
+			Gamma1Bits:    17,
+			Gamma2:        (params.Q - 1) / 88,
+			TRSize:        64,
+			CTildeSize:    32,
+			Oid:           asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 17},
+		},
+		{
+			Name:          "ML-DSA-65",
+			UseAES:        false,
+			K:             6,
+			L:             5,
+			Eta:           4,
+			DoubleEtaBits: 4,
+			Omega:         55,
+			Tau:           49,
+			Gamma1Bits:    19,
+			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        64,
+			CTildeSize:    48,
+			Oid:           asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 18},
+		},
+		{
+			Name:          "ML-DSA-87",
+			UseAES:        false,
+			K:             8,
+			L:             7,
+			Eta:           2,
+			DoubleEtaBits: 3,
+			Omega:         75,
+			Tau:           60,
+			Gamma1Bits:    19,
+			Gamma2:        (params.Q - 1) / 32,
+			TRSize:        64,
+			CTildeSize:    64,
+			Oid:           asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 3, 19},
 		},
 	}
 	TemplateWarning = "// Code generated from"
@@ -127,6 +230,7 @@ func main() {
 	generateModeToplevelFiles()
 	generateParamsFiles()
 	generateSourceFiles()
+	generateACVPTestFiles()
 }
 
 // Generates modeX/internal/params.go from templates/params.templ.go
@@ -154,7 +258,7 @@ func generateParamsFiles() {
 		if offset == -1 {
 			panic("Missing template warning in params.templ.go")
 		}
-		err = ioutil.WriteFile(mode.Pkg()+"/internal/params.go",
+		err = ioutil.WriteFile(mode.PkgPath()+"/internal/params.go",
 			[]byte(res[offset:]), 0o644)
 		if err != nil {
 			panic(err)
@@ -170,6 +274,10 @@ func generateModeToplevelFiles() {
 	}
 
 	for _, mode := range Modes {
+		if mode.NIST() {
+			continue
+		}
+
 		buf := new(bytes.Buffer)
 		err := tl.Execute(buf, mode)
 		if err != nil {
@@ -202,12 +310,18 @@ func generateModePackageFiles() {
 			panic(err)
 		}
 
-		res := string(buf.Bytes())
+		// Formating output code
+		code, err := format.Source(buf.Bytes())
+		if err != nil {
+			panic("error formating code")
+		}
+
+		res := string(code)
 		offset := strings.Index(res, TemplateWarning)
 		if offset == -1 {
 			panic("Missing template warning in modePkg.templ.go")
 		}
-		err = ioutil.WriteFile(mode.Pkg()+"/dilithium.go", []byte(res[offset:]), 0o644)
+		err = ioutil.WriteFile(mode.PkgPath()+"/dilithium.go", []byte(res[offset:]), 0o644)
 		if err != nil {
 			panic(err)
 		}
@@ -247,10 +361,10 @@ func generateSourceFiles() {
 			continue
 		}
 
-		fs, err = ioutil.ReadDir(path.Join(mode.Pkg(), "internal"))
+		fs, err = ioutil.ReadDir(path.Join(mode.PkgPath(), "internal"))
 		for _, f := range fs {
 			name := f.Name()
-			fn := path.Join(mode.Pkg(), "internal", name)
+			fn := path.Join(mode.PkgPath(), "internal", name)
 			if ignored(name) {
 				continue
 			}
@@ -274,7 +388,7 @@ func generateSourceFiles() {
 			}
 		}
 		for name, expected := range files {
-			fn := path.Join(mode.Pkg(), "internal", name)
+			fn := path.Join(mode.PkgPath(), "internal", name)
 			expected = []byte(fmt.Sprintf(
 				"%s mode3/internal/%s by gen.go\n\n%s",
 				TemplateWarning,
@@ -295,3 +409,39 @@ func generateSourceFiles() {
 		}
 	}
 }
+
+// Generates mldsaX/acvp_test.go from templates/acvp.templ.go
+func generateACVPTestFiles() {
+	tl, err := template.ParseFiles("templates/acvp.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, mode := range Modes {
+		if !mode.NIST() {
+			continue
+		}
+
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, mode)
+		if err != nil {
+			panic(err)
+		}
+
+		code, err := format.Source(buf.Bytes())
+		if err != nil {
+			panic("error formating code")
+		}
+
+		res := string(code)
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in acvp.templ.go")
+		}
+		err = ioutil.WriteFile(mode.PkgPath()+"/acvp_test.go",
+			[]byte(res[offset:]), 0o644)
+		if err != nil {
+			panic(err)
+		}
+	}
+}
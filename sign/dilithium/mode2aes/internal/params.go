@@ -13,4 +13,7 @@ const (
 	Tau           = 39
 	Gamma1Bits    = 17
 	Gamma2        = 95232
+	NIST          = false
+	TRSize        = 32
+	CTildeSize    = 32
 )
@@ -8,11 +8,13 @@ package mode3aes
 
 import (
 	"crypto"
+	"encoding/asn1"
 	"errors"
 	"io"
 
-	"github.com/cloudflare/circl/sign/dilithium/internal/common"
+	"github.com/cloudflare/circl/sign"
 	"github.com/cloudflare/circl/sign/dilithium/mode3aes/internal"
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
 )
 
 const (
@@ -53,7 +55,8 @@ func NewKeyFromSeed(seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
 func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 	internal.SignTo(
 		(*internal.PrivateKey)(sk),
-		msg,
+		func(w io.Writer) { _, _ = w.Write(msg) },
+		new([32]byte),
 		signature,
 	)
 }
@@ -62,7 +65,7 @@ func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
 	return internal.Verify(
 		(*internal.PublicKey)(pk),
-		msg,
+		func(w io.Writer) { _, _ = w.Write(msg) },
 		signature,
 	)
 }
@@ -179,3 +182,97 @@ func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
 	}
 	return (*internal.PublicKey)(pk).Equal((*internal.PublicKey)(castOther))
 }
+
+// Boilerplate for the generic signatures API
+
+type scheme struct{}
+
+var sch sign.Scheme = &scheme{}
+
+// Scheme returns a generic signature interface for Dilithium3-AES.
+func Scheme() sign.Scheme { return sch }
+
+func (*scheme) Name() string          { return "Dilithium3-AES" }
+func (*scheme) PublicKeySize() int    { return PublicKeySize }
+func (*scheme) PrivateKeySize() int   { return PrivateKeySize }
+func (*scheme) SignatureSize() int    { return SignatureSize }
+func (*scheme) SeedSize() int         { return SeedSize }
+func (*scheme) TLSIdentifier() uint   { return 0xfeaa /* temp */ }
+func (*scheme) SupportsContext() bool { return false }
+func (*scheme) Oid() asn1.ObjectIdentifier {
+	return asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 2, 267, 11, 6, 5}
+}
+
+func (*scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	return GenerateKey(nil)
+}
+
+func (*scheme) Sign(
+	sk sign.PrivateKey,
+	message []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	sig := make([]byte, SignatureSize)
+	SignTo(priv, message, sig)
+	return sig
+}
+
+func (*scheme) Verify(
+	pk sign.PublicKey,
+	message, signature []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	return Verify(pub, message, signature)
+}
+
+func (*scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != SeedSize {
+		panic(sign.ErrSeedSize)
+	}
+	var buf [SeedSize]byte
+	copy(buf[:], seed)
+	return NewKeyFromSeed(&buf)
+}
+
+func (*scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	if len(buf) != PublicKeySize {
+		return nil, sign.ErrPubKeySize
+	}
+	var (
+		buf2 [PublicKeySize]byte
+		ret  PublicKey
+	)
+	copy(buf2[:], buf)
+	ret.Unpack(&buf2)
+	return &ret, nil
+}
+
+func (*scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	if len(buf) != PrivateKeySize {
+		return nil, sign.ErrPrivKeySize
+	}
+	var (
+		buf2 [PrivateKeySize]byte
+		ret  PrivateKey
+	)
+	copy(buf2[:], buf)
+	ret.Unpack(&buf2)
+	return &ret, nil
+}
+
+func (*PublicKey) Scheme() sign.Scheme  { return sch }
+func (*PrivateKey) Scheme() sign.Scheme { return sch }
@@ -8,7 +8,7 @@ import (
 	"io"
 
 	"github.com/cloudflare/circl/internal/sha3"
-	"github.com/cloudflare/circl/sign/dilithium/internal/common"
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
 )
 
 const (
@@ -29,13 +29,13 @@ const (
 	Alpha = 2 * Gamma2
 
 	// Size of a packed private key
-	PrivateKeySize = 32 + 32 + 32 + PolyLeqEtaSize*(L+K) + common.PolyT0Size*K
+	PrivateKeySize = 32 + 32 + TRSize + PolyLeqEtaSize*(L+K) + common.PolyT0Size*K
 
 	// Size of a packed public key
 	PublicKeySize = 32 + common.PolyT1Size*K
 
 	// Size of a packed signature
-	SignatureSize = L*PolyLeGamma1Size + Omega + K + 32
+	SignatureSize = L*PolyLeGamma1Size + Omega + K + CTildeSize
 
 	// Size of packed w₁
 	PolyW1Size = (common.N * (common.QBits - Gamma1Bits)) / 8
@@ -49,7 +49,7 @@ type PublicKey struct {
 	// Cached values
 	t1p [common.PolyT1Size * K]byte
 	A   *Mat
-	tr  *[32]byte
+	tr  *[TRSize]byte
 }
 
 // PrivateKey is the type of Dilithium private keys.
@@ -59,7 +59,7 @@ type PrivateKey struct {
 	s1  VecL
 	s2  VecK
 	t0  VecK
-	tr  [32]byte
+	tr  [TRSize]byte
 
 	// Cached values
 	A   Mat  // ExpandA(ρ)
@@ -71,29 +71,30 @@ type PrivateKey struct {
 type unpackedSignature struct {
 	z    VecL
 	hint VecK
-	c    [32]byte
+	c    [CTildeSize]byte
 }
 
 // Packs the signature into buf.
 func (sig *unpackedSignature) Pack(buf []byte) {
 	copy(buf[:], sig.c[:])
-	sig.z.PackLeGamma1(buf[32:])
-	sig.hint.PackHint(buf[32+L*PolyLeGamma1Size:])
+	sig.z.PackLeGamma1(buf[CTildeSize:])
+	sig.hint.PackHint(buf[CTildeSize+L*PolyLeGamma1Size:])
 }
 
 // Sets sig to the signature encoded in the buffer.
 //
 // Returns whether buf contains a properly packed signature.
 func (sig *unpackedSignature) Unpack(buf []byte) bool {
-	if len(buf) < SignatureSize {
+	// Dilithium accepts signatures with trailing data, but ML-DSA does not.
+	if len(buf) < SignatureSize || (NIST && len(buf) != SignatureSize) {
 		return false
 	}
 	copy(sig.c[:], buf[:])
-	sig.z.UnpackLeGamma1(buf[32:])
+	sig.z.UnpackLeGamma1(buf[CTildeSize:])
 	if sig.z.Exceeds(Gamma1 - Beta) {
 		return false
 	}
-	if !sig.hint.UnpackHint(buf[32+L*PolyLeGamma1Size:]) {
+	if !sig.hint.UnpackHint(buf[CTildeSize+L*PolyLeGamma1Size:]) {
 		return false
 	}
 	return true
@@ -115,7 +116,7 @@ func (pk *PublicKey) Unpack(buf *[PublicKeySize]byte) {
 	pk.A.Derive(&pk.rho)
 
 	// tr = CRH(ρ ‖ t1) = CRH(pk)
-	pk.tr = new([32]byte)
+	pk.tr = new([TRSize]byte)
 	h := sha3.NewShake256()
 	_, _ = h.Write(buf[:])
 	_, _ = h.Read(pk.tr[:])
@@ -125,8 +126,8 @@ func (pk *PublicKey) Unpack(buf *[PublicKeySize]byte) {
 func (sk *PrivateKey) Pack(buf *[PrivateKeySize]byte) {
 	copy(buf[:32], sk.rho[:])
 	copy(buf[32:64], sk.key[:])
-	copy(buf[64:96], sk.tr[:])
-	offset := 96
+	copy(buf[64:64+TRSize], sk.tr[:])
+	offset := 64 + TRSize
 	sk.s1.PackLeqEta(buf[offset:])
 	offset += PolyLeqEtaSize * L
 	sk.s2.PackLeqEta(buf[offset:])
@@ -138,8 +139,8 @@ func (sk *PrivateKey) Pack(buf *[PrivateKeySize]byte) {
 func (sk *PrivateKey) Unpack(buf *[PrivateKeySize]byte) {
 	copy(sk.rho[:], buf[:32])
 	copy(sk.key[:], buf[32:64])
-	copy(sk.tr[:], buf[64:96])
-	offset := 96
+	copy(sk.tr[:], buf[64:64+TRSize])
+	offset := 64 + TRSize
 	sk.s1.UnpackLeqEta(buf[offset:])
 	offset += PolyLeqEtaSize * L
 	sk.s2.UnpackLeqEta(buf[offset:])
@@ -178,8 +179,13 @@ func NewKeyFromSeed(seed *[common.SeedSize]byte) (*PublicKey, *PrivateKey) {
 	var sk PrivateKey
 	var sSeed [64]byte
 
+	// ML-DSA separates the domains of the parameter sets by appending
+	// k and l to the seed.
 	h := sha3.NewShake256()
 	_, _ = h.Write(seed[:])
+	if NIST {
+		_, _ = h.Write([]byte{byte(K), byte(L)})
+	}
 	_, _ = h.Read(eSeed[:])
 
 	copy(pk.rho[:], eSeed[:32])
@@ -243,14 +249,18 @@ func (sk *PrivateKey) computeT0andT1(t0, t1 *VecK) {
 	t.Power2Round(t0, t1)
 }
 
-// Verify checks whether the given signature by pk on msg is valid.
-func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
+// Verify checks whether the given signature by pk on the message written
+// by msg is valid.
+//
+// For ML-DSA this is ML-DSA.Verify_internal, and msg must write the
+// formatted message M'.
+func Verify(pk *PublicKey, msg func(io.Writer), signature []byte) bool {
 	var sig unpackedSignature
 	var mu [64]byte
 	var zh VecL
 	var Az, Az2dct1, w1 VecK
 	var ch common.Poly
-	var cp [32]byte
+	var cp [CTildeSize]byte
 	var w1Packed [PolyW1Size * K]byte
 
 	// Note that Unpack() checked whether ‖z‖_∞ < γ₁ - β
@@ -262,7 +272,7 @@ func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
 	// μ = CRH(tr ‖ msg)
 	h := sha3.NewShake256()
 	_, _ = h.Write(pk.tr[:])
-	_, _ = h.Write(msg)
+	msg(&h)
 	_, _ = h.Read(mu[:])
 
 	// Compute Az
@@ -279,7 +289,7 @@ func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
 	// which is small enough for NTT().
 	Az2dct1.MulBy2toD(&pk.t1)
 	Az2dct1.NTT()
-	PolyDeriveUniformBall(&ch, &sig.c)
+	PolyDeriveUniformBall(&ch, sig.c[:])
 	ch.NTT()
 	for i := 0; i < K; i++ {
 		Az2dct1[i].MulHat(&Az2dct1[i], &ch)
@@ -305,10 +315,16 @@ func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
 	return sig.c == cp
 }
 
-// SignTo signs the given message and writes the signature into signature.
+// SignTo signs the message written by msg and writes the signature into
+// signature.
+//
+// For ML-DSA this is ML-DSA.Sign_internal, msg must write the formatted
+// message M' and rnd is the randomness of the hedged variant, which is all
+// zeroes for the deterministic one.  Dilithium ignores rnd.
 //
 //nolint:funlen
-func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
+func SignTo(sk *PrivateKey, msg func(io.Writer), rnd *[32]byte,
+	signature []byte) {
 	var mu, rhop [64]byte
 	var w1Packed [PolyW1Size * K]byte
 	var y, yh VecL
@@ -324,12 +340,15 @@ func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 	//  μ = CRH(tr ‖ msg)
 	h := sha3.NewShake256()
 	_, _ = h.Write(sk.tr[:])
-	_, _ = h.Write(msg)
+	msg(&h)
 	_, _ = h.Read(mu[:])
 
-	// ρ' = CRH(key ‖ μ)
+	// ρ' = CRH(key ‖ μ), or CRH(key ‖ rnd ‖ μ) for ML-DSA
 	h.Reset()
 	_, _ = h.Write(sk.key[:])
+	if NIST {
+		_, _ = h.Write(rnd[:])
+	}
 	_, _ = h.Write(mu[:])
 	_, _ = h.Read(rhop[:])
 
@@ -368,7 +387,7 @@ func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 		_, _ = h.Write(w1Packed[:])
 		_, _ = h.Read(sig.c[:])
 
-		PolyDeriveUniformBall(&ch, &sig.c)
+		PolyDeriveUniformBall(&ch, sig.c[:])
 		ch.NTT()
 
 		// Ensure ‖ w₀ - c·s2 ‖_∞ < γ₂ - β.
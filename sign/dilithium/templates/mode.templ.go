@@ -10,8 +10,8 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/cloudflare/circl/sign/dilithium/internal/common"
 	"github.com/cloudflare/circl/sign/dilithium/{{.Pkg}}"
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
 )
 
 // {{.Impl}} implements the mode.Mode interface for {{.Name}}.
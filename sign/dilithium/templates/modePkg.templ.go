@@ -4,19 +4,35 @@
 
 // Code generated from modePkg.templ.go. DO NOT EDIT.
 
+{{ if .NIST -}}
+// {{.Pkg}} implements the NIST signature scheme {{.Name}} as defined in
+//
+// https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.204.pdf
+{{- else -}}
 // {{.Pkg}} implements the CRYSTALS-Dilithium signature scheme {{.Name}}
 // as submitted to round3 of the NIST PQC competition and described in
 //
 // https://pq-crystals.org/dilithium/data/dilithium-specification-round3-20210208.pdf
+{{- end }}
 package {{.Pkg}}
 
 import (
 	"crypto"
+{{- if .NIST }}
+	cryptoRand "crypto/rand"
+{{- end }}
+	"encoding/asn1"
 	"errors"
 	"io"
 
-	"github.com/cloudflare/circl/sign/dilithium/internal/common"
+	"github.com/cloudflare/circl/sign"
+{{- if .NIST }}
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
+	"github.com/cloudflare/circl/sign/mldsa/{{.Pkg}}/internal"
+{{- else }}
 	"github.com/cloudflare/circl/sign/dilithium/{{.Pkg}}/internal"
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
+{{- end }}
 )
 
 const (
@@ -51,13 +67,112 @@ func NewKeyFromSeed(seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
 	pk, sk := internal.NewKeyFromSeed(seed)
 	return (*PublicKey)(pk), (*PrivateKey)(sk)
 }
+{{ if .NIST }}
+// Returns the randomness for signing: all zeroes for the deterministic
+// variant, and read from crypto/rand for the hedged one.
+func signingRandomness(randomized bool) (*[32]byte, error) {
+	var rnd [32]byte
+	if randomized {
+		if _, err := cryptoRand.Read(rnd[:]); err != nil {
+			return nil, err
+		}
+	}
+	return &rnd, nil
+}
+
+// SignTo signs the given message with the context string ctx and writes
+// the signature into signature.  If randomized is true, the hedged variant
+// is used, which draws randomness from crypto/rand, and otherwise the
+// deterministic variant.  A nil ctx is equivalent to an empty one.
+//
+// Returns sign.ErrContextTooLong if ctx is longer than 255 bytes.
+// It will panic if signature is not of length at least SignatureSize.
+func SignTo(sk *PrivateKey, msg, ctx []byte, randomized bool,
+	signature []byte) error {
+	if len(ctx) > 255 {
+		return sign.ErrContextTooLong
+	}
+	rnd, err := signingRandomness(randomized)
+	if err != nil {
+		return err
+	}
+	internal.SignTo(
+		(*internal.PrivateKey)(sk),
+		func(w io.Writer) { common.WriteMessage(w, msg, ctx) },
+		rnd,
+		signature,
+	)
+	return nil
+}
 
+// SignHashTo signs the digest of a message computed with the hash function
+// h using HashML-DSA, with the context string ctx, and writes the signature
+// into signature.  See SignTo for ctx and randomized.
+//
+// Returns an error if h is not supported, if digest is not of h.Size()
+// bytes or if ctx is longer than 255 bytes.
+// It will panic if signature is not of length at least SignatureSize.
+func SignHashTo(sk *PrivateKey, digest []byte, h crypto.Hash, ctx []byte,
+	randomized bool, signature []byte) error {
+	if len(ctx) > 255 {
+		return sign.ErrContextTooLong
+	}
+	oid := common.PreHashOid(h)
+	if oid == nil {
+		return errors.New("{{.Pkg}}: unsupported hash function")
+	}
+	if len(digest) != h.Size() {
+		return errors.New("{{.Pkg}}: wrong digest size")
+	}
+	rnd, err := signingRandomness(randomized)
+	if err != nil {
+		return err
+	}
+	internal.SignTo(
+		(*internal.PrivateKey)(sk),
+		func(w io.Writer) { common.WritePreHashMessage(w, digest, oid, ctx) },
+		rnd,
+		signature,
+	)
+	return nil
+}
+
+// Verify checks whether the given signature by pk on msg with the context
+// string ctx is valid.  A nil ctx is equivalent to an empty one.
+func Verify(pk *PublicKey, msg, ctx, signature []byte) bool {
+	if len(ctx) > 255 {
+		return false
+	}
+	return internal.Verify(
+		(*internal.PublicKey)(pk),
+		func(w io.Writer) { common.WriteMessage(w, msg, ctx) },
+		signature,
+	)
+}
+
+// VerifyHash checks whether the given HashML-DSA signature by pk on the
+// digest computed with the hash function h and the context string ctx
+// is valid.
+func VerifyHash(pk *PublicKey, digest []byte, h crypto.Hash, ctx,
+	signature []byte) bool {
+	oid := common.PreHashOid(h)
+	if len(ctx) > 255 || oid == nil || len(digest) != h.Size() {
+		return false
+	}
+	return internal.Verify(
+		(*internal.PublicKey)(pk),
+		func(w io.Writer) { common.WritePreHashMessage(w, digest, oid, ctx) },
+		signature,
+	)
+}
+{{- else }}
 // SignTo signs the given message and writes the signature into signature.
 // It will panic if signature is not of length at least SignatureSize.
 func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 	internal.SignTo(
 		(*internal.PrivateKey)(sk),
-		msg,
+		func(w io.Writer) { _, _ = w.Write(msg) },
+		new([32]byte),
 		signature,
 	)
 }
@@ -66,10 +181,11 @@ func SignTo(sk *PrivateKey, msg []byte, signature []byte) {
 func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
 	return internal.Verify(
 		(*internal.PublicKey)(pk),
-		msg,
+		func(w io.Writer) { _, _ = w.Write(msg) },
 		signature,
 	)
 }
+{{- end }}
 
 // Sets pk to the public key encoded in buf.
 func (pk *PublicKey) Unpack(buf *[PublicKeySize]byte) {
@@ -136,7 +252,32 @@ func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
 	sk.Unpack(&buf)
 	return nil
 }
+{{ if .NIST }}
+// Sign signs the given message deterministically with an empty context
+// string.  rand is ignored.
+//
+// If opts.HashFunc() is zero, msg is signed with ML-DSA.  Otherwise msg
+// must be the digest of the message computed with opts.HashFunc(), and it
+// is signed with HashML-DSA.
+//
+// This function is used to make PrivateKey implement the crypto.Signer
+// interface.  The package-level SignTo and SignHashTo functions are more
+// flexible.
+func (sk *PrivateKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) (
+	signature []byte, err error) {
+	var sig [SignatureSize]byte
 
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) {
+		err = SignHashTo(sk, msg, opts.HashFunc(), nil, false, sig[:])
+	} else {
+		err = SignTo(sk, msg, nil, false, sig[:])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return sig[:], nil
+}
+{{- else }}
 // Sign signs the given message.
 //
 // opts.HashFunc() must return zero, which can be achieved by passing
@@ -157,6 +298,7 @@ func (sk *PrivateKey) Sign(rand io.Reader, msg []byte, opts crypto.SignerOpts) (
 	SignTo(sk, msg, sig[:])
 	return sig[:], nil
 }
+{{- end }}
 
 // Computes the public key corresponding to this private key.
 //
@@ -183,3 +325,120 @@ func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
 	}
 	return (*internal.PublicKey)(pk).Equal((*internal.PublicKey)(castOther))
 }
+
+// Boilerplate for the generic signatures API
+
+type scheme struct{}
+
+var sch sign.Scheme = &scheme{}
+
+// Scheme returns a generic signature interface for {{.Name}}.
+func Scheme() sign.Scheme { return sch }
+
+func (*scheme) Name() string          { return "{{.Name}}" }
+func (*scheme) PublicKeySize() int    { return PublicKeySize }
+func (*scheme) PrivateKeySize() int   { return PrivateKeySize }
+func (*scheme) SignatureSize() int    { return SignatureSize }
+func (*scheme) SeedSize() int         { return SeedSize }
+{{- if .NIST }}
+func (*scheme) SupportsContext() bool { return true }
+{{- else }}
+func (*scheme) TLSIdentifier() uint   { return {{printf "0x%04x" .TLSIdentifier}} /* temp */ }
+func (*scheme) SupportsContext() bool { return false }
+{{- end }}
+func (*scheme) Oid() asn1.ObjectIdentifier {
+	return {{.OidCode}}
+}
+
+func (*scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	return GenerateKey(nil)
+}
+
+func (*scheme) Sign(
+	sk sign.PrivateKey,
+	message []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok {
+		panic(sign.ErrTypeMismatch)
+	}
+{{- if .NIST }}
+	var ctx []byte
+	if opts != nil {
+		ctx = []byte(opts.Context)
+	}
+	sig := make([]byte, SignatureSize)
+	if err := SignTo(priv, message, ctx, false, sig); err != nil {
+		panic(err)
+	}
+{{- else }}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	sig := make([]byte, SignatureSize)
+	SignTo(priv, message, sig)
+{{- end }}
+	return sig
+}
+
+func (*scheme) Verify(
+	pk sign.PublicKey,
+	message, signature []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok {
+		panic(sign.ErrTypeMismatch)
+	}
+{{- if .NIST }}
+	var ctx []byte
+	if opts != nil {
+		ctx = []byte(opts.Context)
+	}
+	return Verify(pub, message, ctx, signature)
+{{- else }}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	return Verify(pub, message, signature)
+{{- end }}
+}
+
+func (*scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != SeedSize {
+		panic(sign.ErrSeedSize)
+	}
+	var buf [SeedSize]byte
+	copy(buf[:], seed)
+	return NewKeyFromSeed(&buf)
+}
+
+func (*scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	if len(buf) != PublicKeySize {
+		return nil, sign.ErrPubKeySize
+	}
+	var (
+		buf2 [PublicKeySize]byte
+		ret  PublicKey
+	)
+	copy(buf2[:], buf)
+	ret.Unpack(&buf2)
+	return &ret, nil
+}
+
+func (*scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	if len(buf) != PrivateKeySize {
+		return nil, sign.ErrPrivKeySize
+	}
+	var (
+		buf2 [PrivateKeySize]byte
+		ret  PrivateKey
+	)
+	copy(buf2[:], buf)
+	ret.Unpack(&buf2)
+	return &ret, nil
+}
+
+func (*PublicKey) Scheme() sign.Scheme  { return sch }
+func (*PrivateKey) Scheme() sign.Scheme { return sch }
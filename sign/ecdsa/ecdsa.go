@@ -0,0 +1,381 @@
+// Package ecdsa provides the signature schemes ECDSA over the NIST curves
+// P-256, P-384 and P-521, as adapters around crypto/ecdsa.
+//
+// Each curve is used with the hash function of matching strength: SHA-256,
+// SHA-384 and SHA-512, respectively, as in TLS 1.3.  Public keys are
+// encoded in the uncompressed SEC 1 format, private keys as big-endian
+// integers, and signatures as the concatenation r||s of two big-endian
+// integers of the size of the order of the curve, as in IEEE P1363.
+// Hence, unlike those of crypto/ecdsa, signatures are not ASN.1 encoded
+// and have a fixed length.
+//
+// Signing uses crypto/ecdsa with fresh randomness.  SignDeterministic
+// derives the nonce from the private key and the message as in RFC 6979
+// instead, which gives reproducible signatures for tests.
+//
+// References:
+//   - FIPS 186-4: Digital Signature Standard (DSS).
+//     https://doi.org/10.6028/NIST.FIPS.186-4
+//   - RFC 6979: Deterministic Usage of DSA and ECDSA.
+//     https://www.rfc-editor.org/rfc/rfc6979
+package ecdsa
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	cryptoRand "crypto/rand"
+	_ "crypto/sha256" // Registers SHA-256.
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512.
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign"
+)
+
+// ID identifies the supported curves of ECDSA.
+// Note that the zero value is not a valid identifier.
+type ID byte
+
+const (
+	P256 ID = iota + 1 // ECDSA-P256-SHA256
+	P384               // ECDSA-P384-SHA384
+	P521               // ECDSA-P521-SHA512
+	_MaxParams
+)
+
+var (
+	// ErrParam is returned for an invalid ID, or a curve that is not
+	// supported.
+	ErrParam = errors.New("ecdsa: invalid parameters")
+
+	// ErrInvalidPublicKey is returned when unpacking a public key that is
+	// not a point of the curve.
+	ErrInvalidPublicKey = errors.New("ecdsa: invalid public key")
+
+	// ErrInvalidPrivateKey is returned when unpacking a private key that
+	// is not in [1, n-1].
+	ErrInvalidPrivateKey = errors.New("ecdsa: invalid private key")
+)
+
+// params contains the constants of a curve.
+type params struct {
+	id    ID
+	name  string
+	curve func() elliptic.Curve
+	hash  crypto.Hash
+	size  int  // Size of scalars in bytes.
+	tlsID uint // SignatureScheme of TLS 1.3.
+
+	// OIDs of the ecdsa-with-SHA2 signature algorithm and of the curve.
+	oid, curveOid asn1.ObjectIdentifier
+}
+
+var supportedParams = [_MaxParams - 1]params{
+	{
+		P256, "ECDSA-P256-SHA256", elliptic.P256, crypto.SHA256, 32, 0x0403,
+		asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2},
+		asn1.ObjectIdentifier{1, 2, 840, 10045, 3, 1, 7},
+	},
+	{
+		P384, "ECDSA-P384-SHA384", elliptic.P384, crypto.SHA384, 48, 0x0503,
+		asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 3},
+		asn1.ObjectIdentifier{1, 3, 132, 0, 34},
+	},
+	{
+		P521, "ECDSA-P521-SHA512", elliptic.P521, crypto.SHA512, 66, 0x0603,
+		asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 4},
+		asn1.ObjectIdentifier{1, 3, 132, 0, 35},
+	},
+}
+
+// oidPublicKeyECDSA is id-ecPublicKey, the algorithm of ECDSA keys in X.509.
+var oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+
+// IsValid returns true if the identifier is supported.
+func (id ID) IsValid() bool { return 0 < id && id < _MaxParams }
+
+func (id ID) String() string {
+	if !id.IsValid() {
+		return ErrParam.Error()
+	}
+	return supportedParams[id-1].name
+}
+
+func (id ID) params() *params {
+	if !id.IsValid() {
+		panic(ErrParam)
+	}
+	return &supportedParams[id-1]
+}
+
+// Curve returns the elliptic curve of the identifier.
+func (id ID) Curve() elliptic.Curve { return id.params().curve() }
+
+// Hash returns the hash function applied to messages before signing.
+func (id ID) Hash() crypto.Hash { return id.params().hash }
+
+// Oid returns the object identifier ecdsa-with-SHA256, -SHA384 or -SHA512 of
+// the signature algorithm.
+func (id ID) Oid() asn1.ObjectIdentifier { return id.params().oid }
+
+// CurveOid returns the object identifier of the named curve, which is the
+// parameter of id-ecPublicKey in X.509 public keys.
+func (id ID) CurveOid() asn1.ObjectIdentifier { return id.params().curveOid }
+
+// PublicKeySize returns the size of packed public keys.
+func (id ID) PublicKeySize() int { return 1 + 2*id.params().size }
+
+// PrivateKeySize returns the size of packed private keys.
+func (id ID) PrivateKeySize() int { return id.params().size }
+
+// SignatureSize returns the size of signatures.
+func (id ID) SignatureSize() int { return 2 * id.params().size }
+
+func idOf(c elliptic.Curve) (ID, error) {
+	for i := range supportedParams {
+		if c == supportedParams[i].curve() {
+			return supportedParams[i].id, nil
+		}
+	}
+	return 0, ErrParam
+}
+
+func (p *params) digest(message []byte) []byte {
+	h := p.hash.New()
+	_, _ = h.Write(message)
+	return h.Sum(nil)
+}
+
+// PublicKey is the type of ECDSA public keys.
+type PublicKey struct {
+	id ID
+	pk ecdsa.PublicKey
+}
+
+// PrivateKey is the type of ECDSA private keys.
+type PrivateKey struct {
+	id ID
+	sk ecdsa.PrivateKey
+}
+
+// NewPublicKey wraps a public key of crypto/ecdsa over a supported curve.
+func NewPublicKey(pk *ecdsa.PublicKey) (*PublicKey, error) {
+	id, err := idOf(pk.Curve)
+	if err != nil {
+		return nil, err
+	}
+	if !pk.Curve.IsOnCurve(pk.X, pk.Y) {
+		return nil, ErrInvalidPublicKey
+	}
+	return &PublicKey{id: id, pk: *pk}, nil
+}
+
+// NewPrivateKey wraps a private key of crypto/ecdsa over a supported
+// curve.
+func NewPrivateKey(sk *ecdsa.PrivateKey) (*PrivateKey, error) {
+	id, err := idOf(sk.Curve)
+	if err != nil {
+		return nil, err
+	}
+	N := sk.Curve.Params().N
+	if sk.D.Sign() <= 0 || sk.D.Cmp(N) >= 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	return newPrivateKey(id, sk.D), nil
+}
+
+func newPrivateKey(id ID, d *big.Int) *PrivateKey {
+	c := id.Curve()
+	sk := &PrivateKey{id: id}
+	sk.sk.Curve = c
+	sk.sk.D = new(big.Int).Set(d)
+	sk.sk.X, sk.sk.Y = c.ScalarBaseMult(d.Bytes())
+	return sk
+}
+
+// GenerateKey generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader, id ID) (*PublicKey, *PrivateKey, error) {
+	if !id.IsValid() {
+		return nil, nil, ErrParam
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	k, err := ecdsa.GenerateKey(id.Curve(), rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk := &PrivateKey{id: id, sk: *k}
+	return sk.PublicKey(), sk, nil
+}
+
+// ECDSA returns the public key as a public key of crypto/ecdsa.
+func (pk *PublicKey) ECDSA() *ecdsa.PublicKey {
+	k := pk.pk
+	return &k
+}
+
+// ECDSA returns the private key as a private key of crypto/ecdsa.
+func (sk *PrivateKey) ECDSA() *ecdsa.PrivateKey {
+	k := sk.sk
+	k.D = new(big.Int).Set(sk.sk.D)
+	return &k
+}
+
+// ID returns the identifier of the curve of the key.
+func (pk *PublicKey) ID() ID { return pk.id }
+
+// ID returns the identifier of the curve of the key.
+func (sk *PrivateKey) ID() ID { return sk.id }
+
+// PublicKey returns the public key corresponding to this private key.
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{id: sk.id, pk: sk.sk.PublicKey}
+}
+
+// Public returns the public key corresponding to this private key.
+//
+// Returns a *PublicKey.  The type crypto.PublicKey is used to make
+// PrivateKey implement the crypto.Signer interface.
+func (sk *PrivateKey) Public() crypto.PublicKey { return sk.PublicKey() }
+
+func (p *params) marshalSignature(r, s *big.Int) []byte {
+	sig := make([]byte, 2*p.size)
+	r.FillBytes(sig[:p.size])
+	s.FillBytes(sig[p.size:])
+	return sig
+}
+
+// SignDigest signs the digest of a message, using entropy from rand, and
+// returns the signature r||s.  If rand is nil, crypto/rand.Reader will be
+// used.
+func SignDigest(rand io.Reader, sk *PrivateKey, digest []byte) ([]byte, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	r, s, err := ecdsa.Sign(rand, &sk.sk, digest)
+	if err != nil {
+		return nil, err
+	}
+	return sk.id.params().marshalSignature(r, s), nil
+}
+
+// Sign hashes and signs the message, using entropy from rand, and returns
+// the signature r||s.  If rand is nil, crypto/rand.Reader will be used.
+func Sign(rand io.Reader, sk *PrivateKey, message []byte) ([]byte, error) {
+	return SignDigest(rand, sk, sk.id.params().digest(message))
+}
+
+// SignDeterministic hashes and signs the message with the nonce derived
+// as in RFC 6979, and returns the signature r||s.
+//
+// Unlike Sign, it does not run in constant time, so it is intended for
+// tests and reproducible outputs rather than for signing with long-term
+// keys.
+func SignDeterministic(sk *PrivateKey, message []byte) []byte {
+	p := sk.id.params()
+	r, s := signRFC6979(p, sk.sk.D, p.digest(message))
+	return p.marshalSignature(r, s)
+}
+
+// VerifyDigest checks whether the signature r||s by pk on the digest of a
+// message is valid.
+func VerifyDigest(pk *PublicKey, digest, signature []byte) bool {
+	p := pk.id.params()
+	if len(signature) != 2*p.size {
+		return false
+	}
+	r := new(big.Int).SetBytes(signature[:p.size])
+	s := new(big.Int).SetBytes(signature[p.size:])
+	return ecdsa.Verify(&pk.pk, digest, r, s)
+}
+
+// Verify checks whether the signature r||s by pk on the message is valid.
+func Verify(pk *PublicKey, message, signature []byte) bool {
+	return VerifyDigest(pk, pk.id.params().digest(message), signature)
+}
+
+// Sign signs the given digest of a message, which must have been computed
+// with opts.HashFunc(), and returns the signature r||s.  If rand is nil,
+// crypto/rand.Reader will be used.
+//
+// This function is used to make PrivateKey implement the crypto.Signer
+// interface.  Note that the signature is not ASN.1 encoded, unlike that
+// returned by crypto/ecdsa.
+func (sk *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) (
+	signature []byte, err error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) &&
+		len(digest) != opts.HashFunc().Size() {
+		return nil, errors.New("ecdsa: wrong digest size")
+	}
+	return SignDigest(rand, sk, digest)
+}
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	castOther, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return sk.id == castOther.id && sk.sk.D.Cmp(castOther.sk.D) == 0
+}
+
+// MarshalBinary returns the private key as a big-endian integer of
+// PrivateKeySize bytes.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	b := make([]byte, sk.id.PrivateKeySize())
+	return sk.sk.D.FillBytes(b), nil
+}
+
+// UnmarshalBinary sets sk to the private key of the curve of sk.ID()
+// encoded as a big-endian integer, which must be in [1, n-1].
+func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
+	if !sk.id.IsValid() {
+		return ErrParam
+	}
+	if len(data) != sk.id.PrivateKeySize() {
+		return sign.ErrPrivKeySize
+	}
+	d := new(big.Int).SetBytes(data)
+	if d.Sign() == 0 || d.Cmp(sk.id.Curve().Params().N) >= 0 {
+		return ErrInvalidPrivateKey
+	}
+	*sk = *newPrivateKey(sk.id, d)
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	castOther, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.id == castOther.id && pk.pk.Equal(&castOther.pk)
+}
+
+// MarshalBinary returns the public key in the uncompressed SEC 1 format.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return elliptic.Marshal(pk.pk.Curve, pk.pk.X, pk.pk.Y), nil
+}
+
+// UnmarshalBinary sets pk to the public key of the curve of pk.ID()
+// encoded in the uncompressed SEC 1 format.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	if !pk.id.IsValid() {
+		return ErrParam
+	}
+	if len(data) != pk.id.PublicKeySize() {
+		return sign.ErrPubKeySize
+	}
+	c := pk.id.Curve()
+	x, y := elliptic.Unmarshal(c, data)
+	if x == nil {
+		return ErrInvalidPublicKey
+	}
+	pk.pk = ecdsa.PublicKey{Curve: c, X: x, Y: y}
+	return nil
+}
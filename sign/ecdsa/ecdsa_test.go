@@ -0,0 +1,186 @@
+package ecdsa_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/hex"
+	"math/big"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/pki"
+	circlEcdsa "github.com/cloudflare/circl/sign/ecdsa"
+)
+
+func hexBytes(s string) []byte {
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// Test vectors from RFC 6979, Appendix A.2.5, A.2.6 and A.2.7.
+var rfc6979Vectors = []struct {
+	id           circlEcdsa.ID
+	x, msg, r, s string
+}{
+	{
+		circlEcdsa.P256,
+		"C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+		"sample",
+		"EFD48B2AACB6A8FD1140DD9CD45E81D69D2C877B56AAF991C34D0EA84EAF3716",
+		"F7CB1C942D657C41D436C7A1B6E29F65F3E900DBB9AFF4064DC4AB2F843ACDA8",
+	},
+	{
+		circlEcdsa.P256,
+		"C9AFA9D845BA75166B5C215767B1D6934E50C3DB36E89B127B8A622B120F6721",
+		"test",
+		"F1ABB023518351CD71D881567B1EA663ED3EFCF6C5132B354F28D3B0B7D38367",
+		"019F4113742A2B14BD25926B49C649155F267E60D3814B4C0CC84250E46F0083",
+	},
+	{
+		circlEcdsa.P384,
+		"6B9D3DAD2E1B8C1C05B19875B6659F4DE23C3B667BF297BA9AA47740787137D8" +
+			"96D5724E4C70A825F872C9EA60D2EDF5",
+		"sample",
+		"94EDBB92A5ECB8AAD4736E56C691916B3F88140666CE9FA73D64C4EA95AD133C" +
+			"81A648152E44ACF96E36DD1E80FABE46",
+		"99EF4AEB15F178CEA1FE40DB2603138F130E740A19624526203B6351D0A3A94F" +
+			"A329C145786E679E7B82C71A38628AC8",
+	},
+	{
+		circlEcdsa.P521,
+		"00FAD06DAA62BA3B25D2FB40133DA757205DE67F5BB0018FEE8C86E1B68C7E75" +
+			"CAA896EB32F1F47C70855836A6D16FCC1466F6D8FBEC67DB89EC0C08B0E996B83538",
+		"sample",
+		"00C328FAFCBD79DD77850370C46325D987CB525569FB63C5D3BC53950E6D4C5F" +
+			"174E25A1EE9017B5D450606ADD152B534931D7D4E8455CC91F9B15BF05EC36E377FA",
+		"00617CCE7CF5064806C467F678D3B4080D6F1CC50AF26CA209417308281B68AF" +
+			"282623EAA63E5B5C0723D8B8C37FF0777B1A20F8CCB1DCCC43997F1EE0E44DA4A67A",
+	},
+}
+
+func TestRFC6979(t *testing.T) {
+	for _, v := range rfc6979Vectors {
+		sk, err := v.id.Scheme().UnmarshalBinaryPrivateKey(hexBytes(v.x))
+		test.CheckNoErr(t, err, "unmarshal failed")
+		priv := sk.(*circlEcdsa.PrivateKey)
+
+		want := append(hexBytes(v.r), hexBytes(v.s)...)
+		got := circlEcdsa.SignDeterministic(priv, []byte(v.msg))
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, v.id, v.msg)
+		}
+		test.CheckOk(circlEcdsa.Verify(priv.PublicKey(), []byte(v.msg), got),
+			"verify failed", t)
+	}
+}
+
+func TestInterop(t *testing.T) {
+	for _, id := range []circlEcdsa.ID{circlEcdsa.P256, circlEcdsa.P384, circlEcdsa.P521} {
+		k, err := ecdsa.GenerateKey(id.Curve(), rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+		sk, err := circlEcdsa.NewPrivateKey(k)
+		test.CheckNoErr(t, err, "wrapping private key failed")
+		pk, err := circlEcdsa.NewPublicKey(&k.PublicKey)
+		test.CheckNoErr(t, err, "wrapping public key failed")
+		test.CheckOk(sk.PublicKey().Equal(pk), "public keys differ", t)
+		test.CheckOk(sk.ECDSA().Equal(k), "private key roundtrip failed", t)
+		test.CheckOk(pk.ECDSA().Equal(&k.PublicKey), "public key roundtrip failed", t)
+
+		msg := []byte("message")
+		h := id.Hash().New()
+		_, _ = h.Write(msg)
+		digest := h.Sum(nil)
+		size := id.SignatureSize() / 2
+
+		// crypto/ecdsa verifies our signatures, and conversely.
+		for _, sig := range [][]byte{
+			circlEcdsa.SignDeterministic(sk, msg),
+			id.Scheme().Sign(sk, msg, nil),
+		} {
+			test.CheckOk(len(sig) == id.SignatureSize(), "wrong signature size", t)
+			r := new(big.Int).SetBytes(sig[:size])
+			s := new(big.Int).SetBytes(sig[size:])
+			test.CheckOk(ecdsa.Verify(&k.PublicKey, digest, r, s), "crypto/ecdsa rejected", t)
+		}
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest)
+		test.CheckNoErr(t, err, "sign failed")
+		sig := make([]byte, id.SignatureSize())
+		r.FillBytes(sig[:size])
+		s.FillBytes(sig[size:])
+		test.CheckOk(circlEcdsa.Verify(pk, msg, sig), "crypto/ecdsa signature rejected", t)
+
+		var signer crypto.Signer = sk
+		sig, err = signer.Sign(nil, digest, id.Hash())
+		test.CheckNoErr(t, err, "sign failed")
+		test.CheckOk(circlEcdsa.VerifyDigest(pk, digest, sig), "verify failed", t)
+		_, err = signer.Sign(nil, digest[1:], id.Hash())
+		test.CheckIsErr(t, err, "wrong digest size accepted")
+	}
+
+	k, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	test.CheckNoErr(t, err, "keygen failed")
+	_, err = circlEcdsa.NewPrivateKey(k)
+	test.CheckIsErr(t, err, "P-224 accepted")
+}
+
+func TestInvalidKeys(t *testing.T) {
+	sch := circlEcdsa.P256.Scheme()
+	_, err := sch.UnmarshalBinaryPrivateKey(make([]byte, 32))
+	test.CheckIsErr(t, err, "zero key accepted")
+	_, err = sch.UnmarshalBinaryPrivateKey(elliptic.P256().Params().N.Bytes())
+	test.CheckIsErr(t, err, "key n accepted")
+	_, err = sch.UnmarshalBinaryPrivateKey(make([]byte, 31))
+	test.CheckIsErr(t, err, "short key accepted")
+
+	pk, _, err := sch.GenerateKey()
+	test.CheckNoErr(t, err, "keygen failed")
+	enc, _ := pk.MarshalBinary()
+	enc[64] ^= 1
+	_, err = sch.UnmarshalBinaryPublicKey(enc)
+	test.CheckIsErr(t, err, "point off the curve accepted")
+	_, err = circlEcdsa.P384.Scheme().UnmarshalBinaryPublicKey(enc)
+	test.CheckIsErr(t, err, "wrong curve accepted")
+}
+
+// The public keys encoded by pki are those of crypto/x509, with
+// id-ecPublicKey and the curve as parameter.
+func TestPKIX(t *testing.T) {
+	for _, id := range []circlEcdsa.ID{circlEcdsa.P256, circlEcdsa.P384, circlEcdsa.P521} {
+		sch := id.Scheme()
+		if pki.SchemeByOid(id.Oid()) != sch {
+			t.Fatalf("%v: not found by signature OID", id)
+		}
+
+		pk, sk, err := sch.GenerateKey()
+		test.CheckNoErr(t, err, "keygen failed")
+
+		got, err := pki.MarshalPKIXPublicKey(pk)
+		test.CheckNoErr(t, err, "MarshalPKIXPublicKey failed")
+		want, err := x509.MarshalPKIXPublicKey(pk.(*circlEcdsa.PublicKey).ECDSA())
+		test.CheckNoErr(t, err, "x509.MarshalPKIXPublicKey failed")
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, id)
+		}
+
+		pk2, err := pki.UnmarshalPKIXPublicKey(want)
+		test.CheckNoErr(t, err, "UnmarshalPKIXPublicKey failed")
+		if !pk.Equal(pk2) {
+			t.Fatalf("%v: public key does not round trip", id)
+		}
+
+		der, err := pki.MarshalPKIXPrivateKey(sk)
+		test.CheckNoErr(t, err, "MarshalPKIXPrivateKey failed")
+		sk2, err := pki.UnmarshalPKIXPrivateKey(der)
+		test.CheckNoErr(t, err, "UnmarshalPKIXPrivateKey failed")
+		if !sk.Equal(sk2) {
+			t.Fatalf("%v: private key does not round trip", id)
+		}
+	}
+}
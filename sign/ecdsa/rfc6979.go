@@ -0,0 +1,78 @@
+package ecdsa
+
+import (
+	"crypto/hmac"
+	"math/big"
+)
+
+// bits2int converts b to an integer, keeping its leftmost qlen bits, as
+// in RFC 6979, Section 2.3.2.
+func bits2int(b []byte, qlen int) *big.Int {
+	x := new(big.Int).SetBytes(b)
+	if blen := 8 * len(b); blen > qlen {
+		x.Rsh(x, uint(blen-qlen))
+	}
+	return x
+}
+
+// signRFC6979 returns the signature (r, s) of the digest with the private
+// key x, whose nonce is derived with the HMAC_DRBG of RFC 6979, Section
+// 3.2.
+func signRFC6979(p *params, x *big.Int, digest []byte) (r, s *big.Int) {
+	c := p.curve()
+	q := c.Params().N
+	qlen := q.BitLen()
+
+	// int2octets(x) || bits2octets(h1)
+	e := bits2int(digest, qlen)
+	h1 := new(big.Int).Mod(e, q)
+	seed := make([]byte, 2*p.size)
+	x.FillBytes(seed[:p.size])
+	h1.FillBytes(seed[p.size:])
+
+	hlen := p.hash.Size()
+	mac := func(key []byte, data ...[]byte) []byte {
+		h := hmac.New(p.hash.New, key)
+		for _, d := range data {
+			_, _ = h.Write(d)
+		}
+		return h.Sum(nil)
+	}
+	v := make([]byte, hlen)
+	k := make([]byte, hlen)
+	for i := range v {
+		v[i] = 0x01
+	}
+	k = mac(k, v, []byte{0x00}, seed)
+	v = mac(k, v)
+	k = mac(k, v, []byte{0x01}, seed)
+	v = mac(k, v)
+
+	nonce, kInv := new(big.Int), new(big.Int)
+	r, s = new(big.Int), new(big.Int)
+	for {
+		var t []byte
+		for len(t) < p.size {
+			v = mac(k, v)
+			t = append(t, v...)
+		}
+		nonce = bits2int(t[:p.size], qlen)
+		if nonce.Sign() > 0 && nonce.Cmp(q) < 0 {
+			// r = x(kG) mod q, s = (e + r*x)/k mod q
+			rx, _ := c.ScalarBaseMult(nonce.Bytes())
+			r.Mod(rx, q)
+			if r.Sign() != 0 {
+				kInv.ModInverse(nonce, q)
+				s.Mul(r, x)
+				s.Add(s, e)
+				s.Mul(s, kInv)
+				s.Mod(s, q)
+				if s.Sign() != 0 {
+					return r, s
+				}
+			}
+		}
+		k = mac(k, v, []byte{0x00})
+		v = mac(k, v)
+	}
+}
@@ -0,0 +1,126 @@
+package ecdsa
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"math/big"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/sign"
+)
+
+// Scheme returns a signature interface for the curve of the identifier.
+//
+// The scheme implements pki.AlgorithmIdentifierScheme: X.509 identifies its
+// keys by id-ecPublicKey with the curve as parameter, and its signatures by
+// ecdsa-with-SHA256, -SHA384 or -SHA512.
+func (id ID) Scheme() sign.Scheme { return scheme{id.params()} }
+
+type scheme struct{ *params }
+
+func (s scheme) Name() string               { return s.name }
+func (s scheme) PublicKeySize() int         { return s.id.PublicKeySize() }
+func (s scheme) PrivateKeySize() int        { return s.id.PrivateKeySize() }
+func (s scheme) SignatureSize() int         { return s.id.SignatureSize() }
+func (s scheme) SeedSize() int              { return s.size }
+func (s scheme) TLSIdentifier() uint        { return s.tlsID }
+func (s scheme) SupportsContext() bool      { return false }
+func (s scheme) Oid() asn1.ObjectIdentifier { return s.oid }
+
+func (s scheme) KeyAlgorithmIdentifier() pkix.AlgorithmIdentifier {
+	curve, _ := asn1.Marshal(s.curveOid)
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidPublicKeyECDSA,
+		Parameters: asn1.RawValue{FullBytes: curve},
+	}
+}
+
+func (s scheme) SignatureAlgorithmIdentifier() pkix.AlgorithmIdentifier {
+	return pkix.AlgorithmIdentifier{Algorithm: s.oid}
+}
+
+func (s scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	return GenerateKey(rand.Reader, s.id)
+}
+
+func (s scheme) Sign(
+	sk sign.PrivateKey,
+	message []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok || priv.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	sig, err := Sign(rand.Reader, priv, message)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func (s scheme) Verify(
+	pk sign.PublicKey,
+	message, signature []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok || pub.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	return Verify(pub, message, signature)
+}
+
+// DeriveKey deterministically derives a pair of keys from a seed.  The
+// private key is sampled in [1, n-1] by rejection from the output of
+// SHAKE-256 on the seed.
+//
+// Panics if seed is not of length SeedSize().
+func (s scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != s.SeedSize() {
+		panic(sign.ErrSeedSize)
+	}
+	N := s.curve().Params().N
+	excess := uint(8*s.size - N.BitLen())
+
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	b := make([]byte, s.size)
+	d := new(big.Int)
+	for {
+		_, _ = h.Read(b)
+		b[0] &= 0xff >> excess
+		d.SetBytes(b)
+		if d.Sign() != 0 && d.Cmp(N) < 0 {
+			break
+		}
+	}
+	sk := newPrivateKey(s.id, d)
+	return sk.PublicKey(), sk
+}
+
+func (s scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	pk := &PublicKey{id: s.id}
+	if err := pk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+func (s scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	sk := &PrivateKey{id: s.id}
+	if err := sk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+func (pk *PublicKey) Scheme() sign.Scheme  { return pk.id.Scheme() }
+func (sk *PrivateKey) Scheme() sign.Scheme { return sk.id.Scheme() }
@@ -0,0 +1,45 @@
+package secp256k1
+
+import (
+	"strconv"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// AddressSize is the size of an Ethereum address.
+const AddressSize = 20
+
+func keccak256(data ...[]byte) []byte {
+	h := sha3.NewLegacyKeccak256()
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	return h.Sum(nil)
+}
+
+// Address returns the Ethereum address of the public key: the last 20
+// bytes of the Keccak-256 hash of its uncompressed encoding, without the
+// leading 0x04 byte.
+func (pk *PublicKey) Address() []byte {
+	return keccak256(pk.Uncompressed()[1:])[32-AddressSize:]
+}
+
+// TextHash returns the digest that is signed for the message msg by the
+// personal_sign method of Ethereum, as defined by version 0x45 of EIP-191:
+//
+//	Keccak-256("\x19Ethereum Signed Message:\n" || len(msg) || msg)
+//
+// where the length of msg is written in decimal.
+func TextHash(msg []byte) []byte {
+	prefix := "\x19Ethereum Signed Message:\n" + strconv.Itoa(len(msg))
+	return keccak256([]byte(prefix), msg)
+}
+
+// SignText signs msg as the personal_sign method of Ethereum wallets, and
+// returns the recoverable signature r||s||v, where v is 27 or 28.  The
+// signer can be recovered with RecoverPublicKey(TextHash(msg), signature).
+func SignText(sk *PrivateKey, msg []byte) []byte {
+	sig := SignRecoverable(sk, TextHash(msg))
+	sig[64] += 27
+	return sig
+}
@@ -0,0 +1,53 @@
+package secp256k1
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+
+	"github.com/cloudflare/circl/ecc/secp256k1"
+)
+
+// nonceGenerator is the HMAC_DRBG of RFC 6979, Section 3.2, instantiated
+// with SHA-256, which derives the nonces of signatures deterministically
+// from the private key and the message digest.
+type nonceGenerator struct {
+	k, v  [sha256.Size]byte
+	retry bool
+}
+
+func (g *nonceGenerator) hmac(out []byte, data ...[]byte) {
+	h := hmac.New(sha256.New, g.k[:])
+	for _, d := range data {
+		_, _ = h.Write(d)
+	}
+	h.Sum(out[:0])
+}
+
+// newNonceGenerator initializes the generator with the private key x and
+// the digest h1 reduced modulo n, both as 32-byte big-endian values.
+func newNonceGenerator(x, h1 []byte) *nonceGenerator {
+	g := new(nonceGenerator)
+	for i := range g.v {
+		g.v[i] = 0x01
+	}
+	g.hmac(g.k[:], g.v[:], []byte{0x00}, x, h1)
+	g.hmac(g.v[:], g.v[:])
+	g.hmac(g.k[:], g.v[:], []byte{0x01}, x, h1)
+	g.hmac(g.v[:], g.v[:])
+	return g
+}
+
+// next sets k to the next candidate nonce in [1, n-1].
+func (g *nonceGenerator) next(k *secp256k1.Scalar) {
+	for {
+		if g.retry {
+			g.hmac(g.k[:], g.v[:], []byte{0x00})
+			g.hmac(g.v[:], g.v[:])
+		}
+		g.retry = true
+		g.hmac(g.v[:], g.v[:])
+		if k.SetBytes(g.v[:]) && !k.IsZero() {
+			return
+		}
+	}
+}
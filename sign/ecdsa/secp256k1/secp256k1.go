@@ -0,0 +1,356 @@
+// Package secp256k1 implements ECDSA over the curve secp256k1, with the
+// public-key recovery used by Ethereum.
+//
+// Signatures are the concatenation r||s of two 32-byte big-endian
+// integers.  Signing is deterministic, with nonces derived as in RFC 6979
+// using HMAC-SHA-256, and s is normalized to the lower half of [1, n-1], so
+// that signatures are not malleable.  Accordingly, signatures with a high s
+// are rejected.  Recoverable signatures append a recovery identifier v to
+// r||s, which allows to compute the public key from the signature.
+//
+// The functions of this package take the digest of the message, which is
+// converted to an integer as in SEC 1.  The generic API of Scheme hashes
+// messages with SHA-256.
+//
+// References:
+//   - SEC 1: Elliptic Curve Cryptography, version 2.0.
+//     https://www.secg.org/sec1-v2.pdf
+//   - RFC 6979: Deterministic Usage of DSA and ECDSA.
+//     https://www.rfc-editor.org/rfc/rfc6979
+//   - EIP-191: Signed Data Standard.
+//     https://eips.ethereum.org/EIPS/eip-191
+package secp256k1
+
+import (
+	"crypto"
+	cryptoRand "crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/ecc/secp256k1"
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/sign"
+)
+
+const (
+	// SeedSize is the size of seeds for NewKeyFromSeed.
+	SeedSize = 32
+
+	// PublicKeySize is the size of a packed public key, in the compressed
+	// SEC 1 format.
+	PublicKeySize = secp256k1.CompressedSize
+
+	// PrivateKeySize is the size of a packed private key.
+	PrivateKeySize = secp256k1.ScalarSize
+
+	// SignatureSize is the size of a signature r||s.
+	SignatureSize = 2 * secp256k1.ScalarSize
+
+	// RecoverableSignatureSize is the size of a recoverable signature
+	// r||s||v.
+	RecoverableSignatureSize = SignatureSize + 1
+)
+
+var (
+	// ErrInvalidSignature is returned when recovering the public key from
+	// a malformed signature.
+	ErrInvalidSignature = errors.New("secp256k1: invalid signature")
+
+	// ErrInvalidPrivateKey is returned when unpacking a private key that
+	// is not in [1, n-1].
+	ErrInvalidPrivateKey = errors.New("secp256k1: invalid private key")
+)
+
+// PublicKey is the type of ECDSA secp256k1 public keys.
+type PublicKey struct{ p secp256k1.Point }
+
+// PrivateKey is the type of ECDSA secp256k1 private keys.
+type PrivateKey struct {
+	d  secp256k1.Scalar
+	pk PublicKey
+}
+
+func newPrivateKey(d *secp256k1.Scalar) *PrivateKey {
+	sk := &PrivateKey{d: *d}
+	sk.pk.p.ScalarBaseMult(d)
+	return sk
+}
+
+// GenerateKey generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	var b [PrivateKeySize]byte
+	var d secp256k1.Scalar
+	for {
+		if _, err := io.ReadFull(rand, b[:]); err != nil {
+			return nil, nil, err
+		}
+		if d.SetBytes(b[:]) && !d.IsZero() {
+			break
+		}
+	}
+	sk := newPrivateKey(&d)
+	return &sk.pk, sk, nil
+}
+
+// NewKeyFromSeed derives a public/private key pair from the given seed.
+// The private key is sampled in [1, n-1] by rejection from the output of
+// SHAKE-256 on the seed, as DeriveKey does in package ecdsa.
+func NewKeyFromSeed(seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed[:])
+	var b [PrivateKeySize]byte
+	var d secp256k1.Scalar
+	for {
+		_, _ = h.Read(b[:])
+		if d.SetBytes(b[:]) && !d.IsZero() {
+			break
+		}
+	}
+	sk := newPrivateKey(&d)
+	return &sk.pk, sk
+}
+
+// digestToScalar converts the digest of a message to a scalar, keeping its
+// leftmost 256 bits as in SEC 1.
+func digestToScalar(e *secp256k1.Scalar, digest []byte) {
+	var b [secp256k1.ScalarSize]byte
+	if len(digest) >= len(b) {
+		copy(b[:], digest)
+	} else {
+		copy(b[len(b)-len(digest):], digest)
+	}
+	e.SetBytes(b[:])
+}
+
+// signDigest returns the signature (r, s) of the digest with sk, and the
+// recovery identifier.
+func signDigest(sk *PrivateKey, digest []byte) (r, s secp256k1.Scalar, v byte) {
+	var e, k, kInv secp256k1.Scalar
+	var R secp256k1.Point
+	digestToScalar(&e, digest)
+	g := newNonceGenerator(sk.d.Bytes(), e.Bytes())
+	for {
+		g.next(&k)
+		R.ScalarBaseMult(&k)
+		x, _ := R.XBytes()
+		v = 0
+		if !r.SetBytes(x) {
+			v = 2
+		}
+		if r.IsZero() {
+			continue
+		}
+		if R.IsYOdd() {
+			v |= 1
+		}
+
+		// s = (e + r*d)/k
+		kInv.Inv(&k)
+		s.Mul(&r, &sk.d)
+		s.Add(&s, &e)
+		s.Mul(&s, &kInv)
+		if s.IsZero() {
+			continue
+		}
+		if s.IsHigh() {
+			s.Neg(&s)
+			v ^= 1
+		}
+		return r, s, v
+	}
+}
+
+// SignTo signs the digest of a message and writes the signature r||s
+// into signature.
+//
+// It will panic if signature is not of length SignatureSize.
+func SignTo(sk *PrivateKey, digest []byte, signature []byte) {
+	if len(signature) != SignatureSize {
+		panic("secp256k1: signature must be of SignatureSize bytes")
+	}
+	r, s, _ := signDigest(sk, digest)
+	copy(signature[:32], r.Bytes())
+	copy(signature[32:], s.Bytes())
+}
+
+// SignRecoverable signs the digest of a message and returns the
+// recoverable signature r||s||v, where v is 0 or 1 according to the parity
+// of the y-coordinate of the nonce point.  This is the format returned by
+// Sign in go-ethereum.
+func SignRecoverable(sk *PrivateKey, digest []byte) []byte {
+	r, s, v := signDigest(sk, digest)
+	sig := make([]byte, RecoverableSignatureSize)
+	copy(sig[:32], r.Bytes())
+	copy(sig[32:64], s.Bytes())
+	sig[64] = v
+	return sig
+}
+
+// parseSignature parses r||s and checks that r and s are in [1, n-1],
+// and that s is low.
+func parseSignature(r, s *secp256k1.Scalar, sig []byte) bool {
+	return r.SetBytes(sig[:32]) && !r.IsZero() &&
+		s.SetBytes(sig[32:64]) && !s.IsZero() && !s.IsHigh()
+}
+
+// Verify checks whether the given signature r||s by pk on the digest of a
+// message is valid.  Signatures whose s is high are rejected.
+func Verify(pk *PublicKey, digest, signature []byte) bool {
+	var r, s, e, w, u1, u2 secp256k1.Scalar
+	if len(signature) != SignatureSize || !parseSignature(&r, &s, signature) {
+		return false
+	}
+	digestToScalar(&e, digest)
+	w.Inv(&s)
+	u1.Mul(&e, &w)
+	u2.Mul(&r, &w)
+
+	var R secp256k1.Point
+	R.CombinedMult(&u1, &u2, &pk.p)
+	x, err := R.XBytes()
+	if err != nil {
+		return false
+	}
+	var xr secp256k1.Scalar
+	xr.SetBytes(x)
+	return xr.IsEqual(&r)
+}
+
+// RecoverPublicKey returns the public key that produced the recoverable
+// signature r||s||v on the digest of a message.  The recovery identifier
+// v can be in [0, 3] or, as in Ethereum wallets, in [27, 30].  Signatures
+// whose s is high are rejected.
+//
+// A public key is recovered from any well-formed signature, so the
+// caller must check that it is the expected one.
+func RecoverPublicKey(digest, signature []byte) (*PublicKey, error) {
+	var r, s, e, rInv, u1, u2 secp256k1.Scalar
+	if len(signature) != RecoverableSignatureSize ||
+		!parseSignature(&r, &s, signature) {
+		return nil, ErrInvalidSignature
+	}
+	v := signature[64]
+	if v >= 27 {
+		v -= 27
+	}
+	if v > 3 {
+		return nil, ErrInvalidSignature
+	}
+
+	// Recover the nonce point R from its x-coordinate, which is r or r+n.
+	x := new(big.Int).SetBytes(signature[:32])
+	if v&2 != 0 {
+		x.Add(x, new(big.Int).SetBytes(secp256k1.Order()))
+		if x.BitLen() > 256 {
+			return nil, ErrInvalidSignature
+		}
+	}
+	var enc [secp256k1.CompressedSize]byte
+	enc[0] = 0x02 | v&1
+	x.FillBytes(enc[1:])
+	var R secp256k1.Point
+	if err := R.UnmarshalBinary(enc[:]); err != nil {
+		return nil, ErrInvalidSignature
+	}
+
+	// Q = (s*R - e*G)/r
+	digestToScalar(&e, digest)
+	rInv.Inv(&r)
+	u1.Mul(&e, &rInv)
+	u1.Neg(&u1)
+	u2.Mul(&s, &rInv)
+	pk := new(PublicKey)
+	pk.p.CombinedMult(&u1, &u2, &R)
+	if pk.p.IsIdentity() {
+		return nil, ErrInvalidSignature
+	}
+	return pk, nil
+}
+
+// Public returns the public key corresponding to this private key.
+//
+// Returns a *PublicKey.  The type crypto.PublicKey is used to make
+// PrivateKey implement the crypto.Signer interface.
+func (sk *PrivateKey) Public() crypto.PublicKey {
+	pk := sk.pk
+	return &pk
+}
+
+// Sign signs the given digest of a message, and returns the signature
+// r||s.  The digest must have been computed with opts.HashFunc(), if it is
+// not zero.  rand is ignored, as signing is deterministic.
+//
+// This function is used to make PrivateKey implement the crypto.Signer
+// interface.  Note that the signature is not ASN.1 encoded, unlike that
+// returned by crypto/ecdsa.
+func (sk *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) (
+	signature []byte, err error) {
+	if opts != nil && opts.HashFunc() != crypto.Hash(0) &&
+		len(digest) != opts.HashFunc().Size() {
+		return nil, errors.New("secp256k1: wrong digest size")
+	}
+	sig := make([]byte, SignatureSize)
+	SignTo(sk, digest, sig)
+	return sig, nil
+}
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	castOther, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return sk.d.IsEqual(&castOther.d)
+}
+
+// MarshalBinary returns the private key as a 32-byte big-endian integer.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) { return sk.d.Bytes(), nil }
+
+// UnmarshalBinary sets sk to the private key encoded as a 32-byte
+// big-endian integer, which must be in [1, n-1].
+func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
+	if len(data) != PrivateKeySize {
+		return sign.ErrPrivKeySize
+	}
+	var d secp256k1.Scalar
+	if !d.SetBytes(data) || d.IsZero() {
+		return ErrInvalidPrivateKey
+	}
+	*sk = *newPrivateKey(&d)
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	castOther, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.p.IsEqual(&castOther.p)
+}
+
+// MarshalBinary returns the public key in the compressed SEC 1 format.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return pk.p.MarshalBinaryCompress()
+}
+
+// Uncompressed returns the public key in the uncompressed SEC 1 format,
+// as used by Ethereum.
+func (pk *PublicKey) Uncompressed() []byte {
+	b, err := pk.p.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+// UnmarshalBinary sets pk to the public key encoded in the compressed or
+// uncompressed SEC 1 format.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	return pk.p.UnmarshalBinary(data)
+}
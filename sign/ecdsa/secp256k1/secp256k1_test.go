@@ -0,0 +1,203 @@
+package secp256k1_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	group "github.com/cloudflare/circl/ecc/secp256k1"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ecdsa/secp256k1"
+)
+
+type gethVector struct {
+	PrivateKey          test.HexBytes `json:"privateKey"`
+	PublicKey           test.HexBytes `json:"publicKey"`
+	CompressedPublicKey test.HexBytes `json:"compressedPublicKey"`
+	Address             test.HexBytes `json:"address"`
+	Message             test.HexBytes `json:"message"`
+	Digest              test.HexBytes `json:"digest"`
+	Signature           test.HexBytes `json:"signature"`
+	TextHash            test.HexBytes `json:"textHash"`
+	TextSignature       test.HexBytes `json:"textSignature"`
+}
+
+func readGethVectors(t *testing.T) []gethVector {
+	data, err := test.ReadGzip("testdata/geth.json.gz")
+	test.CheckNoErr(t, err, "read failed")
+	var vs []gethVector
+	test.CheckNoErr(t, json.Unmarshal(data, &vs), "json failed")
+	return vs
+}
+
+func TestGethVectors(t *testing.T) {
+	for _, v := range readGethVectors(t) {
+		var sk secp256k1.PrivateKey
+		test.CheckNoErr(t, sk.UnmarshalBinary(v.PrivateKey), "unmarshal failed")
+		pk := sk.Public().(*secp256k1.PublicKey)
+
+		if got := pk.Uncompressed(); !bytes.Equal(got, v.PublicKey) {
+			test.ReportError(t, got, v.PublicKey, v.PrivateKey)
+		}
+		got, err := pk.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+		if !bytes.Equal(got, v.CompressedPublicKey) {
+			test.ReportError(t, got, v.CompressedPublicKey, v.PrivateKey)
+		}
+		if got := pk.Address(); !bytes.Equal(got, v.Address) {
+			test.ReportError(t, got, v.Address, v.PrivateKey)
+		}
+
+		if got := secp256k1.SignRecoverable(&sk, v.Digest); !bytes.Equal(got, v.Signature) {
+			test.ReportError(t, got, v.Signature, v.PrivateKey, v.Digest)
+		}
+		sig := make([]byte, secp256k1.SignatureSize)
+		secp256k1.SignTo(&sk, v.Digest, sig)
+		test.CheckOk(bytes.Equal(sig, v.Signature[:64]), "SignTo differs", t)
+		test.CheckOk(secp256k1.Verify(pk, v.Digest, sig), "verify failed", t)
+
+		rec, err := secp256k1.RecoverPublicKey(v.Digest, v.Signature)
+		test.CheckNoErr(t, err, "recover failed")
+		test.CheckOk(rec.Equal(pk), "recovered wrong key", t)
+
+		if got := secp256k1.TextHash(v.Message); !bytes.Equal(got, v.TextHash) {
+			test.ReportError(t, got, v.TextHash, v.Message)
+		}
+		if got := secp256k1.SignText(&sk, v.Message); !bytes.Equal(got, v.TextSignature) {
+			test.ReportError(t, got, v.TextSignature, v.PrivateKey, v.Message)
+		}
+		rec, err = secp256k1.RecoverPublicKey(v.TextHash, v.TextSignature)
+		test.CheckNoErr(t, err, "recover failed")
+		test.CheckOk(bytes.Equal(rec.Address(), v.Address), "recovered wrong address", t)
+	}
+}
+
+func TestInvalidSignatures(t *testing.T) {
+	pk, sk, err := secp256k1.GenerateKey(nil)
+	test.CheckNoErr(t, err, "keygen failed")
+	digest := sha256.Sum256([]byte("message"))
+	sig := secp256k1.SignRecoverable(sk, digest[:])
+	test.CheckOk(secp256k1.Verify(pk, digest[:], sig[:64]), "verify failed", t)
+
+	other := sha256.Sum256([]byte("other message"))
+	test.CheckOk(!secp256k1.Verify(pk, other[:], sig[:64]), "wrong digest accepted", t)
+	rec, err := secp256k1.RecoverPublicKey(other[:], sig)
+	test.CheckOk(err != nil || !rec.Equal(pk), "wrong digest recovered the key", t)
+
+	// (r, n-s) is valid but high, and thus rejected.
+	n := new(big.Int).SetBytes(group.Order())
+	s := new(big.Int).SetBytes(sig[32:64])
+	high := append([]byte{}, sig...)
+	new(big.Int).Sub(n, s).FillBytes(high[32:64])
+	high[64] ^= 1
+	test.CheckOk(!secp256k1.Verify(pk, digest[:], high[:64]), "high s accepted", t)
+	_, err = secp256k1.RecoverPublicKey(digest[:], high)
+	test.CheckIsErr(t, err, "high s recovered")
+
+	for _, bad := range [][]byte{
+		make([]byte, secp256k1.RecoverableSignatureSize), // r = s = 0
+		append(n.Bytes(), sig[32:]...),                   // r = n
+	} {
+		_, err = secp256k1.RecoverPublicKey(digest[:], bad)
+		test.CheckIsErr(t, err, "invalid signature recovered")
+		test.CheckOk(!secp256k1.Verify(pk, digest[:], bad[:64]), "invalid signature accepted", t)
+	}
+	for _, bad := range [][]byte{
+		append(append([]byte{}, sig[:64]...), 4),  // v = 4
+		append(append([]byte{}, sig[:64]...), 31), // v = 31
+		sig[:64],
+	} {
+		_, err = secp256k1.RecoverPublicKey(digest[:], bad)
+		test.CheckIsErr(t, err, "invalid recovery identifier accepted")
+	}
+	test.CheckOk(!secp256k1.Verify(pk, digest[:], sig), "wrong length accepted", t)
+}
+
+func TestKeys(t *testing.T) {
+	var sk secp256k1.PrivateKey
+	test.CheckIsErr(t, sk.UnmarshalBinary(make([]byte, 32)), "zero key accepted")
+	test.CheckIsErr(t, sk.UnmarshalBinary(group.Order()), "key n accepted")
+	test.CheckIsErr(t, sk.UnmarshalBinary(make([]byte, 31)), "short key accepted")
+
+	one := make([]byte, secp256k1.PrivateKeySize)
+	one[31] = 1
+	test.CheckNoErr(t, sk.UnmarshalBinary(one), "unmarshal failed")
+	pk := sk.Public().(*secp256k1.PublicKey)
+	want, _ := group.Generator().MarshalBinaryCompress()
+	got, _ := pk.MarshalBinary()
+	test.CheckOk(bytes.Equal(got, want), "1*G is not G", t)
+
+	var pk2 secp256k1.PublicKey
+	test.CheckNoErr(t, pk2.UnmarshalBinary(pk.Uncompressed()), "unmarshal failed")
+	test.CheckOk(pk2.Equal(pk), "uncompressed roundtrip failed", t)
+}
+
+func TestNewKeyFromSeed(t *testing.T) {
+	// The private key is the first 32 bytes of SHAKE-256(seed), which are
+	// smaller than n for these seeds.
+	for _, v := range []struct {
+		seed byte
+		sk   string
+	}{
+		{0, "f5977c8283546a63723bc31d2619124f11db4658643336741df81757d5ad3062"},
+		{1, "45ea778d137bb0bfed7e877c1909d41fcaa0ffefe1ef9b07eae7161f6ad03e9d"},
+	} {
+		var seed [secp256k1.SeedSize]byte
+		seed[31] = v.seed
+		pk, sk := secp256k1.NewKeyFromSeed(&seed)
+		enc, err := sk.MarshalBinary()
+		test.CheckNoErr(t, err, "marshal failed")
+		want, _ := hex.DecodeString(v.sk)
+		if !bytes.Equal(enc, want) {
+			test.ReportError(t, enc, want, v.seed)
+		}
+		test.CheckOk(sk.Public().(*secp256k1.PublicKey).Equal(pk),
+			"public key mismatch", t)
+	}
+}
+
+func TestSigner(t *testing.T) {
+	pk, sk, err := secp256k1.GenerateKey(nil)
+	test.CheckNoErr(t, err, "keygen failed")
+	digest := sha256.Sum256([]byte("message"))
+
+	var signer crypto.Signer = sk
+	sig, err := signer.Sign(nil, digest[:], crypto.SHA256)
+	test.CheckNoErr(t, err, "sign failed")
+	test.CheckOk(secp256k1.Verify(pk, digest[:], sig), "verify failed", t)
+
+	_, err = signer.Sign(nil, digest[:20], crypto.SHA256)
+	test.CheckIsErr(t, err, "wrong digest size accepted")
+}
+
+func BenchmarkSecp256k1(b *testing.B) {
+	_, sk, _ := secp256k1.GenerateKey(nil)
+	pk := sk.Public().(*secp256k1.PublicKey)
+	digest := sha256.Sum256([]byte("message"))
+	sig := secp256k1.SignRecoverable(sk, digest[:])
+
+	b.Run("GenerateKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _, _ = secp256k1.GenerateKey(nil)
+		}
+	})
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = secp256k1.SignRecoverable(sk, digest[:])
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = secp256k1.Verify(pk, digest[:], sig[:64])
+		}
+	})
+	b.Run("RecoverPublicKey", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = secp256k1.RecoverPublicKey(digest[:], sig)
+		}
+	})
+}
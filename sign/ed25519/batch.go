@@ -0,0 +1,145 @@
+package ed25519
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/sha512"
+	"io"
+	"sort"
+)
+
+// batchEntry is a signature parsed for batch verification.
+type batchEntry struct {
+	A, R pointR1      // public key and commitment
+	s, k [paramB]byte // response and challenge
+}
+
+// VerifyBatch returns true if, for every i, signatures[i] is a valid Ed25519
+// signature of messages[i] under publicKeys[i] according to VerifyZIP215.
+// Otherwise, it returns false and the indices, in increasing order, of the
+// entries that failed.
+//
+// All the signatures are checked at once with a single multi-scalar
+// multiplication on a random linear combination of their verification
+// equations. When this check fails, the batch is split in halves that are
+// checked recursively, down to single entries, which are checked as
+// VerifyZIP215 does.
+//
+// Panics if the three slices are not of the same length.
+func VerifyBatch(publicKeys []PublicKey, messages, signatures [][]byte) (ok bool, invalid []int) {
+	if len(messages) != len(publicKeys) || len(signatures) != len(publicKeys) {
+		panic("ed25519: batch slices of different length")
+	}
+
+	entries := make([]batchEntry, 0, len(publicKeys))
+	indices := make([]int, 0, len(publicKeys))
+	for i := range publicKeys {
+		var e batchEntry
+		if e.parse(publicKeys[i], messages[i], signatures[i]) {
+			entries = append(entries, e)
+			indices = append(indices, i)
+		} else {
+			invalid = append(invalid, i)
+		}
+	}
+
+	if failed := findInvalid(entries, indices); len(failed) > 0 {
+		invalid = append(invalid, failed...)
+		sort.Ints(invalid)
+	}
+	return len(invalid) == 0, invalid
+}
+
+// VerifyZIP215 returns true if signature is a valid Ed25519 signature of
+// message under publicKey, following the rules of ZIP-215, as VerifyBatch
+// does: S must be less than the group order, the encodings of A and R may
+// be non-canonical, and the cofactored equation [8][S]B = [8]R + [8][k]A is
+// checked. Unlike Verify, the result does not depend on whether a signature
+// is checked alone or in a batch. Any signature accepted by Verify is
+// accepted by VerifyZIP215, but signatures with non-canonical encodings or
+// small-order components, which Verify rejects, may be accepted.
+func VerifyZIP215(publicKey PublicKey, message, signature []byte) bool {
+	var e batchEntry
+	return e.parse(publicKey, message, signature) &&
+		verifyEntries([]batchEntry{e})
+}
+
+// parse decodes a public key and a signature, and calculates the challenge
+// of the message. It returns false if they cannot be decoded.
+func (e *batchEntry) parse(public PublicKey, message, signature []byte) bool {
+	if len(public) != PublicKeySize ||
+		len(signature) != SignatureSize ||
+		!isLessThanOrder(signature[paramB:]) ||
+		!e.A.fromBytes(public, false) ||
+		!e.R.fromBytes(signature[:paramB], false) {
+		return false
+	}
+	copy(e.s[:], signature[paramB:])
+
+	H := sha512.New()
+	_, _ = H.Write(signature[:paramB])
+	_, _ = H.Write(public)
+	_, _ = H.Write(message)
+	hRAM := H.Sum(nil)
+	reduceModOrder(hRAM[:], true)
+	copy(e.k[:], hRAM[:paramB])
+	return true
+}
+
+// findInvalid returns the indices of the entries failing verification.
+func findInvalid(entries []batchEntry, indices []int) []int {
+	if len(entries) == 0 || verifyEntries(entries) {
+		return nil
+	}
+	if len(entries) == 1 {
+		return indices
+	}
+	h := len(entries) / 2
+	return append(
+		findInvalid(entries[:h], indices[:h]),
+		findInvalid(entries[h:], indices[h:])...,
+	)
+}
+
+// verifyEntries checks that [8](sum z_i (S_i B - R_i - k_i A_i)) is the
+// identity, where z_0 = 1 and z_1, z_2, ... are random 128-bit numbers.
+// For a single entry, this is the verification equation of ZIP-215.
+func verifyEntries(entries []batchEntry) bool {
+	const zSize = 16
+	n := len(entries)
+	r := make([]byte, (n-1)*zSize)
+	if _, err := io.ReadFull(cryptoRand.Reader, r); err != nil {
+		panic(err)
+	}
+
+	var zero, sum [paramB]byte
+	z := make([]byte, n*paramB)
+	zk := make([]byte, n*paramB)
+	points := make([]pointR1, 2*n)
+	scalars := make([][]byte, 2*n)
+	for i := range entries {
+		zi := z[i*paramB : (i+1)*paramB]
+		zki := zk[i*paramB : (i+1)*paramB]
+		if i == 0 {
+			zi[0] = 1
+		} else {
+			copy(zi, r[(i-1)*zSize:i*zSize])
+		}
+		calculateS(sum[:], sum[:], entries[i].s[:], zi)
+		calculateS(zki, zero[:], entries[i].k[:], zi)
+
+		points[2*i] = entries[i].R
+		points[2*i].neg()
+		scalars[2*i] = zi
+		points[2*i+1] = entries[i].A
+		points[2*i+1].neg()
+		scalars[2*i+1] = zki
+	}
+
+	var P, O pointR1
+	P.multiMult(sum[:], points, scalars)
+	P.double()
+	P.double()
+	P.double()
+	O.SetIdentity()
+	return P.isEqual(&O)
+}
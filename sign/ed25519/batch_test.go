@@ -0,0 +1,148 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func batch(t testing.TB, n int) (pubs []ed25519.PublicKey, msgs, sigs [][]byte) {
+	pubs = make([]ed25519.PublicKey, n)
+	msgs = make([][]byte, n)
+	sigs = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+		pubs[i] = pub
+		msgs[i] = []byte(fmt.Sprintf("message %v", i))
+		sigs[i] = ed25519.Sign(priv, msgs[i])
+	}
+	return
+}
+
+func TestVerifyBatch(t *testing.T) {
+	pubs, msgs, sigs := batch(t, 64)
+
+	ok, invalid := ed25519.VerifyBatch(pubs, msgs, sigs)
+	test.CheckOk(ok && invalid == nil, "valid batch rejected", t)
+	ok, invalid = ed25519.VerifyBatch(nil, nil, nil)
+	test.CheckOk(ok && invalid == nil, "empty batch rejected", t)
+
+	msgs[3] = []byte("another message")
+	sigs[17] = append([]byte{}, sigs[17]...)
+	sigs[17][ed25519.SignatureSize-1] ^= 0x40 // S is too large.
+	pubs[40] = pubs[41]
+	sigs[50] = sigs[50][1:]
+	sigs[62], sigs[63] = sigs[63], sigs[62]
+
+	want := []int{3, 17, 40, 50, 62, 63}
+	ok, got := ed25519.VerifyBatch(pubs, msgs, sigs)
+	test.CheckOk(!ok, "invalid batch accepted", t)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		test.ReportError(t, got, want)
+	}
+	for i := range pubs {
+		ok, _ := ed25519.VerifyBatch(pubs[i:i+1], msgs[i:i+1], sigs[i:i+1])
+		test.CheckOk(ok == ed25519.VerifyZIP215(pubs[i], msgs[i], sigs[i]),
+			"batch of one differs from VerifyZIP215", t)
+		test.CheckOk(ok == ed25519.Verify(pubs[i], msgs[i], sigs[i]),
+			"batch of one differs from Verify", t)
+	}
+
+	err := test.CheckPanic(func() { ed25519.VerifyBatch(pubs, msgs[1:], sigs) })
+	test.CheckNoErr(t, err, "lengths mismatch must panic")
+}
+
+func TestVerifyBatchZIP215(t *testing.T) {
+	identity := make([]byte, ed25519.PublicKeySize)
+	identity[0] = 0x01
+	// y = p+1 is a non-canonical encoding of the identity.
+	nonCanonical := make([]byte, ed25519.PublicKeySize)
+	for i := range nonCanonical {
+		nonCanonical[i] = 0xff
+	}
+	nonCanonical[0] = 0xee
+	nonCanonical[ed25519.PublicKeySize-1] = 0x7f
+	// x = 0 with the sign bit set is the identity too.
+	negZero := append([]byte{}, identity...)
+	negZero[ed25519.PublicKeySize-1] |= 0x80
+
+	msg := []byte("message")
+	zeroS := make([]byte, ed25519.SignatureSize-ed25519.PublicKeySize)
+	for _, A := range [][]byte{identity, nonCanonical, negZero} {
+		for _, R := range [][]byte{identity, nonCanonical, negZero} {
+			pub := ed25519.PublicKey(A)
+			sig := append(append([]byte{}, R...), zeroS...)
+			ok, _ := ed25519.VerifyBatch(
+				[]ed25519.PublicKey{pub}, [][]byte{msg}, [][]byte{sig})
+			test.CheckOk(ok, "ZIP-215 signature rejected", t)
+			test.CheckOk(ed25519.VerifyZIP215(pub, msg, sig),
+				"ZIP-215 signature rejected by VerifyZIP215", t)
+
+			got := ed25519.Verify(pub, msg, sig)
+			want := bytes.Equal(A, identity) && bytes.Equal(R, identity)
+			if got != want {
+				test.ReportError(t, got, want, A, R)
+			}
+		}
+	}
+}
+
+// TestVerifyZIP215SmallOrder checks that signatures made of the points of
+// small order are accepted with S = 0, alone and in a batch, as required by
+// ZIP-215.
+func TestVerifyZIP215SmallOrder(t *testing.T) {
+	smallOrder := []string{
+		"0100000000000000000000000000000000000000000000000000000000000000",
+		"ecffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff7f",
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000080",
+		"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac037a",
+		"c7176a703d4dd84fba3c0b760d10670f2a2053fa2c39ccc64ec7fd7792ac03fa",
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc05",
+		"26e8958fc2b227b045c3f489f2ef98f0d5dfac05d3c63339b13802886d53fc85",
+	}
+
+	msg := []byte("Zcash")
+	zeroS := make([]byte, ed25519.SignatureSize-ed25519.PublicKeySize)
+	var pubs []ed25519.PublicKey
+	var msgs, sigs [][]byte
+	for _, A := range smallOrder {
+		for _, R := range smallOrder {
+			pub, _ := hex.DecodeString(A)
+			sig, _ := hex.DecodeString(R)
+			sig = append(sig, zeroS...)
+			if !ed25519.VerifyZIP215(pub, msg, sig) {
+				test.ReportError(t, false, true, A, R)
+			}
+			pubs = append(pubs, pub)
+			msgs = append(msgs, msg)
+			sigs = append(sigs, sig)
+		}
+	}
+	ok, invalid := ed25519.VerifyBatch(pubs, msgs, sigs)
+	test.CheckOk(ok && invalid == nil, "small-order batch rejected", t)
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	for _, n := range []int{8, 64, 256} {
+		pubs, msgs, sigs := batch(b, n)
+		b.Run(fmt.Sprintf("Verify/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range pubs {
+					_ = ed25519.Verify(pubs[j], msgs[j], sigs[j])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("VerifyBatch/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ed25519.VerifyBatch(pubs, msgs, sigs)
+			}
+		})
+	}
+}
@@ -177,3 +177,44 @@ func (P *pointR1) doubleMult(Q *pointR1, m, n []byte) {
 		}
 	}
 }
+
+// multiMult returns P=mG+n[0]Q[0]+...+n[k-1]Q[k-1]. The points Q are
+// overwritten.
+func (P *pointR1) multiMult(m []byte, Q []pointR1, n [][]byte) {
+	nafFix := math.OmegaNAF(conv.BytesLe2BigInt(m), omegaFix)
+	nafVar := make([][]int32, len(Q))
+	TabQ := make([][1 << (omegaVar - 2)]pointR2, len(Q))
+	l := len(nafFix)
+	for i := range Q {
+		nafVar[i] = math.OmegaNAF(conv.BytesLe2BigInt(n[i]), omegaVar)
+		if len(nafVar[i]) > l {
+			l = len(nafVar[i])
+		}
+		Q[i].oddMultiples(TabQ[i][:])
+	}
+
+	P.SetIdentity()
+	for j := l - 1; j >= 0; j-- {
+		P.double()
+		// Generator point
+		if j < len(nafFix) && nafFix[j] != 0 {
+			idxM := absolute(nafFix[j]) >> 1
+			R := tabVerif[idxM]
+			if nafFix[j] < 0 {
+				R.neg()
+			}
+			P.mixAdd(&R)
+		}
+		// Variable input points
+		for i := range nafVar {
+			if j < len(nafVar[i]) && nafVar[i][j] != 0 {
+				idxN := absolute(nafVar[i][j]) >> 1
+				S := TabQ[i][idxN]
+				if nafVar[i][j] < 0 {
+					S.neg()
+				}
+				P.add(&S)
+			}
+		}
+	}
+}
@@ -51,7 +51,12 @@ func (P *pointR1) ToBytes(k []byte) error {
 	return nil
 }
 
-func (P *pointR1) FromBytes(k []byte) bool {
+func (P *pointR1) FromBytes(k []byte) bool { return P.fromBytes(k, true) }
+
+// fromBytes decodes a point. If strict is false, it follows the rules of
+// ZIP-215, accepting non-canonical encodings of y, and x=0 with its sign
+// bit set.
+func (P *pointR1) fromBytes(k []byte, strict bool) bool {
 	if len(k) != paramB {
 		panic("wrong size")
 	}
@@ -60,7 +65,10 @@ func (P *pointR1) FromBytes(k []byte) bool {
 	P.y[fp.Size-1] &= 0x7F
 	p := fp.P()
 	if !isLessThan(P.y[:], p[:]) {
-		return false
+		if strict {
+			return false
+		}
+		fp.Modp(&P.y)
 	}
 
 	one, u, v := &fp.Elt{}, &fp.Elt{}, &fp.Elt{}
@@ -75,7 +83,10 @@ func (P *pointR1) FromBytes(k []byte) bool {
 	}
 	fp.Modp(&P.x) // x = x mod p
 	if fp.IsZero(&P.x) && signX == 1 {
-		return false
+		if strict {
+			return false
+		}
+		signX = 0
 	}
 	if signX != (P.x[0] & 1) {
 		fp.Neg(&P.x, &P.x)
@@ -0,0 +1,107 @@
+package ed25519
+
+import (
+	"crypto"
+	"crypto/sha512"
+	"errors"
+	"hash"
+	"strconv"
+)
+
+// errPhOptions reports options that do not select Ed25519ph.
+var errPhOptions = errors.New("ed25519: options must select Ed25519ph with SHA512 and a context of at most 255 bytes")
+
+// checkPhOptions returns the context of opts if they select Ed25519ph, as
+// they do for PrivateKey.Sign and VerifyAny.
+func checkPhOptions(opts SignerOptions) ([]byte, error) {
+	if opts.Scheme != ED25519Ph || opts.HashFunc() != crypto.SHA512 ||
+		len(opts.Context) > ContextMaxSize {
+		return nil, errPhOptions
+	}
+	return []byte(opts.Context), nil
+}
+
+// SignerPh produces Ed25519ph signatures of the data written to it, so
+// that messages do not need to be held in memory. It implements io.Writer.
+type SignerPh struct {
+	priv PrivateKey
+	ctx  []byte
+	h    hash.Hash
+}
+
+// NewSignerPh returns a SignerPh for the private key and the options opts,
+// which must select the ED25519Ph scheme and the SHA512 hash, as for
+// PrivateKey.Sign.
+// It will panic if len(priv) is not PrivateKeySize.
+func NewSignerPh(priv PrivateKey, opts SignerOptions) (*SignerPh, error) {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	ctx, err := checkPhOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SignerPh{priv: priv, ctx: ctx, h: sha512.New()}, nil
+}
+
+// Write adds more data to the message. It never returns an error.
+func (s *SignerPh) Write(p []byte) (n int, err error) { return s.h.Write(p) }
+
+// Reset discards the data written so far.
+func (s *SignerPh) Reset() { s.h.Reset() }
+
+// Sum appends to b the signature of the data written so far. It does not
+// change the state of s, so more data can be written afterwards.
+func (s *SignerPh) Sum(b []byte) []byte {
+	return s.SumDigest(b, s.h.Sum(nil))
+}
+
+// SumDigest appends to b the signature of a message, given its SHA-512
+// digest computed elsewhere. The data written to s is ignored.
+// It will panic if len(digest) is not sha512.Size.
+func (s *SignerPh) SumDigest(b, digest []byte) []byte {
+	if l := len(digest); l != sha512.Size {
+		panic("ed25519: bad digest length: " + strconv.Itoa(l))
+	}
+	var signature [SignatureSize]byte
+	signPHM(signature[:], s.priv, digest, s.ctx, true)
+	return append(b, signature[:]...)
+}
+
+// VerifierPh verifies Ed25519ph signatures of the data written to it, so
+// that messages do not need to be held in memory. It implements io.Writer.
+type VerifierPh struct {
+	pub PublicKey
+	ctx []byte
+	h   hash.Hash
+}
+
+// NewVerifierPh returns a VerifierPh for the public key and the options
+// opts, which must select the ED25519Ph scheme and the SHA512 hash, as for
+// VerifyAny.
+func NewVerifierPh(pub PublicKey, opts SignerOptions) (*VerifierPh, error) {
+	ctx, err := checkPhOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifierPh{pub: pub, ctx: ctx, h: sha512.New()}, nil
+}
+
+// Write adds more data to the message. It never returns an error.
+func (v *VerifierPh) Write(p []byte) (n int, err error) { return v.h.Write(p) }
+
+// Reset discards the data written so far.
+func (v *VerifierPh) Reset() { v.h.Reset() }
+
+// Verify returns true if signature is valid for the data written so far.
+// It does not change the state of v, so more data can be written afterwards.
+func (v *VerifierPh) Verify(signature []byte) bool {
+	return v.VerifyDigest(v.h.Sum(nil), signature)
+}
+
+// VerifyDigest returns true if signature is valid for a message, given its
+// SHA-512 digest computed elsewhere. The data written to v is ignored.
+func (v *VerifierPh) VerifyDigest(digest, signature []byte) bool {
+	return len(digest) == sha512.Size &&
+		verifyPHM(v.pub, digest, signature, v.ctx, true)
+}
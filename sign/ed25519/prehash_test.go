@@ -0,0 +1,74 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha512"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestSignerPh(t *testing.T) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "keygen failed")
+	msg := make([]byte, 10000)
+	_, _ = rand.Read(msg)
+
+	for _, ctx := range []string{"", "a context string"} {
+		opts := ed25519.SignerOptions{Scheme: ed25519.ED25519Ph, Hash: crypto.SHA512, Context: ctx}
+		s, err := ed25519.NewSignerPh(priv, opts)
+		test.CheckNoErr(t, err, "NewSignerPh failed")
+		v, err := ed25519.NewVerifierPh(pub, opts)
+		test.CheckNoErr(t, err, "NewVerifierPh failed")
+
+		// Writes the message in chunks of 1000 bytes; LimitReader hides the
+		// WriteTo method of the bytes.Reader.
+		_, err = io.CopyBuffer(io.MultiWriter(s, v),
+			io.LimitReader(bytes.NewReader(msg), int64(len(msg))),
+			make([]byte, 1000))
+		test.CheckNoErr(t, err, "write failed")
+
+		want := ed25519.SignPh(priv, msg, ctx)
+		got := s.Sum(nil)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, ctx)
+		}
+		test.CheckOk(bytes.Equal(s.Sum([]byte{}), want), "Sum changed the state", t)
+		test.CheckOk(v.Verify(want), "VerifierPh rejected", t)
+		test.CheckOk(v.Verify(want), "Verify changed the state", t)
+		test.CheckOk(ed25519.VerifyAny(pub, msg, got, opts), "VerifyAny rejected", t)
+
+		digest := sha512.Sum512(msg)
+		test.CheckOk(bytes.Equal(s.SumDigest(nil, digest[:]), want), "SumDigest failed", t)
+		test.CheckOk(v.VerifyDigest(digest[:], want), "VerifyDigest rejected", t)
+		test.CheckOk(!v.VerifyDigest(digest[1:], want), "short digest accepted", t)
+
+		_, _ = v.Write([]byte{0})
+		test.CheckOk(!v.Verify(want), "longer message accepted", t)
+		v.Reset()
+		s.Reset()
+		_, _ = v.Write(msg)
+		test.CheckOk(v.Verify(want), "VerifierPh rejected after Reset", t)
+		test.CheckOk(!v.Verify(ed25519.Sign(priv, msg)), "Ed25519 signature accepted", t)
+		test.CheckOk(bytes.Equal(s.Sum(nil), ed25519.SignPh(priv, nil, ctx)),
+			"Reset failed", t)
+
+		err = test.CheckPanic(func() { s.SumDigest(nil, digest[1:]) })
+		test.CheckNoErr(t, err, "short digest must panic")
+	}
+
+	for _, opts := range []ed25519.SignerOptions{
+		{Scheme: ed25519.ED25519, Hash: crypto.SHA512},
+		{Scheme: ed25519.ED25519Ph},
+		{Scheme: ed25519.ED25519Ph, Hash: crypto.SHA512, Context: string(make([]byte, ed25519.ContextMaxSize+1))},
+	} {
+		_, err = ed25519.NewSignerPh(priv, opts)
+		test.CheckIsErr(t, err, "bad options accepted")
+		_, err = ed25519.NewVerifierPh(pub, opts)
+		test.CheckIsErr(t, err, "bad options accepted")
+	}
+}
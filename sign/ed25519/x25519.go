@@ -0,0 +1,67 @@
+package ed25519
+
+import (
+	"crypto/sha512"
+	"errors"
+	"strconv"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	fp "github.com/cloudflare/circl/math/fp25519"
+)
+
+// errX25519 reports public keys that cannot be converted to X25519.
+var errX25519 = errors.New("ed25519: public key is not a point of prime order")
+
+// ToX25519 returns the X25519 public key corresponding to pub, through the
+// birational map u = (1+y)/(1-y) from edwards25519 to curve25519. This
+// allows a single Ed25519 key pair to be used for X25519 key agreement.
+//
+// It returns an error if pub cannot be decoded or is not a point of prime
+// order L. This is stricter than libsodium's
+// crypto_sign_ed25519_pk_to_curve25519, which only rejects low-order points
+// and points A with [L]A of x-coordinate other than zero, so that version
+// 1.0.18 accepts the point of order 2*L (see testdata/libsodium_x25519.csv).
+func (pub PublicKey) ToX25519() (x25519.Key, error) {
+	var k x25519.Key
+	var P, Q, O pointR1
+	if len(pub) != PublicKeySize || !P.FromBytes(pub) {
+		return k, errX25519
+	}
+
+	// Checks that P is not the identity and that [order]P is the identity,
+	// so P has prime order.
+	var zero [paramB]byte
+	R := P
+	Q.doubleMult(&R, zero[:], order[:])
+	O.SetIdentity()
+	if P.isEqual(&O) || !Q.isEqual(&O) {
+		return k, errX25519
+	}
+
+	one, num, den := &fp.Elt{}, &fp.Elt{}, &fp.Elt{}
+	fp.SetOne(one)
+	fp.Add(num, one, &P.y) // 1+y
+	fp.Sub(den, one, &P.y) // 1-y
+	fp.Inv(den, den)
+	fp.Mul(num, num, den) // u = (1+y)/(1-y)
+	if err := fp.ToBytes(k[:], num); err != nil {
+		return k, err
+	}
+	return k, nil
+}
+
+// ToX25519 returns the X25519 private key corresponding to priv, whose
+// public key is the one returned by the ToX25519 method of priv.Public().
+// It is the clamped secret scalar derived from the seed of priv, as in
+// libsodium's crypto_sign_ed25519_sk_to_curve25519.
+// It will panic if len(priv) is not PrivateKeySize.
+func (priv PrivateKey) ToX25519() x25519.Key {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed25519: bad private key length: " + strconv.Itoa(l))
+	}
+	var k x25519.Key
+	h := sha512.Sum512(priv[:SeedSize])
+	clamp(h[:])
+	copy(k[:], h[:x25519.Size])
+	return k
+}
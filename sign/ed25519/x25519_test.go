@@ -0,0 +1,80 @@
+package ed25519_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/csv"
+	"encoding/hex"
+	"os"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/x25519"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed25519"
+)
+
+func TestToX25519Libsodium(t *testing.T) {
+	f, err := os.Open("testdata/libsodium_x25519.csv")
+	test.CheckNoErr(t, err, "cannot open test vectors")
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.Comment = '#'
+	records, err := r.ReadAll()
+	test.CheckNoErr(t, err, "cannot read test vectors")
+
+	for _, v := range records[1:] {
+		seed, _ := hex.DecodeString(v[0])
+		pub, _ := hex.DecodeString(v[1])
+		wantSk, _ := hex.DecodeString(v[2])
+		wantPk, _ := hex.DecodeString(v[3])
+		valid, comment := v[4] == "true", v[5]
+
+		pk, err := ed25519.PublicKey(pub).ToX25519()
+		if valid {
+			test.CheckNoErr(t, err, "conversion failed: "+comment)
+			if !bytes.Equal(pk[:], wantPk) {
+				test.ReportError(t, pk, wantPk, comment)
+			}
+		} else {
+			test.CheckIsErr(t, err, "invalid key accepted: "+comment)
+		}
+
+		if len(seed) > 0 {
+			priv := ed25519.NewKeyFromSeed(seed)
+			test.CheckOk(bytes.Equal(priv[ed25519.SeedSize:], pub), "wrong public key", t)
+			sk := priv.ToX25519()
+			if !bytes.Equal(sk[:], wantSk) {
+				test.ReportError(t, sk, wantSk, comment)
+			}
+		}
+	}
+}
+
+func TestToX25519(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		pubA, privA, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+		pubB, privB, err := ed25519.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+
+		skA, skB := privA.ToX25519(), privB.ToX25519()
+		pkA, err := pubA.ToX25519()
+		test.CheckNoErr(t, err, "conversion failed")
+		pkB, err := pubB.ToX25519()
+		test.CheckNoErr(t, err, "conversion failed")
+
+		var want, sharedA, sharedB x25519.Key
+		x25519.KeyGen(&want, &skA)
+		if want != pkA {
+			test.ReportError(t, pkA, want, pubA)
+		}
+		test.CheckOk(x25519.Shared(&sharedA, &skA, &pkB), "shared failed", t)
+		test.CheckOk(x25519.Shared(&sharedB, &skB, &pkA), "shared failed", t)
+		test.CheckOk(sharedA == sharedB, "shared secrets differ", t)
+	}
+
+	_, err := ed25519.PublicKey(make([]byte, ed25519.PublicKeySize-1)).ToX25519()
+	test.CheckIsErr(t, err, "short key accepted")
+	err = test.CheckPanic(func() { ed25519.PrivateKey(make([]byte, 10)).ToX25519() })
+	test.CheckNoErr(t, err, "short key must panic")
+}
@@ -0,0 +1,171 @@
+package ed448
+
+import (
+	cryptoRand "crypto/rand"
+	"io"
+	"sort"
+
+	"github.com/cloudflare/circl/ecc/goldilocks"
+	"github.com/cloudflare/circl/internal/sha3"
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+// batchEntry is a signature parsed for batch verification.
+type batchEntry struct {
+	A, R goldilocks.Point  // public key and commitment
+	s, k goldilocks.Scalar // response and challenge
+}
+
+// VerifyBatch returns true if, for every i, signatures[i] is a valid Ed448
+// signature of messages[i] under publicKeys[i] and the context ctx,
+// according to VerifyZIP215. Otherwise, it returns false and the indices, in
+// increasing order, of the entries that failed.
+//
+// All the signatures are checked at once with a single multi-scalar
+// multiplication on a random linear combination of their verification
+// equations. When this check fails, the batch is split in halves that are
+// checked recursively, down to single entries, which are checked as
+// VerifyZIP215 does.
+//
+// Panics if the three slices are not of the same length.
+func VerifyBatch(publicKeys []PublicKey, messages, signatures [][]byte, ctx string) (ok bool, invalid []int) {
+	if len(messages) != len(publicKeys) || len(signatures) != len(publicKeys) {
+		panic("ed448: batch slices of different length")
+	}
+
+	entries := make([]batchEntry, 0, len(publicKeys))
+	indices := make([]int, 0, len(publicKeys))
+	for i := range publicKeys {
+		var e batchEntry
+		if e.parse(publicKeys[i], messages[i], signatures[i], []byte(ctx)) {
+			entries = append(entries, e)
+			indices = append(indices, i)
+		} else {
+			invalid = append(invalid, i)
+		}
+	}
+
+	if failed := findInvalid(entries, indices); len(failed) > 0 {
+		invalid = append(invalid, failed...)
+		sort.Ints(invalid)
+	}
+	return len(invalid) == 0, invalid
+}
+
+// VerifyZIP215 returns true if signature is a valid Ed448 signature of
+// message under publicKey and the context ctx, following the rules that
+// ZIP-215 sets for Ed25519, as VerifyBatch does: S must be less than the
+// group order, the encodings of A and R may be non-canonical, and the
+// cofactored equation [4][S]B = [4]R + [4][k]A is checked. Unlike Verify,
+// the result does not depend on whether a signature is checked alone or in
+// a batch. Any signature accepted by Verify is accepted by VerifyZIP215, but
+// signatures with non-canonical encodings, which Verify rejects, may be
+// accepted.
+func VerifyZIP215(publicKey PublicKey, message, signature []byte, ctx string) bool {
+	var e batchEntry
+	return e.parse(publicKey, message, signature, []byte(ctx)) &&
+		verifyEntries([]batchEntry{e})
+}
+
+// parse decodes a public key and a signature, and calculates the challenge
+// of the message. It returns false if they cannot be decoded.
+func (e *batchEntry) parse(public PublicKey, message, signature, ctx []byte) bool {
+	if len(public) != PublicKeySize ||
+		len(signature) != SignatureSize ||
+		len(ctx) > ContextMaxSize ||
+		!isLessThanOrder(signature[paramB:]) ||
+		!decodeZIP215(&e.A, public) ||
+		!decodeZIP215(&e.R, signature[:paramB]) {
+		return false
+	}
+	e.s.FromBytes(signature[paramB:])
+
+	var hRAM [hashSize]byte
+	H := sha3.NewShake256()
+	writeDom(&H, ctx, false)
+	_, _ = H.Write(signature[:paramB])
+	_, _ = H.Write(public)
+	_, _ = H.Write(message)
+	_, _ = H.Read(hRAM[:])
+	e.k.FromBytes(hRAM[:])
+	return true
+}
+
+// decodeZIP215 decodes a point, accepting non-canonical encodings of y, and
+// x=0 with its sign bit set.
+func decodeZIP215(P *goldilocks.Point, in []byte) bool {
+	var b [paramB]byte
+	var y fp.Elt
+	copy(b[:], in)
+	copy(y[:], b[:fp.Size])
+	fp.Modp(&y)
+	copy(b[:fp.Size], y[:])
+
+	minusOne := fp.P()
+	minusOne[0]--
+	if y == fp.One() || y == minusOne {
+		b[paramB-1] &= 0x7f
+	}
+
+	Q, err := goldilocks.FromBytes(b[:])
+	if err != nil {
+		return false
+	}
+	*P = *Q
+	return true
+}
+
+// findInvalid returns the indices of the entries failing verification.
+func findInvalid(entries []batchEntry, indices []int) []int {
+	if len(entries) == 0 || verifyEntries(entries) {
+		return nil
+	}
+	if len(entries) == 1 {
+		return indices
+	}
+	h := len(entries) / 2
+	return append(
+		findInvalid(entries[:h], indices[:h]),
+		findInvalid(entries[h:], indices[h:])...,
+	)
+}
+
+// verifyEntries checks that [4](sum z_i (S_i B - R_i - k_i A_i)) is the
+// identity, where z_0 = 1 and z_1, z_2, ... are random 128-bit numbers.
+// For a single entry, this is the cofactored verification equation.
+func verifyEntries(entries []batchEntry) bool {
+	const zSize = 16
+	n := len(entries)
+	r := make([]byte, (n-1)*zSize)
+	if _, err := io.ReadFull(cryptoRand.Reader, r); err != nil {
+		panic(err)
+	}
+
+	var sum, zs goldilocks.Scalar
+	z := make([]goldilocks.Scalar, n)
+	zk := make([]goldilocks.Scalar, n)
+	points := make([]goldilocks.Point, 2*n)
+	P := make([]*goldilocks.Point, 2*n)
+	scalars := make([]*goldilocks.Scalar, 2*n)
+	for i := range entries {
+		if i == 0 {
+			z[i][0] = 1
+		} else {
+			z[i].FromBytes(r[(i-1)*zSize : i*zSize])
+		}
+		zs.Mul(&z[i], &entries[i].s)
+		sum.Add(&sum, &zs)
+		zk[i].Mul(&z[i], &entries[i].k)
+
+		points[2*i] = entries[i].R
+		points[2*i].Neg()
+		P[2*i], scalars[2*i] = &points[2*i], &z[i]
+		points[2*i+1] = entries[i].A
+		points[2*i+1].Neg()
+		P[2*i+1], scalars[2*i+1] = &points[2*i+1], &zk[i]
+	}
+
+	// The result is multiplied by 4 through the isogeny used by
+	// MultiCombinedMult, which then clears the small-order components.
+	return goldilocks.Curve{}.MultiCombinedMult(&sum, scalars, P).IsIdentity()
+}
@@ -0,0 +1,151 @@
+package ed448_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+func batch(t testing.TB, n int, ctx string) (pubs []ed448.PublicKey, msgs, sigs [][]byte) {
+	pubs = make([]ed448.PublicKey, n)
+	msgs = make([][]byte, n)
+	sigs = make([][]byte, n)
+	for i := 0; i < n; i++ {
+		pub, priv, err := ed448.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+		pubs[i] = pub
+		msgs[i] = []byte(fmt.Sprintf("message %v", i))
+		sigs[i] = ed448.Sign(priv, msgs[i], ctx)
+	}
+	return
+}
+
+func TestVerifyBatch(t *testing.T) {
+	const ctx = "a context string"
+	pubs, msgs, sigs := batch(t, 64, ctx)
+
+	ok, invalid := ed448.VerifyBatch(pubs, msgs, sigs, ctx)
+	test.CheckOk(ok && invalid == nil, "valid batch rejected", t)
+	ok, _ = ed448.VerifyBatch(pubs, msgs, sigs, "another context")
+	test.CheckOk(!ok, "wrong context accepted", t)
+	ok, invalid = ed448.VerifyBatch(nil, nil, nil, ctx)
+	test.CheckOk(ok && invalid == nil, "empty batch rejected", t)
+
+	msgs[3] = []byte("another message")
+	sigs[17] = append([]byte{}, sigs[17]...)
+	sigs[17][ed448.SignatureSize-2] ^= 0x80 // S is too large.
+	pubs[40] = pubs[41]
+	sigs[50] = sigs[50][1:]
+	sigs[62], sigs[63] = sigs[63], sigs[62]
+
+	want := []int{3, 17, 40, 50, 62, 63}
+	ok, got := ed448.VerifyBatch(pubs, msgs, sigs, ctx)
+	test.CheckOk(!ok, "invalid batch accepted", t)
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		test.ReportError(t, got, want)
+	}
+	for i := range pubs {
+		ok, _ := ed448.VerifyBatch(pubs[i:i+1], msgs[i:i+1], sigs[i:i+1], ctx)
+		test.CheckOk(ok == ed448.VerifyZIP215(pubs[i], msgs[i], sigs[i], ctx),
+			"batch of one differs from VerifyZIP215", t)
+		test.CheckOk(ok == ed448.Verify(pubs[i], msgs[i], sigs[i], ctx),
+			"batch of one differs from Verify", t)
+	}
+
+	err := test.CheckPanic(func() { ed448.VerifyBatch(pubs, msgs[1:], sigs, ctx) })
+	test.CheckNoErr(t, err, "lengths mismatch must panic")
+}
+
+func TestVerifyBatchZIP215(t *testing.T) {
+	identity := make([]byte, ed448.PublicKeySize)
+	identity[0] = 0x01
+	// y = p+1 is a non-canonical encoding of the identity.
+	nonCanonical := make([]byte, ed448.PublicKeySize)
+	for i := 28; i < ed448.PublicKeySize-1; i++ {
+		nonCanonical[i] = 0xff
+	}
+	// x = 0 with the sign bit set is the identity too.
+	negZero := append([]byte{}, identity...)
+	negZero[ed448.PublicKeySize-1] |= 0x80
+
+	const ctx = ""
+	msg := []byte("message")
+	zeroS := make([]byte, ed448.SignatureSize-ed448.PublicKeySize)
+	for _, A := range [][]byte{identity, nonCanonical, negZero} {
+		for _, R := range [][]byte{identity, nonCanonical, negZero} {
+			pub := ed448.PublicKey(A)
+			sig := append(append([]byte{}, R...), zeroS...)
+			ok, _ := ed448.VerifyBatch(
+				[]ed448.PublicKey{pub}, [][]byte{msg}, [][]byte{sig}, ctx)
+			test.CheckOk(ok, "ZIP-215 signature rejected", t)
+			test.CheckOk(ed448.VerifyZIP215(pub, msg, sig, ctx),
+				"ZIP-215 signature rejected by VerifyZIP215", t)
+
+			got := ed448.Verify(pub, msg, sig, ctx)
+			want := bytes.Equal(A, identity) && bytes.Equal(R, identity)
+			if got != want {
+				test.ReportError(t, got, want, A, R)
+			}
+		}
+	}
+}
+
+// TestVerifyZIP215SmallOrder checks that signatures made of the points of
+// small order are accepted with S = 0, alone and in a batch.
+func TestVerifyZIP215SmallOrder(t *testing.T) {
+	identity := make([]byte, ed448.PublicKeySize)
+	identity[0] = 0x01
+	// (0, -1) has order 2.
+	minusOne := make([]byte, ed448.PublicKeySize)
+	for i := 0; i < ed448.PublicKeySize-1; i++ {
+		minusOne[i] = 0xff
+	}
+	minusOne[0], minusOne[28] = 0xfe, 0xfe
+	// (-1, 0) and (1, 0) have order 4.
+	order4 := make([]byte, ed448.PublicKeySize)
+	order4Neg := make([]byte, ed448.PublicKeySize)
+	order4Neg[ed448.PublicKeySize-1] = 0x80
+	smallOrder := [][]byte{identity, minusOne, order4, order4Neg}
+
+	const ctx = ""
+	msg := []byte("message")
+	zeroS := make([]byte, ed448.SignatureSize-ed448.PublicKeySize)
+	var pubs []ed448.PublicKey
+	var msgs, sigs [][]byte
+	for _, A := range smallOrder {
+		for _, R := range smallOrder {
+			sig := append(append([]byte{}, R...), zeroS...)
+			if !ed448.VerifyZIP215(A, msg, sig, ctx) {
+				test.ReportError(t, false, true, A, R)
+			}
+			pubs = append(pubs, A)
+			msgs = append(msgs, msg)
+			sigs = append(sigs, sig)
+		}
+	}
+	ok, invalid := ed448.VerifyBatch(pubs, msgs, sigs, ctx)
+	test.CheckOk(ok && invalid == nil, "small-order batch rejected", t)
+}
+
+func BenchmarkVerifyBatch(b *testing.B) {
+	const ctx = ""
+	for _, n := range []int{8, 64, 256} {
+		pubs, msgs, sigs := batch(b, n, ctx)
+		b.Run(fmt.Sprintf("Verify/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for j := range pubs {
+					_ = ed448.Verify(pubs[j], msgs[j], sigs[j], ctx)
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("VerifyBatch/%v", n), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				_, _ = ed448.VerifyBatch(pubs, msgs, sigs, ctx)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+package ed448
+
+import (
+	"crypto"
+	"errors"
+	"strconv"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// errPhOptions reports options that do not select Ed448ph.
+var errPhOptions = errors.New("ed448: options must select Ed448ph with no hash and a context of at most 255 bytes")
+
+// checkPhOptions returns the context of opts if they select Ed448ph, as
+// they do for PrivateKey.Sign and VerifyAny.
+func checkPhOptions(opts SignerOptions) ([]byte, error) {
+	if opts.Scheme != ED448Ph || opts.HashFunc() != crypto.Hash(0) ||
+		len(opts.Context) > ContextMaxSize {
+		return nil, errPhOptions
+	}
+	return []byte(opts.Context), nil
+}
+
+// SignerPh produces Ed448ph signatures of the data written to it, so that
+// messages do not need to be held in memory. It implements io.Writer.
+type SignerPh struct {
+	priv PrivateKey
+	ctx  []byte
+	h    sha3.State
+}
+
+// NewSignerPh returns a SignerPh for the private key and the options opts,
+// which must select the ED448Ph scheme, as for PrivateKey.Sign.
+// It will panic if len(priv) is not PrivateKeySize.
+func NewSignerPh(priv PrivateKey, opts SignerOptions) (*SignerPh, error) {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed448: bad private key length: " + strconv.Itoa(l))
+	}
+	ctx, err := checkPhOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &SignerPh{priv: priv, ctx: ctx, h: sha3.NewShake256()}, nil
+}
+
+// Write adds more data to the message. It never returns an error.
+func (s *SignerPh) Write(p []byte) (n int, err error) { return s.h.Write(p) }
+
+// Reset discards the data written so far.
+func (s *SignerPh) Reset() { s.h.Reset() }
+
+// Sum appends to b the signature of the data written so far. It does not
+// change the state of s, so more data can be written afterwards.
+func (s *SignerPh) Sum(b []byte) []byte {
+	var digest [DigestSize]byte
+	h := s.h
+	_, _ = h.Read(digest[:])
+	return s.SumDigest(b, digest[:])
+}
+
+// SumDigest appends to b the signature of a message, given its SHAKE256
+// digest of DigestSize bytes computed elsewhere. The data written to s is
+// ignored.
+// It will panic if len(digest) is not DigestSize.
+func (s *SignerPh) SumDigest(b, digest []byte) []byte {
+	if l := len(digest); l != DigestSize {
+		panic("ed448: bad digest length: " + strconv.Itoa(l))
+	}
+	var signature [SignatureSize]byte
+	signPHM(signature[:], s.priv, digest, s.ctx, true)
+	return append(b, signature[:]...)
+}
+
+// VerifierPh verifies Ed448ph signatures of the data written to it, so that
+// messages do not need to be held in memory. It implements io.Writer.
+type VerifierPh struct {
+	pub PublicKey
+	ctx []byte
+	h   sha3.State
+}
+
+// NewVerifierPh returns a VerifierPh for the public key and the options
+// opts, which must select the ED448Ph scheme, as for VerifyAny.
+func NewVerifierPh(pub PublicKey, opts SignerOptions) (*VerifierPh, error) {
+	ctx, err := checkPhOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifierPh{pub: pub, ctx: ctx, h: sha3.NewShake256()}, nil
+}
+
+// Write adds more data to the message. It never returns an error.
+func (v *VerifierPh) Write(p []byte) (n int, err error) { return v.h.Write(p) }
+
+// Reset discards the data written so far.
+func (v *VerifierPh) Reset() { v.h.Reset() }
+
+// Verify returns true if signature is valid for the data written so far.
+// It does not change the state of v, so more data can be written afterwards.
+func (v *VerifierPh) Verify(signature []byte) bool {
+	var digest [DigestSize]byte
+	h := v.h
+	_, _ = h.Read(digest[:])
+	return v.VerifyDigest(digest[:], signature)
+}
+
+// VerifyDigest returns true if signature is valid for a message, given its
+// SHAKE256 digest of DigestSize bytes computed elsewhere. The data written
+// to v is ignored.
+func (v *VerifierPh) VerifyDigest(digest, signature []byte) bool {
+	return len(digest) == DigestSize &&
+		verifyPHM(v.pub, digest, signature, v.ctx, true)
+}
@@ -0,0 +1,75 @@
+package ed448_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"io"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+func TestSignerPh(t *testing.T) {
+	pub, priv, err := ed448.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "keygen failed")
+	msg := make([]byte, 10000)
+	_, _ = rand.Read(msg)
+
+	for _, ctx := range []string{"", "a context string"} {
+		opts := ed448.SignerOptions{Scheme: ed448.ED448Ph, Context: ctx}
+		s, err := ed448.NewSignerPh(priv, opts)
+		test.CheckNoErr(t, err, "NewSignerPh failed")
+		v, err := ed448.NewVerifierPh(pub, opts)
+		test.CheckNoErr(t, err, "NewVerifierPh failed")
+
+		// Writes the message in chunks of 1000 bytes; LimitReader hides the
+		// WriteTo method of the bytes.Reader.
+		_, err = io.CopyBuffer(io.MultiWriter(s, v),
+			io.LimitReader(bytes.NewReader(msg), int64(len(msg))),
+			make([]byte, 1000))
+		test.CheckNoErr(t, err, "write failed")
+
+		want := ed448.SignPh(priv, msg, ctx)
+		got := s.Sum(nil)
+		if !bytes.Equal(got, want) {
+			test.ReportError(t, got, want, ctx)
+		}
+		test.CheckOk(bytes.Equal(s.Sum([]byte{}), want), "Sum changed the state", t)
+		test.CheckOk(v.Verify(want), "VerifierPh rejected", t)
+		test.CheckOk(v.Verify(want), "Verify changed the state", t)
+		test.CheckOk(ed448.VerifyAny(pub, msg, got, opts), "VerifyAny rejected", t)
+
+		var digest [ed448.DigestSize]byte
+		sha3.ShakeSum256(digest[:], msg)
+		test.CheckOk(bytes.Equal(s.SumDigest(nil, digest[:]), want), "SumDigest failed", t)
+		test.CheckOk(v.VerifyDigest(digest[:], want), "VerifyDigest rejected", t)
+		test.CheckOk(!v.VerifyDigest(digest[1:], want), "short digest accepted", t)
+
+		_, _ = v.Write([]byte{0})
+		test.CheckOk(!v.Verify(want), "longer message accepted", t)
+		v.Reset()
+		s.Reset()
+		_, _ = v.Write(msg)
+		test.CheckOk(v.Verify(want), "VerifierPh rejected after Reset", t)
+		test.CheckOk(!v.Verify(ed448.Sign(priv, msg, ctx)), "Ed448 signature accepted", t)
+		test.CheckOk(bytes.Equal(s.Sum(nil), ed448.SignPh(priv, nil, ctx)),
+			"Reset failed", t)
+
+		err = test.CheckPanic(func() { s.SumDigest(nil, digest[1:]) })
+		test.CheckNoErr(t, err, "short digest must panic")
+	}
+
+	for _, opts := range []ed448.SignerOptions{
+		{Scheme: ed448.ED448},
+		{Scheme: ed448.ED448Ph, Hash: crypto.SHA512},
+		{Scheme: ed448.ED448Ph, Context: string(make([]byte, ed448.ContextMaxSize+1))},
+	} {
+		_, err = ed448.NewSignerPh(priv, opts)
+		test.CheckIsErr(t, err, "bad options accepted")
+		_, err = ed448.NewVerifierPh(pub, opts)
+		test.CheckIsErr(t, err, "bad options accepted")
+	}
+}
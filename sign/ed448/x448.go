@@ -0,0 +1,78 @@
+package ed448
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/cloudflare/circl/dh/x448"
+	"github.com/cloudflare/circl/ecc/goldilocks"
+	"github.com/cloudflare/circl/internal/sha3"
+	fp "github.com/cloudflare/circl/math/fp448"
+)
+
+// errX448 reports public keys that cannot be converted to X448.
+var errX448 = errors.New("ed448: public key is not a point of prime order")
+
+// ToX448 returns the X448 public key corresponding to pub, through the map
+// u = y^2/x^2 from edwards448 to curve448 of RFC 7748. This allows a single
+// Ed448 key pair to be used for X448 key agreement.
+//
+// It returns an error if pub cannot be decoded, is a low-order point, or
+// is not in the prime-order subgroup.
+func (pub PublicKey) ToX448() (x448.Key, error) {
+	var k x448.Key
+	if len(pub) != PublicKeySize {
+		return k, errX448
+	}
+	P, err := goldilocks.FromBytes(pub)
+	if err != nil || P.IsIdentity() || !isTorsionFree(P) {
+		return k, errX448
+	}
+
+	x, y := P.ToAffine()
+	u := &fp.Elt{}
+	fp.Sqr(&x, &x) // x^2
+	fp.Sqr(&y, &y) // y^2
+	fp.Inv(&x, &x)
+	fp.Mul(u, &y, &x) // u = y^2/x^2
+	if err := fp.ToBytes(k[:], u); err != nil {
+		return k, err
+	}
+	return k, nil
+}
+
+// isTorsionFree returns true if [order]P is the identity. The scalar
+// multiplications of goldilocks.Curve clear the cofactor, so this uses
+// generic additions instead.
+func isTorsionFree(P *goldilocks.Point) bool {
+	order := goldilocks.Curve{}.Order()
+	Q := goldilocks.Curve{}.Identity()
+	for i := len(order) - 1; i >= 0; i-- {
+		for j := 7; j >= 0; j-- {
+			Q.Double()
+			if (order[i]>>uint(j))&1 == 1 {
+				Q.Add(P)
+			}
+		}
+	}
+	return Q.IsIdentity()
+}
+
+// ToX448 returns the X448 private key corresponding to priv, whose public
+// key is the one returned by the ToX448 method of priv.Public(). It is the
+// clamped secret scalar derived from the seed of priv.
+// It will panic if len(priv) is not PrivateKeySize.
+func (priv PrivateKey) ToX448() x448.Key {
+	if l := len(priv); l != PrivateKeySize {
+		panic("ed448: bad private key length: " + strconv.Itoa(l))
+	}
+	var k x448.Key
+	var h [hashSize]byte
+	H := sha3.NewShake256()
+	_, _ = H.Write(priv[:SeedSize])
+	_, _ = H.Read(h[:])
+	h[0] &= 0xFC
+	h[paramB-2] |= 0x80
+	copy(k[:], h[:x448.Size])
+	return k
+}
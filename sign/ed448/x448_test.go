@@ -0,0 +1,68 @@
+package ed448_test
+
+import (
+	"crypto/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/dh/x448"
+	"github.com/cloudflare/circl/ecc/goldilocks"
+	"github.com/cloudflare/circl/internal/test"
+	fp "github.com/cloudflare/circl/math/fp448"
+	"github.com/cloudflare/circl/sign/ed448"
+)
+
+func TestToX448(t *testing.T) {
+	for i := 0; i < 32; i++ {
+		pubA, privA, err := ed448.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+		pubB, privB, err := ed448.GenerateKey(rand.Reader)
+		test.CheckNoErr(t, err, "keygen failed")
+
+		skA, skB := privA.ToX448(), privB.ToX448()
+		pkA, err := pubA.ToX448()
+		test.CheckNoErr(t, err, "conversion failed")
+		pkB, err := pubB.ToX448()
+		test.CheckNoErr(t, err, "conversion failed")
+
+		var want, sharedA, sharedB x448.Key
+		x448.KeyGen(&want, &skA)
+		if want != pkA {
+			test.ReportError(t, pkA, want, pubA)
+		}
+		test.CheckOk(x448.Shared(&sharedA, &skA, &pkB), "shared failed", t)
+		test.CheckOk(x448.Shared(&sharedB, &skB, &pkA), "shared failed", t)
+		test.CheckOk(sharedA == sharedB, "shared secrets differ", t)
+	}
+
+	// Points of order 1, 2 and 4, and of order 2*L and 4*L.
+	identity := make([]byte, ed448.PublicKeySize)
+	identity[0] = 1
+	minusOne := fp.P()
+	minusOne[0]--
+	order2 := make([]byte, ed448.PublicKeySize)
+	copy(order2, minusOne[:])
+	order4 := make([]byte, ed448.PublicKeySize)
+	invalid := [][]byte{identity, order2, order4}
+
+	pub, _, err := ed448.GenerateKey(rand.Reader)
+	test.CheckNoErr(t, err, "keygen failed")
+	for _, T := range [][]byte{order2, order4} {
+		P, err := goldilocks.FromBytes(pub)
+		test.CheckNoErr(t, err, "decoding failed")
+		Q, err := goldilocks.FromBytes(T)
+		test.CheckNoErr(t, err, "decoding failed")
+		P.Add(Q)
+		enc := make([]byte, ed448.PublicKeySize)
+		test.CheckNoErr(t, P.ToBytes(enc), "encoding failed")
+		invalid = append(invalid, enc)
+	}
+	invalid = append(invalid, pub[1:])
+
+	for _, k := range invalid {
+		_, err := ed448.PublicKey(k).ToX448()
+		test.CheckIsErr(t, err, "invalid key accepted")
+	}
+
+	err = test.CheckPanic(func() { ed448.PrivateKey(make([]byte, 10)).ToX448() })
+	test.CheckNoErr(t, err, "short key must panic")
+}
@@ -0,0 +1,19 @@
+//go:generate go run gen.go
+
+// Package falcon implements the Falcon signature scheme as submitted to
+// round 3 of the NIST PQC competition and described in
+//
+// https://falcon-sign.info/falcon.pdf
+//
+// The two parameter sets are implemented by the subpackages
+//
+//  github.com/cloudflare/circl/sign/falcon/falcon512
+//  github.com/cloudflare/circl/sign/falcon/falcon1024
+//
+// Signing samples from discrete Gaussians with floating-point arithmetic.
+// To run in constant time and to produce the same signatures on all
+// platforms, it is emulated with integer operations.  Signatures are in
+// the padded format of fixed size.
+//
+// Falcon does not support context strings.
+package falcon
@@ -0,0 +1,96 @@
+package falcon
+
+import (
+	"crypto"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign/falcon/falcon512"
+)
+
+func TestSignDeterministic(t *testing.T) {
+	var seed [falcon512.SeedSize]byte
+	pk, sk := falcon512.NewKeyFromSeed(&seed)
+	msg := []byte("message")
+
+	// The same randomness yields the same signature.
+	var sigs [2][falcon512.SignatureSize]byte
+	for i := range sigs {
+		rnd := sha3.NewShake256()
+		err := falcon512.SignTo(sk, msg, &rnd, sigs[i][:])
+		test.CheckNoErr(t, err, "SignTo failed")
+		test.CheckOk(falcon512.Verify(pk, msg, sigs[i][:]), "Verify failed", t)
+	}
+	test.CheckOk(sigs[0] == sigs[1], "signatures differ", t)
+
+	// crypto.Signer.
+	sig, err := sk.Sign(nil, msg, crypto.Hash(0))
+	test.CheckNoErr(t, err, "Sign failed")
+	test.CheckOk(falcon512.Verify(pk, msg, sig), "Verify failed", t)
+	_, err = sk.Sign(nil, msg, crypto.SHA256)
+	test.CheckIsErr(t, err, "Sign should fail on a hashed message")
+}
+
+func TestEncodings(t *testing.T) {
+	var seed [falcon512.SeedSize]byte
+	pk, sk := falcon512.NewKeyFromSeed(&seed)
+	msg := []byte("message")
+	var sig [falcon512.SignatureSize]byte
+	test.CheckNoErr(t, falcon512.SignTo(sk, msg, nil, sig[:]), "SignTo failed")
+
+	// The private key is recovered from f, g and F.
+	var sk2 falcon512.PrivateKey
+	test.CheckNoErr(t, sk2.UnmarshalBinary(sk.Bytes()), "UnmarshalBinary failed")
+	test.CheckOk(sk.Equal(&sk2), "private keys differ", t)
+	test.CheckOk(pk.Equal(sk2.Public()), "public keys differ", t)
+
+	// Values out of range are rejected.
+	bad := pk.Bytes()
+	bad[1], bad[2] = 0xff, 0xfc
+	var pk2 falcon512.PublicKey
+	test.CheckIsErr(t, pk2.UnmarshalBinary(bad), "invalid public key accepted")
+	bad = sk.Bytes()
+	bad[0] ^= 1
+	test.CheckIsErr(t, sk2.UnmarshalBinary(bad), "invalid private key accepted")
+
+	// Signatures must be zero-padded and have the right header.
+	bad = append([]byte{}, sig[:]...)
+	bad[len(bad)-1] = 1
+	test.CheckOk(!falcon512.Verify(pk, msg, bad), "invalid padding accepted", t)
+	bad = append([]byte{}, sig[:]...)
+	bad[0] ^= 1
+	test.CheckOk(!falcon512.Verify(pk, msg, bad), "invalid header accepted", t)
+	test.CheckOk(!falcon512.Verify(pk, msg, sig[:len(sig)-1]), "short signature accepted", t)
+}
+
+func BenchmarkGenerateKey(b *testing.B) {
+	var seed [falcon512.SeedSize]byte
+	for i := 0; i < b.N; i++ {
+		seed[0] = byte(i)
+		_, _ = falcon512.NewKeyFromSeed(&seed)
+	}
+}
+
+func BenchmarkSign(b *testing.B) {
+	var seed [falcon512.SeedSize]byte
+	_, sk := falcon512.NewKeyFromSeed(&seed)
+	msg := []byte("message")
+	var sig [falcon512.SignatureSize]byte
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = falcon512.SignTo(sk, msg, nil, sig[:])
+	}
+}
+
+func BenchmarkVerify(b *testing.B) {
+	var seed [falcon512.SeedSize]byte
+	pk, sk := falcon512.NewKeyFromSeed(&seed)
+	msg := []byte("message")
+	var sig [falcon512.SignatureSize]byte
+	_ = falcon512.SignTo(sk, msg, nil, sig[:])
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = falcon512.Verify(pk, msg, sig[:])
+	}
+}
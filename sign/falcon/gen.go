@@ -0,0 +1,323 @@
+//go:build ignore
+// +build ignore
+
+// Autogenerates the packages of the Falcon parameter sets from templates,
+// and the precomputed tables of the shared implementation.
+package main
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"math"
+	"math/big"
+	"path"
+	"strings"
+	"text/template"
+)
+
+type Mode struct {
+	Name string
+	LogN uint
+	Oid  asn1.ObjectIdentifier
+}
+
+func (m Mode) Pkg() string { return strings.ReplaceAll(strings.ToLower(m.Name), "-", "") }
+func (m Mode) N() int      { return 1 << m.LogN }
+
+// Bit length of the coefficients of f and g in private keys.
+func (m Mode) FgBits() int {
+	if m.LogN == 9 {
+		return 6
+	}
+	return 5
+}
+
+func (m Mode) PublicKeySize() int  { return 1 + 14*m.N()/8 }
+func (m Mode) PrivateKeySize() int { return 1 + 2*m.FgBits()*m.N()/8 + m.N() }
+
+// Size of signatures in the padded format.
+func (m Mode) SignatureSize() int {
+	if m.LogN == 9 {
+		return 666
+	}
+	return 1280
+}
+
+func (m Mode) OidCode() string {
+	return fmt.Sprintf("asn1.ObjectIdentifier{%s}",
+		strings.ReplaceAll(m.Oid.String(), ".", ", "))
+}
+
+var (
+	Modes = []Mode{
+		{
+			Name: "Falcon-512",
+			LogN: 9,
+			Oid:  asn1.ObjectIdentifier{1, 3, 9999, 3, 16},
+		},
+		{
+			Name: "Falcon-1024",
+			LogN: 10,
+			Oid:  asn1.ObjectIdentifier{1, 3, 9999, 3, 19},
+		},
+	}
+	TemplateWarning = "// Code generated from"
+)
+
+func main() {
+	generateModePackageFiles()
+	generateTables()
+}
+
+// Generates falconX/falcon.go from templates/pkg.templ.go
+func generateModePackageFiles() {
+	tl, err := template.ParseFiles("templates/pkg.templ.go")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, mode := range Modes {
+		buf := new(bytes.Buffer)
+		err := tl.Execute(buf, mode)
+		if err != nil {
+			panic(err)
+		}
+
+		res := string(buf.Bytes())
+		offset := strings.Index(res, TemplateWarning)
+		if offset == -1 {
+			panic("Missing template warning in pkg.templ.go")
+		}
+		writeGoFile(path.Join(mode.Pkg(), "falcon.go"), []byte(res[offset:]))
+	}
+}
+
+func writeGoFile(name string, src []byte) {
+	code, err := format.Source(src)
+	if err != nil {
+		panic(fmt.Sprintf("error formating code: %v\n%s", err, src))
+	}
+	if err := ioutil.WriteFile(name, code, 0o644); err != nil {
+		panic(err)
+	}
+}
+
+// Precision in bits of the computations of the tables.
+const prec = 400
+
+func newFloat(x float64) *big.Float { return new(big.Float).SetPrec(prec).SetFloat64(x) }
+
+func parseFloat(s string) *big.Float {
+	x, _, err := big.ParseFloat(s, 10, prec, big.ToNearestEven)
+	if err != nil {
+		panic(err)
+	}
+	return x
+}
+
+var bigPi = parseFloat("3.14159265358979323846264338327950288419716939937510" +
+	"58209749445923078164062862089986280348253421170679")
+
+// Returns exp(-x) for x >= 0, through the Taylor series of exp(x).
+func bigExpNeg(x *big.Float) *big.Float {
+	sum, term := newFloat(1), newFloat(1)
+	eps := new(big.Float).SetMantExp(newFloat(1), -2*prec)
+	for k := 1; term.Cmp(eps) > 0; k++ {
+		term.Mul(term, x)
+		term.Quo(term, newFloat(float64(k)))
+		sum.Add(sum, term)
+	}
+	return sum.Quo(newFloat(1), sum)
+}
+
+// Returns cos(x) and sin(x) for 0 <= x <= pi, through their Taylor series.
+func bigCosSin(x *big.Float) (*big.Float, *big.Float) {
+	c, s := newFloat(0), newFloat(0)
+	term := newFloat(1)
+	for k := 0; k < 200; k++ {
+		// term = x^k/k!
+		if k%2 == 0 {
+			if k%4 == 0 {
+				c.Add(c, term)
+			} else {
+				c.Sub(c, term)
+			}
+		} else {
+			if k%4 == 1 {
+				s.Add(s, term)
+			} else {
+				s.Sub(s, term)
+			}
+		}
+		term.Mul(term, x)
+		term.Quo(term, newFloat(float64(k+1)))
+	}
+	return c, s
+}
+
+// Rounds x to a float64, flushing to zero the values which are only the
+// error on the approximation of pi.
+func roundFloat(x *big.Float) float64 {
+	if x.MantExp(nil) < -prec/2 {
+		return 0
+	}
+	f, _ := x.Float64()
+	return f
+}
+
+func bitRev10(k int) int {
+	r := 0
+	for i := 0; i < 10; i++ {
+		r |= ((k >> uint(i)) & 1) << uint(9-i)
+	}
+	return r
+}
+
+// Returns the cumulative distribution table {round(2^bits P(|z| > k))}_k of
+// the discrete Gaussian over Z of standard deviation sigma.  The table is
+// truncated at the first zero entry.
+func cdt(sigma *big.Float, bits uint) []*big.Int {
+	const tail = 64
+	twoS2 := new(big.Float).SetPrec(prec).Mul(sigma, sigma)
+	twoS2.Mul(twoS2, newFloat(2))
+	rho := make([]*big.Float, tail)
+	total := newFloat(0)
+	for z := 0; z < tail; z++ {
+		a := newFloat(float64(z * z))
+		a.Quo(a, twoS2)
+		rho[z] = bigExpNeg(a)
+		if z > 0 {
+			rho[z].Mul(rho[z], newFloat(2))
+		}
+		total.Add(total, rho[z])
+	}
+	scale := new(big.Float).SetMantExp(newFloat(1), int(bits))
+	var ret []*big.Int
+	for k := 0; ; k++ {
+		above := newFloat(0)
+		for z := k + 1; z < tail; z++ {
+			above.Add(above, rho[z])
+		}
+		above.Quo(above, total)
+		above.Mul(above, scale)
+		above.Add(above, newFloat(0.5))
+		v, _ := above.Int(nil)
+		if v.Sign() == 0 {
+			return ret
+		}
+		ret = append(ret, v)
+	}
+}
+
+// The reverse cumulative distribution table of the base sampler, as given
+// in Table 3.1 of the specification.  It is not recomputed with cdt, as the
+// values of the specification are a few units off in the last place.
+var rcdtSpec = []string{
+	"3024686241123004913666",
+	"1564742784480091954050",
+	"636254429462080897535",
+	"199560484645026482916",
+	"47667343854657281903",
+	"8595902006365044063",
+	"1163297957344668388",
+	"117656387352093658",
+	"8867391802663976",
+	"496969357462633",
+	"20680885154299",
+	"638331848991",
+	"14602316184",
+	"247426747",
+	"3104126",
+	"28824",
+	"198",
+	"1",
+}
+
+// Generates internal/tables.go.
+func generateTables() {
+	w := new(bytes.Buffer)
+	fmt.Fprint(w, "// Code generated by gen.go. DO NOT EDIT.\n\n")
+	fmt.Fprint(w, "package internal\n\n")
+
+	fmt.Fprint(w, "// gmTab[2k] and gmTab[2k+1] are the real and imaginary parts of\n")
+	fmt.Fprint(w, "// exp(i*pi*brv(k)/1024), where brv reverses the order of 10 bits.\n")
+	fmt.Fprint(w, "var gmTab = [2048]fpr{\n")
+	for k := 0; k < 1024; k++ {
+		x := new(big.Float).SetPrec(prec).Mul(bigPi, newFloat(float64(bitRev10(k))))
+		x.Quo(x, newFloat(1024))
+		c, s := bigCosSin(x)
+		cf, sf := roundFloat(c), roundFloat(s)
+		fmt.Fprintf(w, "\t%#016x, %#016x,\n", math.Float64bits(cf), math.Float64bits(sf))
+	}
+	fmt.Fprint(w, "}\n\n")
+
+	fmt.Fprint(w, "// rcdt[k] = {hi, lo} is round(2^72 P(z > k)) for the half Gaussian\n")
+	fmt.Fprint(w, "// of standard deviation sigma_0 = 1.8205 used by the base sampler.\n")
+	fmt.Fprint(w, "var rcdt = [...][2]uint64{\n")
+	mask64 := new(big.Int).SetUint64(math.MaxUint64)
+	for _, d := range rcdtSpec {
+		v, _ := new(big.Int).SetString(d, 10)
+		hi := new(big.Int).Rsh(v, 64).Uint64()
+		lo := new(big.Int).And(v, mask64).Uint64()
+		fmt.Fprintf(w, "\t{%d, %#016x}, // %s\n", hi, lo, v)
+	}
+	fmt.Fprint(w, "}\n\n")
+
+	// sigma_{f,g} for n = 1024; smaller degrees sum several samples.
+	sfg := new(big.Float).SetPrec(prec).Quo(newFloat(12289), newFloat(2048))
+	sfg.Sqrt(sfg)
+	sfg.Mul(sfg, parseFloat("1.17"))
+	fmt.Fprint(w, "// gaussFG[k] is round(2^63 P(|z| > k)) for the discrete Gaussian over Z\n")
+	fmt.Fprint(w, "// of standard deviation 1.17*sqrt(q/2048) used to sample f and g.\n")
+	fmt.Fprint(w, "var gaussFG = [...]uint64{\n")
+	for _, v := range cdt(sfg, 63) {
+		fmt.Fprintf(w, "\t%d,\n", v.Uint64())
+	}
+	fmt.Fprint(w, "}\n\n")
+
+	// See Section 2.6 of the specification: sigma_min and sigma of the
+	// sampler, where eps = 1/sqrt(Q_s*lambda) with Q_s = 2^64 signatures.
+	var sigmaMin, sigma [11]uint64
+	for logn := uint(9); logn <= 10; logn++ {
+		n := float64(uint(1) << logn)
+		lambda := 128.0 * (n / 512)
+		eps := 1 / math.Sqrt(math.Ldexp(lambda, 64))
+		smin := math.Sqrt(math.Log(4*n*(1+1/eps))/2) / math.Pi
+		sigmaMin[logn] = math.Float64bits(smin)
+		sigma[logn] = math.Float64bits(smin * 1.17 * math.Sqrt(12289))
+	}
+	fmt.Fprint(w, "// Inverse of the standard deviation of the sampler, and minimal standard\n")
+	fmt.Fprint(w, "// deviation, indexed by logn.\n")
+	fmt.Fprint(w, "var (\n")
+	fmt.Fprint(w, "\tfprInvSigma = [11]fpr{\n")
+	for logn := 9; logn <= 10; logn++ {
+		v := 1 / math.Float64frombits(sigma[logn])
+		fmt.Fprintf(w, "\t\t%d: %#016x, // %v\n", logn, math.Float64bits(v), v)
+	}
+	fmt.Fprint(w, "\t}\n")
+	fmt.Fprint(w, "\tfprSigmaMin = [11]fpr{\n")
+	for logn := 9; logn <= 10; logn++ {
+		fmt.Fprintf(w, "\t\t%d: %#016x, // %v\n", logn, sigmaMin[logn],
+			math.Float64frombits(sigmaMin[logn]))
+	}
+	fmt.Fprint(w, "\t}\n")
+	fmt.Fprint(w, ")\n\n")
+
+	// Powers of g = 7, a primitive 2048th root of unity modulo q.
+	fmt.Fprint(w, "// zetas[k] is g^brv(k) mod q, where g = 7 is a primitive 2048th root of\n")
+	fmt.Fprint(w, "// unity modulo q, and brv reverses the order of 10 bits.\n")
+	fmt.Fprint(w, "var zetas = [1024]uint16{\n")
+	for k := 0; k < 1024; k++ {
+		z := new(big.Int).Exp(big.NewInt(7), big.NewInt(int64(bitRev10(k))), big.NewInt(12289))
+		fmt.Fprintf(w, "%d,", z.Int64())
+		if k%16 == 15 {
+			fmt.Fprint(w, "\n")
+		}
+	}
+	fmt.Fprint(w, "}\n")
+
+	writeGoFile("internal/tables.go", w.Bytes())
+}
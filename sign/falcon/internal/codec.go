@@ -0,0 +1,180 @@
+package internal
+
+// modqEncode packs the coefficients of h, which are in [0, Q), on 14 bits
+// each, most significant bits first.
+func modqEncode(out []byte, h []uint16) {
+	acc, accLen, v := uint32(0), uint(0), 0
+	for _, x := range h {
+		acc = acc<<14 | uint32(x)
+		accLen += 14
+		for accLen >= 8 {
+			accLen -= 8
+			out[v] = byte(acc >> accLen)
+			v++
+		}
+	}
+	if accLen > 0 {
+		out[v] = byte(acc << (8 - accLen))
+	}
+}
+
+// modqDecode is the inverse of modqEncode.  It returns false if a value is
+// not in [0, Q), or if the unused bits of the last byte are not zero.
+func modqDecode(h []uint16, in []byte) bool {
+	acc, accLen, u := uint32(0), uint(0), 0
+	for _, b := range in {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		if accLen >= 14 {
+			accLen -= 14
+			w := (acc >> accLen) & 0x3FFF
+			if w >= Q || u >= len(h) {
+				return false
+			}
+			h[u] = uint16(w)
+			u++
+		}
+	}
+	return u == len(h) && acc&(1<<accLen-1) == 0
+}
+
+// trimEncode packs the coefficients of f, which are in
+// (-2^(bits-1), 2^(bits-1)), on the given number of bits each, in two's
+// complement, most significant bits first.
+func trimEncode(out []byte, f []int8, bits uint) {
+	acc, accLen, v := uint32(0), uint(0), 0
+	mask := uint32(1)<<bits - 1
+	for _, x := range f {
+		acc = acc<<bits | uint32(x)&mask
+		accLen += bits
+		for accLen >= 8 {
+			accLen -= 8
+			out[v] = byte(acc >> accLen)
+			v++
+		}
+	}
+	if accLen > 0 {
+		out[v] = byte(acc << (8 - accLen))
+	}
+}
+
+// trimDecode is the inverse of trimEncode.  It returns false if a value is
+// -2^(bits-1), or if the unused bits of the last byte are not zero.
+func trimDecode(f []int8, in []byte, bits uint) bool {
+	acc, accLen, u := uint32(0), uint(0), 0
+	mask1 := uint32(1)<<bits - 1
+	mask2 := uint32(1) << (bits - 1)
+	for _, b := range in {
+		acc = acc<<8 | uint32(b)
+		accLen += 8
+		for accLen >= bits && u < len(f) {
+			accLen -= bits
+			w := (acc >> accLen) & mask1
+			w |= -(w & mask2)
+			if w == -mask2 {
+				return false
+			}
+			f[u] = int8(int32(w))
+			u++
+		}
+	}
+	return u == len(f) && acc&(1<<accLen-1) == 0
+}
+
+// compEncode writes the compressed encoding of s to out, and returns the
+// number of bytes written, or false if out is too short or a coefficient
+// is not in [-2047, 2047].
+//
+// Each coefficient is encoded as its sign bit, its 7 low bits, and its
+// remaining bits in unary: a 1 preceded by as many 0 as their value.
+func compEncode(out []byte, s []int16) (int, bool) {
+	for _, x := range s {
+		if x < -2047 || x > 2047 {
+			return 0, false
+		}
+	}
+
+	acc, accLen, v := uint32(0), uint(0), 0
+	for _, x := range s {
+		acc <<= 1
+		t := int32(x)
+		if t < 0 {
+			t = -t
+			acc |= 1
+		}
+		w := uint32(t)
+		acc = acc<<7 | w&127
+		w >>= 7
+		accLen += 8
+		acc <<= w + 1
+		acc |= 1
+		accLen += uint(w) + 1
+		for accLen >= 8 {
+			accLen -= 8
+			if v >= len(out) {
+				return 0, false
+			}
+			out[v] = byte(acc >> accLen)
+			v++
+		}
+	}
+	if accLen > 0 {
+		if v >= len(out) {
+			return 0, false
+		}
+		out[v] = byte(acc << (8 - accLen))
+		v++
+	}
+	return v, true
+}
+
+// compDecode is the inverse of compEncode.  It returns the number of bytes
+// read, or false if the encoding is not canonical: "-0" is rejected, as are
+// non-zero unused bits in the last byte.
+func compDecode(s []int16, in []byte) (int, bool) {
+	acc, accLen, v := uint32(0), uint(0), 0
+	for u := range s {
+		// Sign bit and low bits.
+		if v >= len(in) {
+			return 0, false
+		}
+		acc = acc<<8 | uint32(in[v])
+		v++
+		b := acc >> accLen
+		neg := b & 128
+		m := b & 127
+
+		// Unary high bits.
+		for {
+			if accLen == 0 {
+				if v >= len(in) {
+					return 0, false
+				}
+				acc = acc<<8 | uint32(in[v])
+				v++
+				accLen = 8
+			}
+			accLen--
+			if (acc>>accLen)&1 != 0 {
+				break
+			}
+			m += 128
+			if m > 2047 {
+				return 0, false
+			}
+		}
+
+		if neg != 0 && m == 0 {
+			return 0, false
+		}
+		if neg != 0 {
+			s[u] = -int16(m)
+		} else {
+			s[u] = int16(m)
+		}
+	}
+	if acc&(1<<accLen-1) != 0 {
+		return 0, false
+	}
+	return v, true
+}
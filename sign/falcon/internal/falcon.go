@@ -0,0 +1,426 @@
+// Package internal implements the Falcon signature scheme for all degrees,
+// with the floating-point arithmetic emulated in constant time.
+package internal
+
+import (
+	cryptoRand "crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"io"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+const (
+	// Size of seed for NewKeyFromSeed.
+	SeedSize = 48
+
+	// Size of the nonce hashed with the message.
+	NonceSize = 40
+
+	// Size of the seed of the sampler, expanded with SHAKE256.
+	samplerSeedSize = 48
+)
+
+// Bound on the squared norm of signatures, indexed by logn.
+var l2bound = [11]uint32{
+	0, 101498, 208714, 428865, 892039, 1852696,
+	3842630, 7959734, 16468416, 34034726, 70265242,
+}
+
+// Size of signatures in the padded format, indexed by logn.
+var paddedSignatureSize = [11]int{
+	0, 44, 47, 52, 63, 82, 122, 200, 356, 666, 1280,
+}
+
+// PublicKeySize returns the size of a packed public key of degree 2^logn.
+func PublicKeySize(logn uint) int { return 1 + (14<<logn+7)/8 }
+
+// PrivateKeySize returns the size of a packed private key of degree 2^logn.
+func PrivateKeySize(logn uint) int {
+	return 1 + int(2*maxFgBits[logn]<<logn+7)/8 + (maxFGBits<<logn+7)/8
+}
+
+// SignatureSize returns the size of a signature of degree 2^logn.
+func SignatureSize(logn uint) int { return paddedSignatureSize[logn] }
+
+// PublicKey is the type of Falcon public keys.
+type PublicKey struct {
+	logn uint
+	h    []uint16
+}
+
+// PrivateKey is the type of Falcon private keys.
+type PrivateKey struct {
+	logn       uint
+	f, g, F, G []int8
+	pk         PublicKey
+
+	// Basis [[g, -f], [G, -F]] and its normalized LDL tree, in FFT form.
+	b00, b01, b10, b11, tree []fpr
+}
+
+// GenerateKey generates a public/private key pair of degree 2^logn using
+// entropy from rand.  If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(logn uint, rand io.Reader) (*PublicKey, *PrivateKey, error) {
+	var seed [SeedSize]byte
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	_, err := io.ReadFull(rand, seed[:])
+	if err != nil {
+		return nil, nil, err
+	}
+	pk, sk := NewKeyFromSeed(logn, &seed)
+	return pk, sk, nil
+}
+
+// NewKeyFromSeed derives a public/private key pair of degree 2^logn using
+// the given seed.
+func NewKeyFromSeed(logn uint, seed *[SeedSize]byte) (*PublicKey, *PrivateKey) {
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed[:])
+	f, g, F, G, pkh := newKeyFromSeed(&h, logn)
+
+	sk := &PrivateKey{
+		logn: logn,
+		f:    f,
+		g:    g,
+		F:    F,
+		G:    G,
+		pk:   PublicKey{logn: logn, h: pkh},
+	}
+	sk.expand()
+	return sk.Public(), sk
+}
+
+// Public returns the public key corresponding to sk.
+func (sk *PrivateKey) Public() *PublicKey {
+	pk := sk.pk
+	return &pk
+}
+
+// expand computes the basis and the LDL tree used to sign.
+func (sk *PrivateKey) expand() {
+	logn := sk.logn
+	n := 1 << logn
+	ek := make([]fpr, 4*n+treeSize(logn))
+	sk.b00, sk.b01, sk.b10, sk.b11 = ek[:n], ek[n:2*n], ek[2*n:3*n], ek[3*n:4*n]
+	sk.tree = ek[4*n:]
+	for u := 0; u < n; u++ {
+		sk.b00[u] = fprOf(int64(sk.g[u]))
+		sk.b01[u] = fprOf(int64(sk.f[u]))
+		sk.b10[u] = fprOf(int64(sk.G[u]))
+		sk.b11[u] = fprOf(int64(sk.F[u]))
+	}
+	fft(sk.b00, logn)
+	fft(sk.b01, logn)
+	fft(sk.b10, logn)
+	fft(sk.b11, logn)
+	polyNeg(sk.b01, logn)
+	polyNeg(sk.b11, logn)
+
+	// Gram matrix of the basis.
+	tmp := make([]fpr, 7*n)
+	g00, g01, g11, gxx := tmp[:n], tmp[n:2*n], tmp[2*n:3*n], tmp[3*n:4*n]
+	copy(g00, sk.b00)
+	polyMulSelfAdjFFT(g00, logn)
+	copy(gxx, sk.b01)
+	polyMulSelfAdjFFT(gxx, logn)
+	polyAdd(g00, gxx, logn)
+
+	copy(g01, sk.b00)
+	polyMulAdjFFT(g01, sk.b10, logn)
+	copy(gxx, sk.b01)
+	polyMulAdjFFT(gxx, sk.b11, logn)
+	polyAdd(g01, gxx, logn)
+
+	copy(g11, sk.b10)
+	polyMulSelfAdjFFT(g11, logn)
+	copy(gxx, sk.b11)
+	polyMulSelfAdjFFT(gxx, logn)
+	polyAdd(g11, gxx, logn)
+
+	ffLDL(sk.tree, g00, g01, g11, tmp[3*n:], logn)
+	ffLDLNormalize(sk.tree, logn, logn)
+}
+
+// hashToPoint returns the hash of the nonce and message as a polynomial
+// modulo Q.
+func hashToPoint(nonce, msg []byte, logn uint) []uint16 {
+	n := 1 << logn
+	c := make([]uint16, n)
+	h := sha3.NewShake256()
+	_, _ = h.Write(nonce)
+	_, _ = h.Write(msg)
+	var buf [2]byte
+	for u := 0; u < n; {
+		_, _ = h.Read(buf[:])
+		w := uint32(buf[0])<<8 | uint32(buf[1])
+		if w < 5*Q {
+			c[u] = uint16(w % Q)
+			u++
+		}
+	}
+	return c
+}
+
+// isShort returns whether the squared norm of (s1, s2) is acceptable.
+func isShort(s1, s2 []int16, logn uint) bool {
+	sqn := uint64(0)
+	for u := range s1 {
+		sqn += uint64(int64(s1[u]) * int64(s1[u]))
+		sqn += uint64(int64(s2[u]) * int64(s2[u]))
+	}
+	return sqn <= uint64(l2bound[logn])
+}
+
+// signTarget returns s2 such that (c - s2*h, s2) is short, or false if the
+// sampled vector is too long.
+func (sk *PrivateKey) signTarget(s *sampler, c []uint16) ([]int16, bool) {
+	logn := sk.logn
+	n := 1 << logn
+	tmp := make([]fpr, 6*n)
+	t0, t1, tx, ty := tmp[:n], tmp[n:2*n], tmp[2*n:3*n], tmp[3*n:4*n]
+
+	// Target vector: (c, 0)*B^-1 = (-c*F, c*f)/q.
+	for u := 0; u < n; u++ {
+		t0[u] = fprOf(int64(c[u]))
+	}
+	fft(t0, logn)
+	copy(t1, t0)
+	polyMulFFT(t1, sk.b01, logn)
+	polyMulConst(t1, fprNeg(fprInvQ), logn)
+	polyMulFFT(t0, sk.b11, logn)
+	polyMulConst(t0, fprInvQ, logn)
+
+	s.ffSampling(tx, ty, sk.tree, t0, t1, tmp[4*n:], logn)
+
+	// Lattice point (tx, ty)*B.
+	copy(t0, tx)
+	copy(t1, ty)
+	polyMulFFT(tx, sk.b00, logn)
+	polyMulFFT(ty, sk.b10, logn)
+	polyAdd(tx, ty, logn)
+	copy(ty, t0)
+	polyMulFFT(ty, sk.b01, logn)
+	copy(t0, tx)
+	polyMulFFT(t1, sk.b11, logn)
+	polyAdd(t1, ty, logn)
+	ifft(t0, logn)
+	ifft(t1, logn)
+
+	// The signature is the difference with (c, 0).
+	s1 := make([]int16, n)
+	s2 := make([]int16, n)
+	for u := 0; u < n; u++ {
+		s1[u] = int16(int64(c[u]) - fprRint(t0[u]))
+		s2[u] = int16(-fprRint(t1[u]))
+	}
+	return s2, isShort(s1, s2, logn)
+}
+
+// SignTo signs the given message and writes the signature into signature.
+// The randomness of the nonce and the sampler is read from rand, which must
+// not be nil.
+func SignTo(sk *PrivateKey, msg []byte, rand io.Reader, signature []byte) error {
+	logn := sk.logn
+	if len(signature) != SignatureSize(logn) {
+		return errors.New("falcon: wrong signature buffer size")
+	}
+	var seed [samplerSeedSize]byte
+	s := sampler{sigmaMin: fprSigmaMin[logn]}
+
+	for {
+		nonce := signature[1 : 1+NonceSize]
+		if _, err := io.ReadFull(rand, nonce); err != nil {
+			return err
+		}
+		if _, err := io.ReadFull(rand, seed[:]); err != nil {
+			return err
+		}
+		c := hashToPoint(nonce, msg, logn)
+		rng := sha3.NewShake256()
+		_, _ = rng.Write(seed[:])
+
+		for {
+			// Each attempt reseeds the sampler from rng.
+			s.p.init(&rng)
+			s2, ok := sk.signTarget(&s, c)
+			if !ok {
+				continue
+			}
+
+			// Retry with another nonce if the signature does not fit.
+			body := signature[1+NonceSize:]
+			if v, ok := compEncode(body, s2); ok {
+				signature[0] = 0x30 + byte(logn)
+				for i := v; i < len(body); i++ {
+					body[i] = 0
+				}
+				return nil
+			}
+			break
+		}
+	}
+}
+
+// Verify checks whether the given signature by pk on msg is valid.
+func Verify(pk *PublicKey, msg []byte, signature []byte) bool {
+	logn := pk.logn
+	n := 1 << logn
+	if pk.h == nil || len(signature) != SignatureSize(logn) ||
+		signature[0] != 0x30+byte(logn) {
+		return false
+	}
+	s2 := make([]int16, n)
+	body := signature[1+NonceSize:]
+	v, ok := compDecode(s2, body)
+	if !ok {
+		return false
+	}
+	for _, b := range body[v:] {
+		if b != 0 {
+			return false
+		}
+	}
+	c := hashToPoint(signature[1:1+NonceSize], msg, logn)
+
+	// s1 = c - s2*h mod q.
+	ts := make([]uint32, n)
+	th := make([]uint32, n)
+	for u := 0; u < n; u++ {
+		ts[u] = modQ(int32(s2[u]))
+		th[u] = uint32(pk.h[u])
+	}
+	ntt(ts, logn)
+	ntt(th, logn)
+	for u := 0; u < n; u++ {
+		ts[u] = ts[u] * th[u] % Q
+	}
+	invNTT(ts, logn)
+	s1 := make([]int16, n)
+	for u := 0; u < n; u++ {
+		s1[u] = int16(center((uint32(c[u]) + Q - ts[u]) % Q))
+	}
+	return isShort(s1, s2, logn)
+}
+
+// Pack packs the public key into buf.
+func (pk *PublicKey) Pack(buf []byte) {
+	buf[0] = byte(pk.logn)
+	modqEncode(buf[1:PublicKeySize(pk.logn)], pk.h)
+}
+
+// Unpack sets pk to the public key of degree 2^logn encoded in buf.
+func (pk *PublicKey) Unpack(logn uint, buf []byte) error {
+	if len(buf) != PublicKeySize(logn) {
+		return errors.New("falcon: wrong public key size")
+	}
+	if buf[0] != byte(logn) {
+		return errors.New("falcon: wrong public key header")
+	}
+	h := make([]uint16, 1<<logn)
+	if !modqDecode(h, buf[1:]) {
+		return errors.New("falcon: invalid public key")
+	}
+	pk.logn = logn
+	pk.h = h
+	return nil
+}
+
+// Pack packs the private key into buf.
+func (sk *PrivateKey) Pack(buf []byte) {
+	logn := sk.logn
+	buf[0] = 0x50 + byte(logn)
+	fgLen := int(maxFgBits[logn]<<logn) / 8
+	trimEncode(buf[1:1+fgLen], sk.f, maxFgBits[logn])
+	trimEncode(buf[1+fgLen:1+2*fgLen], sk.g, maxFgBits[logn])
+	trimEncode(buf[1+2*fgLen:PrivateKeySize(logn)], sk.F, maxFGBits)
+}
+
+// Unpack sets sk to the private key of degree 2^logn encoded in buf.  The
+// polynomial G and the public key are recomputed from f, g and F.
+func (sk *PrivateKey) Unpack(logn uint, buf []byte) error {
+	if len(buf) != PrivateKeySize(logn) {
+		return errors.New("falcon: wrong private key size")
+	}
+	if buf[0] != 0x50+byte(logn) {
+		return errors.New("falcon: wrong private key header")
+	}
+	n := 1 << logn
+	f := make([]int8, n)
+	g := make([]int8, n)
+	F := make([]int8, n)
+	fgLen := int(maxFgBits[logn]<<logn) / 8
+	if !trimDecode(f, buf[1:1+fgLen], maxFgBits[logn]) ||
+		!trimDecode(g, buf[1+fgLen:1+2*fgLen], maxFgBits[logn]) ||
+		!trimDecode(F, buf[1+2*fgLen:], maxFGBits) {
+		return errors.New("falcon: invalid private key")
+	}
+
+	// As f*G - g*F = q, G = g*F/f mod q, and h = g/f mod q.
+	tG, ok := divModQ(g, f, logn)
+	if !ok {
+		return errors.New("falcon: invalid private key")
+	}
+	h := make([]uint16, n)
+	for u := 0; u < n; u++ {
+		h[u] = uint16(tG[u])
+	}
+	tF := make([]uint32, n)
+	for u := 0; u < n; u++ {
+		tF[u] = modQ(int32(F[u]))
+	}
+	ntt(tG, logn)
+	ntt(tF, logn)
+	for u := 0; u < n; u++ {
+		tG[u] = tG[u] * tF[u] % Q
+	}
+	invNTT(tG, logn)
+	G := make([]int8, n)
+	lim := int32(1)<<(maxFGBits-1) - 1
+	for u := 0; u < n; u++ {
+		x := center(tG[u])
+		if x < -lim || x > lim {
+			return errors.New("falcon: invalid private key")
+		}
+		G[u] = int8(x)
+	}
+
+	*sk = PrivateKey{
+		logn: logn,
+		f:    f,
+		g:    g,
+		F:    F,
+		G:    G,
+		pk:   PublicKey{logn: logn, h: h},
+	}
+	sk.expand()
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other *PublicKey) bool {
+	if pk.logn != other.logn || len(pk.h) != len(other.h) {
+		return false
+	}
+	ret := 1
+	for u := range pk.h {
+		ret &= subtle.ConstantTimeEq(int32(pk.h[u]), int32(other.h[u]))
+	}
+	return ret == 1
+}
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other *PrivateKey) bool {
+	if sk.logn != other.logn || len(sk.f) != len(other.f) {
+		return false
+	}
+	ret := byte(0)
+	for u := range sk.f {
+		ret |= byte(sk.f[u]^other.f[u]) | byte(sk.g[u]^other.g[u]) |
+			byte(sk.F[u]^other.F[u])
+	}
+	return subtle.ConstantTimeByteEq(ret, 0) == 1
+}
@@ -0,0 +1,222 @@
+package internal
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/internal/test"
+)
+
+func TestNTT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for logn := uint(9); logn <= 10; logn++ {
+		n := 1 << logn
+		a := make([]uint32, n)
+		b := make([]uint32, n)
+		for u := 0; u < n; u++ {
+			a[u] = uint32(r.Intn(Q))
+			b[u] = uint32(r.Intn(Q))
+		}
+
+		// Schoolbook multiplication modulo X^n+1.
+		want := make([]uint32, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				p := a[i] * b[j] % Q
+				if i+j < n {
+					want[i+j] = (want[i+j] + p) % Q
+				} else {
+					want[i+j-n] = (want[i+j-n] + Q - p) % Q
+				}
+			}
+		}
+
+		ntt(a, logn)
+		ntt(b, logn)
+		for u := 0; u < n; u++ {
+			a[u] = a[u] * b[u] % Q
+		}
+		invNTT(a, logn)
+		for u := 0; u < n; u++ {
+			if a[u] != want[u] {
+				test.ReportError(t, a[u], want[u], logn, u)
+			}
+		}
+	}
+}
+
+func TestFFT(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for logn := uint(1); logn <= 10; logn++ {
+		n := 1 << logn
+		a := make([]fpr, n)
+		b := make([]fpr, n)
+		ia := make([]int64, n)
+		ib := make([]int64, n)
+		for u := 0; u < n; u++ {
+			ia[u] = int64(r.Intn(201) - 100)
+			ib[u] = int64(r.Intn(201) - 100)
+			a[u] = fprOf(ia[u])
+			b[u] = fprOf(ib[u])
+		}
+		want := make([]int64, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i+j < n {
+					want[i+j] += ia[i] * ib[j]
+				} else {
+					want[i+j-n] -= ia[i] * ib[j]
+				}
+			}
+		}
+
+		fft(a, logn)
+		fft(b, logn)
+
+		// Splitting and merging is the identity.
+		c := make([]fpr, n)
+		tmp := make([]fpr, n)
+		polySplitFFT(tmp, tmp[n/2:], a, logn)
+		polyMergeFFT(c, tmp, tmp[n/2:], logn)
+		polySub(c, a, logn)
+		ifft(c, logn)
+		for u := 0; u < n; u++ {
+			if fprRint(fprMul(c[u], fprScaled(1, 20))) != 0 {
+				t.Fatalf("split/merge logn=%v: %v", logn, c[u].float64())
+			}
+		}
+
+		polyMulFFT(a, b, logn)
+		ifft(a, logn)
+		for u := 0; u < n; u++ {
+			if got := fprRint(a[u]); got != want[u] {
+				test.ReportError(t, got, want[u], logn, u)
+			}
+		}
+	}
+}
+
+func TestCodec(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	s := make([]int16, 512)
+	for u := range s {
+		s[u] = int16(r.NormFloat64() * 165)
+	}
+	buf := make([]byte, 1000)
+	v, ok := compEncode(buf, s)
+	test.CheckOk(ok, "compEncode failed", t)
+	s2 := make([]int16, 512)
+	w, ok := compDecode(s2, buf[:v])
+	test.CheckOk(ok && w == v, "compDecode failed", t)
+	for u := range s {
+		if s[u] != s2[u] {
+			test.ReportError(t, s2[u], s[u], u)
+		}
+	}
+
+	// "-0" is not canonical.
+	_, ok = compDecode(s2[:1], []byte{0x80, 0x80})
+	test.CheckOk(!ok, "compDecode accepted -0", t)
+
+	f := make([]int8, 512)
+	for u := range f {
+		f[u] = int8(r.Intn(63) - 31)
+	}
+	enc := make([]byte, 384)
+	trimEncode(enc, f, 6)
+	f2 := make([]int8, 512)
+	test.CheckOk(trimDecode(f2, enc, 6), "trimDecode failed", t)
+	for u := range f {
+		if f[u] != f2[u] {
+			test.ReportError(t, f2[u], f[u], u)
+		}
+	}
+}
+
+func TestNTRUSolve(t *testing.T) {
+	for logn := uint(9); logn <= 10; logn++ {
+		var seed [SeedSize]byte
+		seed[0] = byte(logn)
+		h := sha3.NewShake256()
+		_, _ = h.Write(seed[:])
+		f, g, F, G, pkh := newKeyFromSeed(&h, logn)
+
+		// f*G - g*F = q.
+		n := 1 << logn
+		toBig := func(a []int8) []*big.Int {
+			ret := make([]*big.Int, n)
+			for u := range a {
+				ret[u] = big.NewInt(int64(a[u]))
+			}
+			return ret
+		}
+		fG := polyMulBig(toBig(f), toBig(G))
+		gF := polyMulBig(toBig(g), toBig(F))
+		for u := 0; u < n; u++ {
+			fG[u].Sub(fG[u], gF[u])
+			want := int64(0)
+			if u == 0 {
+				want = Q
+			}
+			if !fG[u].IsInt64() || fG[u].Int64() != want {
+				test.ReportError(t, fG[u], want, logn, u)
+			}
+		}
+
+		// h*f = g mod q.
+		th := make([]uint32, n)
+		tf := make([]uint32, n)
+		for u := 0; u < n; u++ {
+			th[u] = uint32(pkh[u])
+			tf[u] = modQ(int32(f[u]))
+		}
+		ntt(th, logn)
+		ntt(tf, logn)
+		for u := 0; u < n; u++ {
+			th[u] = th[u] * tf[u] % Q
+		}
+		invNTT(th, logn)
+		for u := 0; u < n; u++ {
+			if center(th[u]) != int32(g[u]) {
+				test.ReportError(t, center(th[u]), g[u], logn, u)
+			}
+		}
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	for logn := uint(9); logn <= 10; logn++ {
+		var seed [SeedSize]byte
+		pk, sk := NewKeyFromSeed(logn, &seed)
+		r := rand.New(rand.NewSource(int64(logn)))
+		msg := []byte("message")
+		for i := 0; i < 10; i++ {
+			sig := make([]byte, SignatureSize(logn))
+			test.CheckNoErr(t, SignTo(sk, msg, r, sig), "SignTo failed")
+			test.CheckOk(Verify(pk, msg, sig), "Verify failed", t)
+			test.CheckOk(!Verify(pk, []byte("other"), sig), "Verify succeeded", t)
+			sig[len(sig)-1] ^= 1
+			test.CheckOk(!Verify(pk, msg, sig), "Verify succeeded", t)
+		}
+
+		buf := make([]byte, PrivateKeySize(logn))
+		sk.Pack(buf)
+		var sk2 PrivateKey
+		test.CheckNoErr(t, sk2.Unpack(logn, buf), "Unpack failed")
+		test.CheckOk(sk.Equal(&sk2), "private keys differ", t)
+		test.CheckOk(pk.Equal(sk2.Public()), "public keys differ", t)
+		for u := range sk.G {
+			if sk.G[u] != sk2.G[u] {
+				test.ReportError(t, sk2.G[u], sk.G[u], u)
+			}
+		}
+
+		buf = make([]byte, PublicKeySize(logn))
+		pk.Pack(buf)
+		var pk2 PublicKey
+		test.CheckNoErr(t, pk2.Unpack(logn, buf), "Unpack failed")
+		test.CheckOk(pk.Equal(&pk2), "public keys differ", t)
+	}
+}
@@ -0,0 +1,215 @@
+package internal
+
+// Polynomials of degree n = 2^logn with real coefficients are represented in
+// FFT form by their values at the n/2 roots of X^n+1 of positive imaginary
+// part: the real parts are stored in the first half of the slice, and the
+// imaginary parts in the second half.
+
+// Complex multiplication.
+func fpcMul(aRe, aIm, bRe, bIm fpr) (fpr, fpr) {
+	return fprSub(fprMul(aRe, bRe), fprMul(aIm, bIm)),
+		fprAdd(fprMul(aRe, bIm), fprMul(aIm, bRe))
+}
+
+// Complex division.
+func fpcDiv(aRe, aIm, bRe, bIm fpr) (fpr, fpr) {
+	m := fprInv(fprAdd(fprSqr(bRe), fprSqr(bIm)))
+	bRe = fprMul(bRe, m)
+	bIm = fprNeg(fprMul(bIm, m))
+	return fpcMul(aRe, aIm, bRe, bIm)
+}
+
+// fft converts f to FFT form, in place.
+func fft(f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	t := hn
+	for u, m := uint(1), 2; u < logn; u, m = u+1, m<<1 {
+		ht := t >> 1
+		hm := m >> 1
+		for i1, j1 := 0, 0; i1 < hm; i1, j1 = i1+1, j1+t {
+			sRe := gmTab[(m+i1)<<1]
+			sIm := gmTab[(m+i1)<<1+1]
+			for j := j1; j < j1+ht; j++ {
+				xRe, xIm := f[j], f[j+hn]
+				yRe, yIm := fpcMul(f[j+ht], f[j+ht+hn], sRe, sIm)
+				f[j], f[j+hn] = fprAdd(xRe, yRe), fprAdd(xIm, yIm)
+				f[j+ht], f[j+ht+hn] = fprSub(xRe, yRe), fprSub(xIm, yIm)
+			}
+		}
+		t = ht
+	}
+}
+
+// ifft converts f from FFT form, in place.
+func ifft(f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	t, m := 1, n
+	for u := logn; u > 1; u-- {
+		hm := m >> 1
+		dt := t << 1
+		for i1, j1 := 0, 0; j1 < hn; i1, j1 = i1+1, j1+dt {
+			sRe := gmTab[(hm+i1)<<1]
+			sIm := fprNeg(gmTab[(hm+i1)<<1+1])
+			for j := j1; j < j1+t; j++ {
+				xRe, xIm := f[j], f[j+hn]
+				yRe, yIm := f[j+t], f[j+t+hn]
+				f[j], f[j+hn] = fprAdd(xRe, yRe), fprAdd(xIm, yIm)
+				f[j+t], f[j+t+hn] = fpcMul(fprSub(xRe, yRe), fprSub(xIm, yIm), sRe, sIm)
+			}
+		}
+		t = dt
+		m = hm
+	}
+	if logn > 0 {
+		ni := fprScaled(1, 1-int(logn))
+		for u := range f[:n] {
+			f[u] = fprMul(f[u], ni)
+		}
+	}
+}
+
+// polyAdd sets a = a + b.
+func polyAdd(a, b []fpr, logn uint) {
+	for u := 0; u < 1<<logn; u++ {
+		a[u] = fprAdd(a[u], b[u])
+	}
+}
+
+// polySub sets a = a - b.
+func polySub(a, b []fpr, logn uint) {
+	for u := 0; u < 1<<logn; u++ {
+		a[u] = fprSub(a[u], b[u])
+	}
+}
+
+// polyNeg sets a = -a.
+func polyNeg(a []fpr, logn uint) {
+	for u := 0; u < 1<<logn; u++ {
+		a[u] = fprNeg(a[u])
+	}
+}
+
+// polyAdjFFT sets a to its adjoint, in FFT form.
+func polyAdjFFT(a []fpr, logn uint) {
+	n := 1 << logn
+	for u := n >> 1; u < n; u++ {
+		a[u] = fprNeg(a[u])
+	}
+}
+
+// polyMulFFT sets a = a*b, in FFT form.
+func polyMulFFT(a, b []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		a[u], a[u+hn] = fpcMul(a[u], a[u+hn], b[u], b[u+hn])
+	}
+}
+
+// polyMulAdjFFT sets a = a*adj(b), in FFT form.
+func polyMulAdjFFT(a, b []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		a[u], a[u+hn] = fpcMul(a[u], a[u+hn], b[u], fprNeg(b[u+hn]))
+	}
+}
+
+// polyMulSelfAdjFFT sets a = a*adj(a), in FFT form.
+func polyMulSelfAdjFFT(a []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		a[u] = fprAdd(fprSqr(a[u]), fprSqr(a[u+hn]))
+		a[u+hn] = fprZero
+	}
+}
+
+// polyMulConst sets a = x*a.
+func polyMulConst(a []fpr, x fpr, logn uint) {
+	for u := 0; u < 1<<logn; u++ {
+		a[u] = fprMul(a[u], x)
+	}
+}
+
+// polyDivFFT sets a = a/b, in FFT form.
+func polyDivFFT(a, b []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		a[u], a[u+hn] = fpcDiv(a[u], a[u+hn], b[u], b[u+hn])
+	}
+}
+
+// polyInvNorm2FFT sets d = 1/(a*adj(a) + b*adj(b)), in FFT form.  The
+// result is self-adjoint, so its imaginary parts are zero.
+func polyInvNorm2FFT(d, a, b []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		na := fprAdd(fprSqr(a[u]), fprSqr(a[u+hn]))
+		nb := fprAdd(fprSqr(b[u]), fprSqr(b[u+hn]))
+		d[u] = fprInv(fprAdd(na, nb))
+		d[u+hn] = fprZero
+	}
+}
+
+// polyMulAutoAdjFFT sets a = a*b, in FFT form, where b is self-adjoint.
+func polyMulAutoAdjFFT(a, b []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		a[u] = fprMul(a[u], b[u])
+		a[u+hn] = fprMul(a[u+hn], b[u])
+	}
+}
+
+// polyLDLmvFFT computes the LDL decomposition of the self-adjoint matrix
+// [[g00, g01], [adj(g01), g11]], in FFT form: it sets l10 = adj(g01/g00)
+// and d11 = g11 - g01*adj(g01)/g00.
+func polyLDLmvFFT(d11, l10, g00, g01, g11 []fpr, logn uint) {
+	hn := 1 << logn >> 1
+	for u := 0; u < hn; u++ {
+		muRe, muIm := fpcDiv(g01[u], g01[u+hn], g00[u], g00[u+hn])
+		xRe, xIm := fpcMul(muRe, muIm, g01[u], fprNeg(g01[u+hn]))
+		d11[u] = fprSub(g11[u], xRe)
+		d11[u+hn] = fprSub(g11[u+hn], xIm)
+		l10[u] = muRe
+		l10[u+hn] = fprNeg(muIm)
+	}
+}
+
+// polySplitFFT splits f, in FFT form, into f0 and f1 of half its degree,
+// such that f(x) = f0(x^2) + x*f1(x^2).
+func polySplitFFT(f0, f1, f []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	qn := hn >> 1
+
+	f0[0] = f[0]
+	f1[0] = f[hn]
+	for u := 0; u < qn; u++ {
+		aRe, aIm := f[u<<1], f[u<<1+hn]
+		bRe, bIm := f[u<<1+1], f[u<<1+1+hn]
+
+		f0[u] = fprHalf(fprAdd(aRe, bRe))
+		f0[u+qn] = fprHalf(fprAdd(aIm, bIm))
+
+		tRe, tIm := fpcMul(fprSub(aRe, bRe), fprSub(aIm, bIm),
+			gmTab[(u+hn)<<1], fprNeg(gmTab[(u+hn)<<1+1]))
+		f1[u] = fprHalf(tRe)
+		f1[u+qn] = fprHalf(tIm)
+	}
+}
+
+// polyMergeFFT is the inverse of polySplitFFT.
+func polyMergeFFT(f, f0, f1 []fpr, logn uint) {
+	n := 1 << logn
+	hn := n >> 1
+	qn := hn >> 1
+
+	f[0] = f0[0]
+	f[hn] = f1[0]
+	for u := 0; u < qn; u++ {
+		aRe, aIm := f0[u], f0[u+qn]
+		bRe, bIm := fpcMul(f1[u], f1[u+qn], gmTab[(u+hn)<<1], gmTab[(u+hn)<<1+1])
+		f[u<<1], f[u<<1+hn] = fprAdd(aRe, bRe), fprAdd(aIm, bIm)
+		f[u<<1+1], f[u<<1+1+hn] = fprSub(aRe, bRe), fprSub(aIm, bIm)
+	}
+}
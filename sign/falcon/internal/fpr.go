@@ -0,0 +1,308 @@
+package internal
+
+import (
+	"math"
+	"math/bits"
+)
+
+// fpr is a floating-point value in the IEEE-754 binary64 format, on which
+// arithmetic is computed with integer operations only, in constant time.
+//
+// Compared to the hardware floating-point unit, denormals are flushed to
+// zero, and infinities and NaNs are not supported, as they never occur in
+// Falcon.  Rounding is always to the nearest, ties to even, so the results
+// are identical to the native ones on all other values.  This makes the
+// signatures reproducible across platforms, and independent of the compiler
+// fusing multiplications and additions.
+type fpr uint64
+
+const (
+	fprZero       fpr = 0
+	fprOne        fpr = 0x3ff0000000000000
+	fprTwo        fpr = 0x4000000000000000
+	fprQ          fpr = 0x40c8008000000000 // 12289
+	fprInvQ       fpr = 0x3f1554e39097a782 // 1/12289
+	fprInv2SqrSig fpr = 0x3fc34f8bc183bbc2 // 1/(2*1.8205^2)
+	fprLog2       fpr = 0x3fe62e42fefa39ef // ln(2)
+	fprInvLog2    fpr = 0x3ff71547652b82fe // 1/ln(2)
+	fprPTwo63     fpr = 0x43e0000000000000 // 2^63
+	fprBNormMax   fpr = 0x40d06d9a5fd8adac // 16822.4121
+)
+
+func (x fpr) float64() float64 { return math.Float64frombits(uint64(x)) }
+
+// fprBuild returns the value (-1)^s*m*2^e, where m is either zero or in
+// [2^54, 2^55).  The two least significant bits of m are used for rounding,
+// and the lowest one must be "sticky", i.e., set if any of the dropped bits
+// was set.  Values too small to be represented, including the case m = 0,
+// are flushed to zero.
+func fprBuild(s uint64, e int, m uint64) fpr {
+	e += 1076
+	m &= ^uint64(int64(e) >> 63)
+	e &= -int(m >> 54)
+	x := (s<<63 | m>>2) + uint64(uint32(e))<<52
+	x += uint64(0xC8>>(m&7)) & 1
+	return fpr(x)
+}
+
+// norm64 shifts m left until its top bit is set, and adjusts e accordingly,
+// so that m*2^e is unchanged.  If m is zero, it is left unchanged, and e is
+// decreased by 63.
+func norm64(m uint64, e int) (uint64, int) {
+	e -= 63
+	for k := uint(32); k > 0; k >>= 1 {
+		nt := m >> (64 - k)
+		nt = (nt | -nt) >> 63
+		m ^= (m ^ (m << k)) & (nt - 1)
+		e += int(nt) * int(k)
+	}
+	return m, e
+}
+
+// fprScaled returns i*2^sc.
+func fprScaled(i int64, sc int) fpr {
+	s := uint64(i) >> 63
+	i ^= -int64(s)
+	i += int64(s)
+	m := uint64(i)
+	m, e := norm64(m, 9+sc)
+	m |= (m & 0x1FF) + 0x1FF
+	m >>= 9
+	t := uint64(i|-i) >> 63
+	m &= -t
+	e &= -int(t)
+	return fprBuild(s, e, m)
+}
+
+// fprOf returns i as a floating-point value.
+func fprOf(i int64) fpr { return fprScaled(i, 0) }
+
+func fprAdd(x, y fpr) fpr {
+	// Swap x and y so that |x| >= |y|, and x is positive if |x| = |y|.
+	m := uint64(1)<<63 - 1
+	za := uint64(x)&m - uint64(y)&m
+	cs := za>>63 | (1-(-za)>>63)&(uint64(x)>>63)
+	m = uint64(x^y) & -cs
+	x ^= fpr(m)
+	y ^= fpr(m)
+
+	ex := int(uint64(x) >> 52)
+	sx := uint64(ex >> 11)
+	ex &= 0x7FF
+	m = uint64(uint32((ex+0x7FF)>>11)) << 52
+	xu := (uint64(x)&(1<<52-1) | m) << 3
+	ex -= 1078
+	ey := int(uint64(y) >> 52)
+	sy := uint64(ey >> 11)
+	ey &= 0x7FF
+	m = uint64(uint32((ey+0x7FF)>>11)) << 52
+	yu := (uint64(y)&(1<<52-1) | m) << 3
+	ey -= 1078
+
+	// Align y on x, keeping the dropped bits as a sticky bit.
+	cc := ex - ey
+	yu &= -uint64(uint32(cc-60) >> 31)
+	cc &= 63
+	m = uint64(1)<<uint(cc) - 1
+	yu |= (yu & m) + m
+	yu >>= uint(cc)
+
+	xu += yu - ((yu << 1) & -(sx ^ sy))
+	xu, ex = norm64(xu, ex)
+	xu |= (xu & 0x1FF) + 0x1FF
+	xu >>= 9
+	ex += 9
+	return fprBuild(sx, ex, xu)
+}
+
+func fprSub(x, y fpr) fpr { return fprAdd(x, fprNeg(y)) }
+
+func fprNeg(x fpr) fpr { return x ^ 1<<63 }
+
+// fprHalf returns x/2.
+func fprHalf(x fpr) fpr {
+	x -= 1 << 52
+	t := ((uint64(x)>>52)&0x7FF + 1) >> 11
+	return x & fpr(t-1)
+}
+
+// fprDouble returns 2x.
+func fprDouble(x fpr) fpr {
+	return x + fpr(((uint64(x)>>52)&0x7FF+0x7FF)>>11)<<52
+}
+
+func fprMul(x, y fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	yu := uint64(y)&(1<<52-1) | 1<<52
+
+	// The product is in [2^104, 2^106); keep its top 56 bits, with a sticky
+	// bit, and normalize it to [2^54, 2^55).
+	hi, lo := bits.Mul64(xu, yu)
+	zu := hi<<14 | lo>>50
+	zu |= ((lo & (1<<50 - 1)) + (1<<50 - 1)) >> 50
+	zv := zu >> 55
+	zu = zu>>zv | zu&zv
+
+	ex := int(uint64(x)>>52) & 0x7FF
+	ey := int(uint64(y)>>52) & 0x7FF
+	e := ex + ey - 2100 + int(zv)
+	s := uint64(x^y) >> 63
+
+	// Zero if either operand is zero.
+	d := uint64(((ex + 0x7FF) & (ey + 0x7FF)) >> 11)
+	zu &= -d
+	return fprBuild(s, e, zu)
+}
+
+func fprSqr(x fpr) fpr { return fprMul(x, x) }
+
+// fprDiv returns x/y, for y != 0.
+func fprDiv(x, y fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	yu := uint64(y)&(1<<52-1) | 1<<52
+
+	// Bit-by-bit division of the mantissas, with a sticky bit.
+	q := uint64(0)
+	for i := 0; i < 55; i++ {
+		b := ((xu - yu) >> 63) - 1
+		xu -= b & yu
+		q |= b & 1
+		xu <<= 1
+		q <<= 1
+	}
+	q |= (xu | -xu) >> 63
+
+	qv := q >> 55
+	q = q>>qv | q&qv
+
+	ex := int(uint64(x)>>52) & 0x7FF
+	ey := int(uint64(y)>>52) & 0x7FF
+	e := ex - ey - 55 + int(qv)
+	s := uint64(x^y) >> 63
+
+	// Zero if x is zero.
+	d := (ex + 0x7FF) >> 11
+	s &= uint64(d)
+	e &= -d
+	q &= -uint64(d)
+	return fprBuild(s, e, q)
+}
+
+func fprInv(x fpr) fpr { return fprDiv(fprOne, x) }
+
+// fprSqrt returns the square root of x >= 0.
+func fprSqrt(x fpr) fpr {
+	xu := uint64(x)&(1<<52-1) | 1<<52
+	ex := int(uint64(x)>>52) & 0x7FF
+	e := ex - 1023
+
+	// Make the exponent even, then compute the root bit by bit.
+	xu += xu & -uint64(e&1)
+	e >>= 1
+	xu <<= 1
+	q, s, r := uint64(0), uint64(0), uint64(1)<<53
+	for i := 0; i < 54; i++ {
+		t := s + r
+		b := ((xu - t) >> 63) - 1
+		s += (r << 1) & b
+		xu -= t & b
+		q += r & b
+		xu <<= 1
+		r >>= 1
+	}
+	q <<= 1
+	q |= (xu | -xu) >> 63
+	e -= 54
+
+	q &= -uint64((ex + 0x7FF) >> 11)
+	return fprBuild(0, e, q)
+}
+
+// fprRint returns x rounded to the nearest integer, ties to even.  It
+// requires |x| < 2^63.
+func fprRint(x fpr) int64 {
+	m := (uint64(x)<<10 | 1<<62) & (1<<63 - 1)
+	e := 1085 - int(uint64(x)>>52)&0x7FF
+
+	// If the exponent is too low, the result is zero, but the rounding bits
+	// are still computed from m.
+	m &= -uint64(uint32(e-64) >> 31)
+	e &= 63
+
+	d := m << uint(63-e)
+	dd := uint32(d) | uint32(d>>32)&0x1FFFFFFF
+	f := uint32(d>>61) | (dd|-dd)>>31
+	m = m>>uint(e) + uint64((0xC8>>f)&1)
+	s := uint64(x) >> 63
+	return int64(m^-s) + int64(s)
+}
+
+// fprFloor returns the largest integer not greater than x, for |x| < 2^63.
+func fprFloor(x fpr) int64 {
+	e := int(uint64(x)>>52) & 0x7FF
+	t := uint64(x) >> 63
+	xi := int64((uint64(x)<<10 | 1<<62) & (1<<63 - 1))
+	xi = (xi ^ -int64(t)) + int64(t)
+	cc := 1085 - e
+	xi >>= uint(cc & 63)
+
+	// If the exponent is too low, the result is -1 for negative values,
+	// and 0 for positive ones.
+	xi ^= (xi ^ -int64(t)) & -int64(uint32(63-cc)>>31)
+	return xi
+}
+
+// fprTrunc returns x rounded toward zero, for |x| < 2^63.
+func fprTrunc(x fpr) int64 {
+	e := int(uint64(x)>>52) & 0x7FF
+	xu := (uint64(x)<<10 | 1<<62) & (1<<63 - 1)
+	cc := 1085 - e
+	xu >>= uint(cc & 63)
+	xu &= -uint64(uint32(cc-64) >> 31)
+	t := uint64(x) >> 63
+	xu = (xu ^ -t) + t
+	return int64(xu)
+}
+
+// fprLt returns 1 if x < y, and 0 otherwise.
+func fprLt(x, y fpr) int {
+	sx, sy := int64(x), int64(y)
+
+	// If the signs differ, comparing x with 0 is enough.  Otherwise, the
+	// comparison of the bit patterns must be reversed for negative values.
+	sy &= ^((sx ^ sy) >> 63)
+	cc0 := int((sx-sy)>>63) & 1
+	cc1 := int((sy-sx)>>63) & 1
+	return cc0 ^ ((cc0 ^ cc1) & int(uint64(x&y)>>63))
+}
+
+// expmP63 returns round(2^63*ccs*exp(-x)), for 0 <= x < ln(2) and
+// 0 <= ccs <= 1, with an error of at most a few units.  It uses a
+// polynomial approximation of exp(-x) evaluated in fixed point.
+func expmP63(x, ccs fpr) uint64 {
+	coeffs := [...]uint64{
+		0x00000004741183A3,
+		0x00000036548CFC06,
+		0x0000024FDCBF140A,
+		0x0000171D939DE045,
+		0x0000D00CF58F6F84,
+		0x000680681CF796E3,
+		0x002D82D8305B0FEA,
+		0x011111110E066FD0,
+		0x0555555555070F00,
+		0x155555555581FF00,
+		0x400000000002B400,
+		0x7FFFFFFFFFFF4800,
+		0x8000000000000000,
+	}
+
+	y := coeffs[0]
+	z := uint64(fprTrunc(fprMul(x, fprPTwo63))) << 1
+	for u := 1; u < len(coeffs); u++ {
+		hi, _ := bits.Mul64(z, y)
+		y = coeffs[u] - hi
+	}
+	z = uint64(fprTrunc(fprMul(ccs, fprPTwo63))) << 1
+	y, _ = bits.Mul64(z, y)
+	return y
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randFloat(r *rand.Rand) float64 {
+	x := r.NormFloat64() * math.Ldexp(1, r.Intn(80)-40)
+	if r.Intn(10) == 0 {
+		x = float64(r.Int63n(1<<20) - 1<<19)
+	}
+	return x
+}
+
+func checkFpr(t *testing.T, op string, x, y float64, got fpr, want float64) {
+	t.Helper()
+	if got.float64() != want {
+		t.Fatalf("%s(%v, %v) = %v, want %v", op, x, y, got.float64(), want)
+	}
+}
+
+func TestFpr(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100000; i++ {
+		x, y := randFloat(r), randFloat(r)
+		fx := fpr(math.Float64bits(x))
+		fy := fpr(math.Float64bits(y))
+
+		checkFpr(t, "add", x, y, fprAdd(fx, fy), x+y)
+		checkFpr(t, "sub", x, y, fprSub(fx, fy), x-y)
+		checkFpr(t, "mul", x, y, fprMul(fx, fy), x*y)
+		checkFpr(t, "div", x, y, fprDiv(fx, fy), x/y)
+		checkFpr(t, "sqrt", x, 0, fprSqrt(fpr(math.Float64bits(math.Abs(x)))),
+			math.Sqrt(math.Abs(x)))
+		checkFpr(t, "half", x, 0, fprHalf(fx), x/2)
+		checkFpr(t, "double", x, 0, fprDouble(fx), 2*x)
+
+		if math.Abs(x) < 1<<62 {
+			if got, want := fprRint(fx), int64(math.RoundToEven(x)); got != want {
+				t.Fatalf("rint(%v) = %v, want %v", x, got, want)
+			}
+			if got, want := fprFloor(fx), int64(math.Floor(x)); got != want {
+				t.Fatalf("floor(%v) = %v, want %v", x, got, want)
+			}
+			if got, want := fprTrunc(fx), int64(math.Trunc(x)); got != want {
+				t.Fatalf("trunc(%v) = %v, want %v", x, got, want)
+			}
+		}
+		if got, want := fprLt(fx, fy) == 1, x < y; got != want {
+			t.Fatalf("lt(%v, %v) = %v, want %v", x, y, got, want)
+		}
+
+		n := r.Int63() >> uint(r.Intn(63))
+		if r.Intn(2) == 0 {
+			n = -n
+		}
+		sc := r.Intn(40) - 20
+		checkFpr(t, "scaled", float64(n), float64(sc), fprScaled(n, sc),
+			math.Ldexp(float64(n), sc))
+	}
+
+	// Zeroes.
+	zero := fprZero
+	checkFpr(t, "add", 0, 0, fprAdd(zero, zero), 0)
+	checkFpr(t, "mul", 0, 1, fprMul(zero, fprOne), 0)
+	checkFpr(t, "div", 0, 1, fprDiv(zero, fprOne), 0)
+	checkFpr(t, "sqrt", 0, 0, fprSqrt(zero), 0)
+	checkFpr(t, "half", 0, 0, fprHalf(zero), 0)
+	checkFpr(t, "double", 0, 0, fprDouble(zero), 0)
+	checkFpr(t, "of", 0, 0, fprOf(0), 0)
+	checkFpr(t, "sub", 1, 1, fprSub(fprOne, fprOne), 0)
+}
+
+func TestFprConstants(t *testing.T) {
+	for _, c := range []struct {
+		x    fpr
+		want float64
+	}{
+		{fprQ, 12289},
+		{fprInvQ, 1.0 / 12289},
+		{fprInv2SqrSig, 1 / (2 * 1.8205 * 1.8205)},
+		{fprLog2, math.Ln2},
+		{fprInvLog2, 1 / math.Ln2},
+		{fprPTwo63, 1 << 63},
+		{fprBNormMax, 16822.4121},
+	} {
+		if c.x.float64() != c.want {
+			t.Fatalf("got %v, want %v", c.x.float64(), c.want)
+		}
+	}
+}
+
+func TestExpm(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < 10000; i++ {
+		x := r.Float64() * math.Ln2
+		ccs := r.Float64()
+		got := expmP63(fpr(math.Float64bits(x)), fpr(math.Float64bits(ccs)))
+		want := ccs * math.Exp(-x)
+		if d := math.Abs(math.Ldexp(float64(got), -63) - want); d > 1e-14 {
+			t.Fatalf("expm(%v, %v) = %v, want %v", x, ccs,
+				math.Ldexp(float64(got), -63), want)
+		}
+	}
+}
@@ -0,0 +1,381 @@
+package internal
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// Maximum bit length of the coefficients of f and g, indexed by logn.  The
+// coefficients of F and G always fit in 8 bits.
+var maxFgBits = [11]uint{0, 8, 8, 8, 8, 8, 7, 7, 6, 6, 5}
+
+const maxFGBits = 8
+
+// mkgauss returns a polynomial whose coefficients are drawn from the
+// discrete Gaussian of standard deviation 1.17*sqrt(q/(2n)), as the sum of
+// 1024/n samples of the one of degree 1024.  The sum of the coefficients is
+// made odd, which is required for f and g to have odd resultants with
+// X^n+1, as otherwise NTRUSolve fails.
+func mkgauss(h *sha3.State, logn uint) []int8 {
+	n := 1 << logn
+	f := make([]int8, n)
+	var buf [8]byte
+	parity := 0
+	for u := 0; u < n; u++ {
+		for {
+			s := 0
+			for i := 0; i < 1<<(10-logn); i++ {
+				_, _ = h.Read(buf[:])
+				r := binary.LittleEndian.Uint64(buf[:])
+				neg := int(r >> 63)
+				r &= 1<<63 - 1
+				v := 0
+				for k := range gaussFG {
+					v += int((r - gaussFG[k]) >> 63)
+				}
+				s += (v ^ -neg) + neg
+			}
+			if s < -127 || s > 127 {
+				continue
+			}
+			if u == n-1 {
+				if (parity^s)&1 == 0 {
+					continue
+				}
+			} else {
+				parity ^= s & 1
+			}
+			f[u] = int8(s)
+			break
+		}
+	}
+	return f
+}
+
+// newKeyFromSeed returns f, g, F, G and the public key h, derived from the
+// SHAKE256 state h.
+func newKeyFromSeed(rng *sha3.State, logn uint) (f, g, F, G []int8, h []uint16) {
+	n := 1 << logn
+	lim := 1 << (maxFgBits[logn] - 1)
+
+	for {
+		f = mkgauss(rng, logn)
+		g = mkgauss(rng, logn)
+
+		// The coefficients of f and g must fit in the private key encoding,
+		// and (g, -f) must be short enough.
+		ok := true
+		norm := 0
+		for u := 0; u < n; u++ {
+			if int(f[u]) <= -lim || int(f[u]) >= lim ||
+				int(g[u]) <= -lim || int(g[u]) >= lim {
+				ok = false
+			}
+			norm += int(f[u])*int(f[u]) + int(g[u])*int(g[u])
+		}
+		if !ok || norm >= 16823 {
+			continue
+		}
+
+		// The orthogonalized vector (q*adj(f), q*adj(g))/(f*adj(f)+g*adj(g))
+		// must be short enough.
+		rf := make([]fpr, n)
+		rg := make([]fpr, n)
+		rt := make([]fpr, n)
+		for u := 0; u < n; u++ {
+			rf[u] = fprOf(int64(f[u]))
+			rg[u] = fprOf(int64(g[u]))
+		}
+		fft(rf, logn)
+		fft(rg, logn)
+		polyInvNorm2FFT(rt, rf, rg, logn)
+		polyAdjFFT(rf, logn)
+		polyAdjFFT(rg, logn)
+		polyMulConst(rf, fprQ, logn)
+		polyMulConst(rg, fprQ, logn)
+		polyMulAutoAdjFFT(rf, rt, logn)
+		polyMulAutoAdjFFT(rg, rt, logn)
+		ifft(rf, logn)
+		ifft(rg, logn)
+		bnorm := fprZero
+		for u := 0; u < n; u++ {
+			bnorm = fprAdd(bnorm, fprSqr(rf[u]))
+			bnorm = fprAdd(bnorm, fprSqr(rg[u]))
+		}
+		if fprLt(bnorm, fprBNormMax) == 0 {
+			continue
+		}
+
+		// f must be invertible modulo q.
+		th, ok := divModQ(g, f, logn)
+		if !ok {
+			continue
+		}
+
+		F, G, ok = ntruSolve(f, g, logn)
+		if !ok {
+			continue
+		}
+
+		h = make([]uint16, n)
+		for u := 0; u < n; u++ {
+			h[u] = uint16(th[u])
+		}
+		return f, g, F, G, h
+	}
+}
+
+// ntruSolve returns F and G such that f*G - g*F = q, with coefficients
+// that fit in maxFGBits bits, or false if there is none.
+func ntruSolve(f, g []int8, logn uint) (F, G []int8, ok bool) {
+	n := 1 << logn
+	bf := make([]*big.Int, n)
+	bg := make([]*big.Int, n)
+	for u := 0; u < n; u++ {
+		bf[u] = big.NewInt(int64(f[u]))
+		bg[u] = big.NewInt(int64(g[u]))
+	}
+	bF, bG, ok := ntruSolveBig(bf, bg)
+	if !ok {
+		return nil, nil, false
+	}
+
+	lim := int64(1)<<(maxFGBits-1) - 1
+	F = make([]int8, n)
+	G = make([]int8, n)
+	for u := 0; u < n; u++ {
+		if !bF[u].IsInt64() || !bG[u].IsInt64() {
+			return nil, nil, false
+		}
+		x, y := bF[u].Int64(), bG[u].Int64()
+		if x < -lim || x > lim || y < -lim || y > lim {
+			return nil, nil, false
+		}
+		F[u], G[u] = int8(x), int8(y)
+	}
+	return F, G, true
+}
+
+// ntruSolveBig solves the NTRU equation recursively, with the field norm
+// to go down to degree one, where it is an extended GCD.  At each level,
+// the solution is reduced with Babai's round-off algorithm.
+func ntruSolveBig(f, g []*big.Int) (F, G []*big.Int, ok bool) {
+	n := len(f)
+	if n == 1 {
+		u, v := new(big.Int), new(big.Int)
+		d := new(big.Int).GCD(u, v, f[0], g[0])
+		if d.Cmp(big.NewInt(1)) != 0 {
+			return nil, nil, false
+		}
+		q := big.NewInt(Q)
+		F = []*big.Int{v.Mul(v, q).Neg(v)}
+		G = []*big.Int{u.Mul(u, q)}
+		return F, G, true
+	}
+
+	Fp, Gp, ok := ntruSolveBig(fieldNorm(f), fieldNorm(g))
+	if !ok {
+		return nil, nil, false
+	}
+
+	// F = F'(x^2)*g(-x) and G = G'(x^2)*f(-x).
+	F = polyMulBig(lift(Fp), galoisConj(g))
+	G = polyMulBig(lift(Gp), galoisConj(f))
+	reduceBig(f, g, F, G)
+	return F, G, true
+}
+
+// fieldNorm returns f(x)*f(-x), as a polynomial in x^2.
+func fieldNorm(f []*big.Int) []*big.Int {
+	hn := len(f) / 2
+	fe := make([]*big.Int, hn)
+	fo := make([]*big.Int, hn)
+	for u := 0; u < hn; u++ {
+		fe[u] = f[2*u]
+		fo[u] = f[2*u+1]
+	}
+	fe = polyMulBig(fe, fe)
+	fo = polyMulBig(fo, fo)
+
+	// f(x)*f(-x) = fe(x^2)^2 - x^2*fo(x^2)^2.
+	ret := make([]*big.Int, hn)
+	ret[0] = fe[0].Add(fe[0], fo[hn-1])
+	for u := 1; u < hn; u++ {
+		ret[u] = fe[u].Sub(fe[u], fo[u-1])
+	}
+	return ret
+}
+
+// lift returns f(x^2).
+func lift(f []*big.Int) []*big.Int {
+	ret := make([]*big.Int, 2*len(f))
+	for u := range f {
+		ret[2*u] = f[u]
+		ret[2*u+1] = new(big.Int)
+	}
+	return ret
+}
+
+// galoisConj returns f(-x).
+func galoisConj(f []*big.Int) []*big.Int {
+	ret := make([]*big.Int, len(f))
+	for u := range f {
+		ret[u] = new(big.Int).Set(f[u])
+		if u%2 == 1 {
+			ret[u].Neg(ret[u])
+		}
+	}
+	return ret
+}
+
+func maxBitLen(polys ...[]*big.Int) int {
+	m := 0
+	for _, p := range polys {
+		for _, x := range p {
+			if l := x.BitLen(); l > m {
+				m = l
+			}
+		}
+	}
+	return m
+}
+
+// toFFT returns f/2^shift, in FFT form.
+func toFFT(f []*big.Int, shift uint, logn uint) []fpr {
+	ret := make([]fpr, len(f))
+	t := new(big.Int)
+	for u := range f {
+		ret[u] = fprOf(t.Rsh(f[u], shift).Int64())
+	}
+	fft(ret, logn)
+	return ret
+}
+
+// reduceBig reduces (F, G) with respect to (f, g), subtracting k*(f, g)
+// where k is a rounding of (F*adj(f) + G*adj(g))/(f*adj(f) + g*adj(g)).
+// This is computed in floating point, on the 53 most significant bits of
+// the coefficients, so several rounds are needed when F and G are much
+// larger than f and g: each round computes the top bits of k, and reduces
+// the size of F and G by about as many bits.
+func reduceBig(f, g, F, G []*big.Int) {
+	const kBits = 25
+
+	n := len(f)
+	logn := uint(0)
+	for 1<<logn < n {
+		logn++
+	}
+
+	size := maxBitLen(f, g)
+	if size < 53 {
+		size = 53
+	}
+	fa := toFFT(f, uint(size-53), logn)
+	ga := toFFT(g, uint(size-53), logn)
+	den := make([]fpr, n)
+	polyInvNorm2FFT(den, fa, ga, logn)
+
+	// The bound on the number of rounds is only a safeguard against
+	// rounding errors making k oscillate: a failure to reduce F and G is
+	// caught by the final bound on their coefficients.
+	k := make([]*big.Int, n)
+	for round := 0; round < 1000; round++ {
+		Size := maxBitLen(F, G)
+		if Size < 53 {
+			Size = 53
+		}
+		if Size < size {
+			return
+		}
+
+		// The quotient is (F/f)*2^(size-Size).  Compute k = rint(F/f*2^-d),
+		// with d such that k has at most about kBits bits.
+		d := Size - size - kBits
+		if d < 0 {
+			d = 0
+		}
+		num := toFFT(F, uint(Size-53), logn)
+		t := toFFT(G, uint(Size-53), logn)
+		polyMulAdjFFT(num, fa, logn)
+		polyMulAdjFFT(t, ga, logn)
+		polyAdd(num, t, logn)
+		polyMulAutoAdjFFT(num, den, logn)
+		polyMulConst(num, fprScaled(1, Size-size-d), logn)
+		ifft(num, logn)
+
+		zero := true
+		for u := 0; u < n; u++ {
+			k[u] = big.NewInt(fprRint(num[u]))
+			if k[u].Sign() != 0 {
+				zero = false
+			}
+		}
+		if zero {
+			return
+		}
+
+		fk := polyMulBig(f, k)
+		gk := polyMulBig(g, k)
+		for u := 0; u < n; u++ {
+			F[u].Sub(F[u], fk[u].Lsh(fk[u], uint(d)))
+			G[u].Sub(G[u], gk[u].Lsh(gk[u], uint(d)))
+		}
+	}
+}
+
+// polyMulBig returns a*b mod X^n+1, computed with a single multiplication
+// of integers by Kronecker substitution.
+func polyMulBig(a, b []*big.Int) []*big.Int {
+	n := len(a)
+	logn := 0
+	for 1<<uint(logn) < n {
+		logn++
+	}
+
+	// Each coefficient of the product fits in w-1 bits, sign included.
+	w := uint(maxBitLen(a) + maxBitLen(b) + logn + 2)
+	c := new(big.Int).Mul(kroneckerPack(a, w), kroneckerPack(b, w))
+	prod := make([]*big.Int, 2*n)
+	kroneckerUnpack(prod, c, w)
+
+	for u := 0; u < n; u++ {
+		prod[u].Sub(prod[u], prod[u+n])
+	}
+	return prod[:n]
+}
+
+// Returns the sum of the a[i]*2^(w*i).
+func kroneckerPack(a []*big.Int, w uint) *big.Int {
+	if len(a) == 1 {
+		return new(big.Int).Set(a[0])
+	}
+	h := len(a) / 2
+	hi := kroneckerPack(a[h:], w)
+	hi.Lsh(hi, w*uint(h))
+	return hi.Add(hi, kroneckerPack(a[:h], w))
+}
+
+// Sets out to the coefficients c[i] of c = sum c[i]*2^(w*i), for
+// |c[i]| < 2^(w-2).
+func kroneckerUnpack(out []*big.Int, c *big.Int, w uint) {
+	if len(out) == 1 {
+		out[0] = c
+		return
+	}
+	h := len(out) / 2
+	bits := w * uint(h)
+
+	// Take the low part as a signed value.
+	lo := new(big.Int).Lsh(big.NewInt(1), bits)
+	mask := new(big.Int).Sub(lo, big.NewInt(1))
+	l := new(big.Int).And(c, mask)
+	if l.Bit(int(bits)-1) == 1 {
+		l.Sub(l, lo)
+	}
+	hi := new(big.Int).Sub(c, l)
+	hi.Rsh(hi, bits)
+	kroneckerUnpack(out[:h], l, w)
+	kroneckerUnpack(out[h:], hi, w)
+}
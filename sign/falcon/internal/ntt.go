@@ -0,0 +1,90 @@
+package internal
+
+// Q is the modulus of the ring of public keys.
+const Q = 12289
+
+// Returns x^(Q-2) mod Q, that is the inverse of x modulo Q if x is not zero,
+// and zero otherwise.
+func modInv(x uint32) uint32 {
+	r := uint32(1)
+	for e := uint32(Q - 2); e != 0; e >>= 1 {
+		if e&1 == 1 {
+			r = r * x % Q
+		}
+		x = x * x % Q
+	}
+	return r
+}
+
+// ntt computes the number-theoretic transform of a, in place.  The
+// coefficients of a must be in [0, Q), and so are those of the output, in
+// bit-reversed order.
+func ntt(a []uint32, logn uint) {
+	n := 1 << logn
+	k := 0
+	for l := n >> 1; l >= 1; l >>= 1 {
+		for start := 0; start < n; start += 2 * l {
+			k++
+			zeta := uint32(zetas[k])
+			for j := start; j < start+l; j++ {
+				t := zeta * a[j+l] % Q
+				a[j+l] = (a[j] + Q - t) % Q
+				a[j] = (a[j] + t) % Q
+			}
+		}
+	}
+}
+
+// invNTT is the inverse of ntt.
+func invNTT(a []uint32, logn uint) {
+	n := 1 << logn
+	k := n
+	for l := 1; l < n; l <<= 1 {
+		for start := 0; start < n; start += 2 * l {
+			k--
+			zeta := Q - uint32(zetas[k])
+			for j := start; j < start+l; j++ {
+				t := a[j]
+				a[j] = (t + a[j+l]) % Q
+				a[j+l] = zeta * ((t + Q - a[j+l]) % Q) % Q
+			}
+		}
+	}
+	ni := modInv(uint32(n))
+	for j := range a[:n] {
+		a[j] = a[j] * ni % Q
+	}
+}
+
+// Returns x mod Q in [0, Q), for |x| < Q.
+func modQ(x int32) uint32 {
+	return uint32(x + Q&(x>>31))
+}
+
+// Returns x in [0, Q) as an integer in (-Q/2, Q/2].
+func center(x uint32) int32 {
+	return int32(x) - Q&((Q/2-int32(x))>>31)
+}
+
+// divModQ returns a/b mod Q, for polynomials a and b with small
+// coefficients, or false if b is not invertible.
+func divModQ(a, b []int8, logn uint) ([]uint32, bool) {
+	n := 1 << logn
+	ta := make([]uint32, n)
+	tb := make([]uint32, n)
+	for u := 0; u < n; u++ {
+		ta[u] = modQ(int32(a[u]))
+		tb[u] = modQ(int32(b[u]))
+	}
+	ntt(ta, logn)
+	ntt(tb, logn)
+	ok := true
+	for u := 0; u < n; u++ {
+		if tb[u] == 0 {
+			ok = false
+		}
+		ta[u] = ta[u] * modInv(tb[u]) % Q
+	}
+	invNTT(ta, logn)
+	return ta, ok
+}
@@ -0,0 +1,251 @@
+package internal
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/cloudflare/circl/internal/sha3"
+)
+
+// prng is the pseudorandom generator of the sampler, as in the reference
+// implementation: ChaCha20 keyed with 48 bytes and a 64-bit counter, eight
+// blocks at a time, with their words interleaved as in the AVX2 code.
+type prng struct {
+	key [12]uint32
+	cc  uint64
+	buf [512]byte
+	ptr int
+}
+
+// init seeds p with 56 bytes read from src.
+func (p *prng) init(src *sha3.State) {
+	var tmp [56]byte
+	_, _ = src.Read(tmp[:])
+	for i := range p.key {
+		p.key[i] = binary.LittleEndian.Uint32(tmp[4*i:])
+	}
+	p.cc = binary.LittleEndian.Uint64(tmp[48:])
+	p.refill()
+}
+
+var chachaConst = [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}
+
+func chachaQR(a, b, c, d uint32) (uint32, uint32, uint32, uint32) {
+	a += b
+	d = bits.RotateLeft32(d^a, 16)
+	c += d
+	b = bits.RotateLeft32(b^c, 12)
+	a += b
+	d = bits.RotateLeft32(d^a, 8)
+	c += d
+	b = bits.RotateLeft32(b^c, 7)
+	return a, b, c, d
+}
+
+func (p *prng) refill() {
+	for u := 0; u < 8; u++ {
+		var x, st [16]uint32
+		copy(st[:4], chachaConst[:])
+		copy(st[4:], p.key[:])
+		st[14] ^= uint32(p.cc)
+		st[15] ^= uint32(p.cc >> 32)
+		x = st
+		for i := 0; i < 10; i++ {
+			x[0], x[4], x[8], x[12] = chachaQR(x[0], x[4], x[8], x[12])
+			x[1], x[5], x[9], x[13] = chachaQR(x[1], x[5], x[9], x[13])
+			x[2], x[6], x[10], x[14] = chachaQR(x[2], x[6], x[10], x[14])
+			x[3], x[7], x[11], x[15] = chachaQR(x[3], x[7], x[11], x[15])
+			x[0], x[5], x[10], x[15] = chachaQR(x[0], x[5], x[10], x[15])
+			x[1], x[6], x[11], x[12] = chachaQR(x[1], x[6], x[11], x[12])
+			x[2], x[7], x[8], x[13] = chachaQR(x[2], x[7], x[8], x[13])
+			x[3], x[4], x[9], x[14] = chachaQR(x[3], x[4], x[9], x[14])
+		}
+		for v := range x {
+			binary.LittleEndian.PutUint32(p.buf[(u<<2)+(v<<5):], x[v]+st[v])
+		}
+		p.cc++
+	}
+	p.ptr = 0
+}
+
+func (p *prng) u8() uint64 {
+	x := p.buf[p.ptr]
+	p.ptr++
+	if p.ptr == len(p.buf) {
+		p.refill()
+	}
+	return uint64(x)
+}
+
+// u64 reads 8 bytes, and like the reference, discards the end of the
+// buffer when fewer than 9 bytes are left.
+func (p *prng) u64() uint64 {
+	if p.ptr >= len(p.buf)-9 {
+		p.refill()
+	}
+	x := binary.LittleEndian.Uint64(p.buf[p.ptr:])
+	p.ptr += 8
+	return x
+}
+
+// gaussian0 samples from the half Gaussian of standard deviation 1.8205
+// over the non-negative integers, in constant time.
+func (p *prng) gaussian0() int {
+	lo := p.u64()
+	hi := p.u8()
+	z := 0
+	for i := range rcdt {
+		_, b := bits.Sub64(lo, rcdt[i][1], 0)
+		z += int((hi - rcdt[i][0] - b) >> 63)
+	}
+	return z
+}
+
+// berExp returns true with probability ccs*exp(-x), for x >= 0.
+func (p *prng) berExp(x, ccs fpr) bool {
+	// Write x = s*ln(2) + r, with 0 <= r < ln(2), so that exp(-x) is
+	// 2^-s*exp(-r).  Saturating s at 63 has a negligible impact.
+	s := fprTrunc(fprMul(x, fprInvLog2))
+	r := fprSub(x, fprMul(fprOf(s), fprLog2))
+	sw := uint32(s)
+	sw ^= (sw ^ 63) & -((63 - sw) >> 31)
+	z := ((expmP63(r, ccs) << 1) - 1) >> sw
+
+	// Compare z with a random 64-bit value, lazily: the loop stops at the
+	// first differing byte, which leaks nothing about z.
+	var w uint32
+	for i := 56; ; i -= 8 {
+		w = uint32(p.u8()) - uint32((z>>uint(i))&0xFF)
+		if w != 0 || i == 0 {
+			break
+		}
+	}
+	return w>>31 != 0
+}
+
+// sampler draws integers from discrete Gaussians.
+type sampler struct {
+	p        prng
+	sigmaMin fpr
+}
+
+// sample returns an integer drawn from the discrete Gaussian of center mu
+// and standard deviation 1/isigma, which must be in [sigmaMin, 1.8205].
+func (s *sampler) sample(mu, isigma fpr) int64 {
+	// Center is mu = si + r, with si an integer and 0 <= r < 1.
+	si := fprFloor(mu)
+	r := fprSub(mu, fprOf(si))
+
+	// Rejection sampling from the base half Gaussian.
+	dss := fprHalf(fprSqr(isigma))
+	ccs := fprMul(isigma, s.sigmaMin)
+	for {
+		z0 := s.p.gaussian0()
+		b := int(s.p.u8() & 1)
+		z := b + (2*b-1)*z0
+
+		x := fprMul(fprSqr(fprSub(fprOf(int64(z)), r)), dss)
+		x = fprSub(x, fprMul(fprOf(int64(z0*z0)), fprInv2SqrSig))
+		if s.p.berExp(x, ccs) {
+			return si + int64(z)
+		}
+	}
+}
+
+// treeSize returns the number of elements of the LDL tree of degree 2^logn.
+func treeSize(logn uint) int {
+	return int(logn+1) << logn
+}
+
+// ffLDL computes the LDL tree of the Gram matrix [[g00, g01], [adj(g01),
+// g11]], in FFT form.  The tree is made of l10 followed by the trees of
+// d00 and d11, split in halves.  The inputs are preserved, and tmp must
+// have room for 3*2^logn elements.
+func ffLDL(tree, g00, g01, g11, tmp []fpr, logn uint) {
+	n := 1 << logn
+	if n == 1 {
+		tree[0] = g00[0]
+		return
+	}
+	hn := n >> 1
+	d00, d11, tmp := tmp[:n], tmp[n:2*n], tmp[2*n:]
+
+	copy(d00, g00[:n])
+	polyLDLmvFFT(d11, tree, g00, g01, g11, logn)
+
+	polySplitFFT(tmp, tmp[hn:], d00, logn)
+	polySplitFFT(d00, d00[hn:], d11, logn)
+	copy(d11, tmp[:n])
+	ffLDLInner(tree[n:], d11, d11[hn:], tmp, logn-1)
+	ffLDLInner(tree[n+treeSize(logn-1):], d00, d00[hn:], tmp, logn-1)
+}
+
+// ffLDLInner is ffLDL for the matrix [[g0, g1], [adj(g1), g0]] obtained by
+// splitting a self-adjoint polynomial.  It overwrites g0 and g1.
+func ffLDLInner(tree, g0, g1, tmp []fpr, logn uint) {
+	n := 1 << logn
+	if n == 1 {
+		tree[0] = g0[0]
+		return
+	}
+	hn := n >> 1
+
+	polyLDLmvFFT(tmp, tree, g0, g1, g0, logn)
+
+	polySplitFFT(g1, g1[hn:], g0, logn)
+	polySplitFFT(g0, g0[hn:], tmp, logn)
+	ffLDLInner(tree[n:], g1, g1[hn:], tmp, logn-1)
+	ffLDLInner(tree[n+treeSize(logn-1):], g0, g0[hn:], tmp, logn-1)
+}
+
+// ffLDLNormalize replaces the leaves d of the tree with sqrt(d)/sigma, the
+// inverse of the standard deviation to sample with.
+func ffLDLNormalize(tree []fpr, origLogn, logn uint) {
+	n := 1 << logn
+	if n == 1 {
+		tree[0] = fprMul(fprSqrt(tree[0]), fprInvSigma[origLogn])
+		return
+	}
+	ffLDLNormalize(tree[n:], origLogn, logn-1)
+	ffLDLNormalize(tree[n+treeSize(logn-1):], origLogn, logn-1)
+}
+
+// ffSampling samples (z0, z1) close to the target (t0, t1) with the
+// normalized LDL tree, in FFT form.  It requires logn >= 1, and tmp must
+// have room for 2*2^logn elements.
+func (s *sampler) ffSampling(z0, z1, tree, t0, t1, tmp []fpr, logn uint) {
+	if logn == 1 {
+		x0, x1 := t1[0], t1[1]
+		sigma := tree[3]
+		w0 := fprOf(s.sample(x0, sigma))
+		w1 := fprOf(s.sample(x1, sigma))
+		z1[0], z1[1] = w0, w1
+
+		cRe, cIm := fpcMul(fprSub(x0, w0), fprSub(x1, w1), tree[0], tree[1])
+		x0, x1 = fprAdd(cRe, t0[0]), fprAdd(cIm, t0[1])
+		sigma = tree[2]
+		z0[0] = fprOf(s.sample(x0, sigma))
+		z0[1] = fprOf(s.sample(x1, sigma))
+		return
+	}
+
+	n := 1 << logn
+	hn := n >> 1
+	tree0 := tree[n:]
+	tree1 := tree[n+treeSize(logn-1):]
+
+	// Sample z1 with the right subtree.
+	polySplitFFT(z1, z1[hn:], t1, logn)
+	s.ffSampling(tmp, tmp[hn:], tree1, z1, z1[hn:], tmp[n:], logn-1)
+	polyMergeFFT(z1, tmp, tmp[hn:], logn)
+
+	// Update the target t0 to t0 + (t1 - z1)*l10, and sample z0 with the
+	// left subtree.
+	copy(tmp, t1[:n])
+	polySub(tmp, z1, logn)
+	polyMulFFT(tmp, tree, logn)
+	polyAdd(tmp, t0, logn)
+	polySplitFFT(z0, z0[hn:], tmp, logn)
+	s.ffSampling(tmp, tmp[hn:], tree0, z0, z0[hn:], tmp[n:], logn-1)
+	polyMergeFFT(z0, tmp, tmp[hn:], logn)
+}
@@ -0,0 +1,114 @@
+package falcon
+
+// Code to generate the NIST "PQCsignKAT" test vectors.
+// See PQCgenKAT_sign.c and katrng.c in the reference implementation.
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/nist"
+	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/falcon/falcon1024"
+	"github.com/cloudflare/circl/sign/falcon/falcon512"
+	"github.com/cloudflare/circl/sign/falcon/internal"
+)
+
+// Signing functions taking the source of randomness, which the generic
+// API does not expose.
+type katMode struct {
+	scheme sign.Scheme
+	sign   func(sk sign.PrivateKey, msg []byte, g *nist.DRBG) ([]byte, error)
+}
+
+var katModes = map[string]katMode{
+	"Falcon-512": {
+		falcon512.Scheme(),
+		func(sk sign.PrivateKey, msg []byte, g *nist.DRBG) ([]byte, error) {
+			sig := make([]byte, falcon512.SignatureSize)
+			err := falcon512.SignTo(sk.(*falcon512.PrivateKey), msg, g, sig)
+			return sig, err
+		},
+	},
+	"Falcon-1024": {
+		falcon1024.Scheme(),
+		func(sk sign.PrivateKey, msg []byte, g *nist.DRBG) ([]byte, error) {
+			sig := make([]byte, falcon1024.SignatureSize)
+			err := falcon1024.SignTo(sk.(*falcon1024.PrivateKey), msg, g, sig)
+			return sig, err
+		},
+	},
+}
+
+// TestKATRegression checks the digests of the test vectors generated by
+// this package in the format of PQCgenKAT_sign.  They are not taken from the
+// reference implementation, and only catch changes to the output of this
+// package.
+func TestKATRegression(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		want string
+	}{
+		{"Falcon-512", "64ee2bdc94df8b030efe65506ada8da7f0dbb4ebde1e159770f085cf294165c6"},
+		{"Falcon-1024", "ce06af3d934523f2bbdad7e98b8cd7e7be6abc4db81d60344adc973ca282626b"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if testing.Short() && tc.name == "Falcon-1024" {
+				t.Skip("key generation is too slow for -short")
+			}
+			mode := katModes[tc.name]
+			var seed [48]byte
+			var eseed [48]byte
+			for i := 0; i < 48; i++ {
+				seed[i] = byte(i)
+			}
+			f := sha256.New()
+			g := nist.NewDRBG(&seed)
+			fmt.Fprintf(f, "# %s\n\n", tc.name)
+			for i := 0; i < 100; i++ {
+				mlen := 33 * (i + 1)
+				g.Fill(seed[:])
+				msg := make([]byte, mlen)
+				g.Fill(msg[:])
+
+				fmt.Fprintf(f, "count = %d\n", i)
+				fmt.Fprintf(f, "seed = %X\n", seed)
+				fmt.Fprintf(f, "mlen = %d\n", mlen)
+				fmt.Fprintf(f, "msg = %X\n", msg)
+
+				g2 := nist.NewDRBG(&seed)
+				g2.Fill(eseed[:])
+				pk, sk := mode.scheme.DeriveKey(eseed[:])
+				ppk, _ := pk.MarshalBinary()
+				psk, _ := sk.MarshalBinary()
+
+				fmt.Fprintf(f, "pk = %X\n", ppk)
+				fmt.Fprintf(f, "sk = %X\n", psk)
+
+				sig, err := mode.sign(sk, msg, &g2)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !mode.scheme.Verify(pk, msg, sig, nil) {
+					t.Fatal()
+				}
+
+				// The reference outputs compressed signatures, as
+				// sig_len || nonce || msg || esig with sig_len the length
+				// of esig in two bytes big-endian.  The compressed
+				// encoding is the padded one without the trailing zeros,
+				// as its last bit is always set.
+				nonce := sig[1 : 1+internal.NonceSize]
+				body := bytes.TrimRight(sig[1+internal.NonceSize:], "\x00")
+				esig := append([]byte{sig[0] - 0x10}, body...)
+				fmt.Fprintf(f, "smlen = %d\n", 2+len(nonce)+mlen+len(esig))
+				fmt.Fprintf(f, "sm = %04X%X%X%X\n\n", len(esig), nonce, msg, esig)
+			}
+			if fmt.Sprintf("%x", f.Sum(nil)) != tc.want {
+				t.Fatal()
+			}
+		})
+	}
+}
@@ -1,4 +1,4 @@
-//go:generate go run src.go -out ../amd64.s -stubs ../stubs_amd64.go -pkg common
+//go:generate go run src.go -out ../amd64.s -stubs ../stubs_amd64.go -pkg dilithium
 
 // AVX2 optimized version of Poly.[Inv]NTT().  See the comments on the generic
 // implementation for details on the maths involved.
@@ -9,7 +9,7 @@ import (
 	. "github.com/mmcloughlin/avo/operand" // nolint:golint,stylecheck
 	. "github.com/mmcloughlin/avo/reg"     // nolint:golint,stylecheck
 
-	"github.com/cloudflare/circl/sign/dilithium/internal/common/params"
+	"github.com/cloudflare/circl/sign/internal/dilithium/params"
 )
 
 // XXX align Poly on 16 bytes such that we can use aligned moves
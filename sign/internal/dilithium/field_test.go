@@ -1,4 +1,4 @@
-package common
+package dilithium
 
 import (
 	"crypto/rand"
@@ -0,0 +1,51 @@
+package dilithium
+
+import (
+	"crypto"
+	"io"
+)
+
+// DER encodings of the OIDs of the hash functions allowed for HashML-DSA,
+// see §5.4 of FIPS 204.  SHAKE128 and SHAKE256 are also allowed, but are
+// not available as a crypto.Hash.
+var preHashOids = map[crypto.Hash][]byte{
+	crypto.SHA256:     preHashOid(0x01),
+	crypto.SHA384:     preHashOid(0x02),
+	crypto.SHA512:     preHashOid(0x03),
+	crypto.SHA224:     preHashOid(0x04),
+	crypto.SHA512_224: preHashOid(0x05),
+	crypto.SHA512_256: preHashOid(0x06),
+	crypto.SHA3_224:   preHashOid(0x07),
+	crypto.SHA3_256:   preHashOid(0x08),
+	crypto.SHA3_384:   preHashOid(0x09),
+	crypto.SHA3_512:   preHashOid(0x0a),
+}
+
+// Returns the DER encoding of the OID 2.16.840.1.101.3.4.2.n.
+func preHashOid(n byte) []byte {
+	return []byte{0x06, 0x09, 0x60, 0x86, 0x48, 0x01, 0x65, 0x03, 0x04, 0x02, n}
+}
+
+// PreHashOid returns the DER encoding of the OID of h as used in the
+// messages of HashML-DSA, or nil if h is not allowed.
+func PreHashOid(h crypto.Hash) []byte {
+	return preHashOids[h]
+}
+
+// WriteMessage writes the formatted message M' = 0 ‖ |ctx| ‖ ctx ‖ msg
+// of ML-DSA to w.  Assumes ctx is at most 255 bytes.
+func WriteMessage(w io.Writer, msg, ctx []byte) {
+	_, _ = w.Write([]byte{0, byte(len(ctx))})
+	_, _ = w.Write(ctx)
+	_, _ = w.Write(msg)
+}
+
+// WritePreHashMessage writes the formatted message
+// M' = 1 ‖ |ctx| ‖ ctx ‖ oid ‖ digest of HashML-DSA to w.  Assumes ctx is at
+// most 255 bytes.
+func WritePreHashMessage(w io.Writer, digest, oid, ctx []byte) {
+	_, _ = w.Write([]byte{1, byte(len(ctx))})
+	_, _ = w.Write(ctx)
+	_, _ = w.Write(oid)
+	_, _ = w.Write(digest)
+}
@@ -0,0 +1,22 @@
+// Package mldsa implements the post-quantum signature scheme ML-DSA as
+// defined in FIPS 204
+//
+//  https://nvlpubs.nist.gov/nistpubs/FIPS/NIST.FIPS.204.pdf
+//
+// ML-DSA is the standardized version of CRYSTALS-Dilithium, which is
+// available in the package github.com/cloudflare/circl/sign/dilithium.
+// The two are not interoperable: ML-DSA adds domain separation to key
+// generation, uses a longer hash of the public key and a longer commitment
+// hash for the higher security levels, and signs messages together with a
+// context string, either directly or as a digest (HashML-DSA).
+//
+// Each of the three parameter sets of ML-DSA is implemented by a
+// subpackage.  For instance, ML-DSA-44 can be found in
+//
+//  github.com/cloudflare/circl/sign/mldsa/mldsa44
+//
+// The packages are generated by ../dilithium/gen.go.  To choose a scheme
+// at runtime, use the generic signatures API under
+//
+//  github.com/cloudflare/circl/sign/schemes
+package mldsa
@@ -0,0 +1,153 @@
+package mldsa
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/sign"
+	common "github.com/cloudflare/circl/sign/internal/dilithium"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/schemes"
+)
+
+var names = []string{"ML-DSA-44", "ML-DSA-65", "ML-DSA-87"}
+
+func TestContext(t *testing.T) {
+	msg := []byte("message")
+	for _, name := range names {
+		scheme := schemes.ByName(name)
+		pk, sk, err := scheme.GenerateKey()
+		test.CheckNoErr(t, err, "keygen failed")
+
+		if !scheme.SupportsContext() {
+			t.Fatalf("%v: context not supported", name)
+		}
+
+		sig := scheme.Sign(sk, msg, nil)
+		if !scheme.Verify(pk, msg, sig, &sign.SignatureOpts{}) {
+			t.Fatalf("%v: nil and empty contexts differ", name)
+		}
+		if scheme.Verify(pk, msg, append(sig, 0), nil) {
+			t.Fatalf("%v: signature with trailing data accepted", name)
+		}
+
+		opts := &sign.SignatureOpts{Context: strings.Repeat("c", 255)}
+		sig = scheme.Sign(sk, msg, opts)
+		if !scheme.Verify(pk, msg, sig, opts) {
+			t.Fatalf("%v: longest context rejected", name)
+		}
+		if scheme.Verify(pk, msg, sig, nil) {
+			t.Fatalf("%v: context ignored", name)
+		}
+
+		opts.Context += "c"
+		if scheme.Verify(pk, msg, sig, opts) {
+			t.Fatalf("%v: too long context accepted", name)
+		}
+		err = test.CheckPanic(func() { scheme.Sign(sk, msg, opts) })
+		test.CheckNoErr(t, err, "too long context did not panic")
+	}
+}
+
+func TestPreHash(t *testing.T) {
+	msg := []byte("message")
+	ctx := []byte("context")
+	digest := sha256.Sum256(msg)
+
+	pk, sk, err := mldsa44.GenerateKey(nil)
+	test.CheckNoErr(t, err, "keygen failed")
+
+	var sig [mldsa44.SignatureSize]byte
+	err = mldsa44.SignHashTo(sk, digest[:], crypto.SHA256, ctx, true, sig[:])
+	test.CheckNoErr(t, err, "SignHashTo failed")
+	if !mldsa44.VerifyHash(pk, digest[:], crypto.SHA256, ctx, sig[:]) {
+		t.Fatal("HashML-DSA signature rejected")
+	}
+	if mldsa44.VerifyHash(pk, digest[:], crypto.SHA256, nil, sig[:]) {
+		t.Fatal("HashML-DSA context ignored")
+	}
+	if mldsa44.Verify(pk, digest[:], ctx, sig[:]) {
+		t.Fatal("HashML-DSA signature accepted as ML-DSA signature")
+	}
+
+	// The hash function is part of the signed message.
+	digest2 := sha512.Sum512_256(msg)
+	if mldsa44.VerifyHash(pk, digest2[:], crypto.SHA512_256, ctx, sig[:]) {
+		t.Fatal("hash function ignored")
+	}
+
+	// crypto.Signer signs digests with HashML-DSA and an empty context.
+	sig2, err := sk.Sign(nil, digest[:], crypto.SHA256)
+	test.CheckNoErr(t, err, "Sign failed")
+	if !mldsa44.VerifyHash(pk, digest[:], crypto.SHA256, nil, sig2) {
+		t.Fatal("crypto.Signer signature rejected")
+	}
+	sig2, err = sk.Sign(nil, msg, crypto.Hash(0))
+	test.CheckNoErr(t, err, "Sign failed")
+	if !mldsa44.Verify(pk, msg, nil, sig2) {
+		t.Fatal("crypto.Signer signature rejected")
+	}
+
+	err = mldsa44.SignHashTo(sk, digest[:], crypto.MD5, nil, false, sig[:])
+	test.CheckIsErr(t, err, "unsupported hash function accepted")
+	err = mldsa44.SignHashTo(sk, digest[1:], crypto.SHA256, nil, false, sig[:])
+	test.CheckIsErr(t, err, "wrong digest size accepted")
+	err = mldsa44.SignHashTo(sk, digest[:], crypto.SHA256,
+		make([]byte, 256), false, sig[:])
+	if err != sign.ErrContextTooLong {
+		test.ReportError(t, err, sign.ErrContextTooLong)
+	}
+}
+
+// The OIDs of the hash functions are those of the NIST Computer Security
+// Objects Register, which FIPS 204 refers to.
+func TestPreHashOid(t *testing.T) {
+	for _, tc := range []struct {
+		h   crypto.Hash
+		oid string
+	}{
+		{crypto.SHA256, "0609608648016503040201"},     // 2.16.840.1.101.3.4.2.1
+		{crypto.SHA384, "0609608648016503040202"},     // 2.16.840.1.101.3.4.2.2
+		{crypto.SHA512, "0609608648016503040203"},     // 2.16.840.1.101.3.4.2.3
+		{crypto.SHA224, "0609608648016503040204"},     // 2.16.840.1.101.3.4.2.4
+		{crypto.SHA512_224, "0609608648016503040205"}, // 2.16.840.1.101.3.4.2.5
+		{crypto.SHA512_256, "0609608648016503040206"}, // 2.16.840.1.101.3.4.2.6
+		{crypto.SHA3_224, "0609608648016503040207"},   // 2.16.840.1.101.3.4.2.7
+		{crypto.SHA3_256, "0609608648016503040208"},   // 2.16.840.1.101.3.4.2.8
+		{crypto.SHA3_384, "0609608648016503040209"},   // 2.16.840.1.101.3.4.2.9
+		{crypto.SHA3_512, "060960864801650304020a"},   // 2.16.840.1.101.3.4.2.10
+		{crypto.MD5, ""},
+		{crypto.SHA1, ""},
+	} {
+		got := hex.EncodeToString(common.PreHashOid(tc.h))
+		if got != tc.oid {
+			test.ReportError(t, got, tc.oid, tc.h)
+		}
+	}
+}
+
+// The formatted messages M' of Algorithms 2 and 4 of FIPS 204.
+func TestMessageFormat(t *testing.T) {
+	var buf bytes.Buffer
+	common.WriteMessage(&buf, []byte("msg"), []byte("ctx"))
+	want := "00036374786d7367"
+	if got := hex.EncodeToString(buf.Bytes()); got != want {
+		test.ReportError(t, got, want)
+	}
+
+	buf.Reset()
+	digest := sha256.Sum256([]byte("msg"))
+	common.WritePreHashMessage(&buf, digest[:],
+		common.PreHashOid(crypto.SHA256), []byte("ctx"))
+	want = "0103637478" + "0609608648016503040201" +
+		hex.EncodeToString(digest[:])
+	if got := hex.EncodeToString(buf.Bytes()); got != want {
+		test.ReportError(t, got, want)
+	}
+}
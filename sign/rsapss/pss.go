@@ -0,0 +1,114 @@
+package rsapss
+
+import (
+	"encoding/binary"
+	"io"
+	"math/big"
+)
+
+// mgf1XOR xors out with the mask generated by MGF1 from seed, as in
+// RFC 8017, Appendix B.2.1.
+func mgf1XOR(p *params, out, seed []byte) {
+	var counter [4]byte
+	h := p.hash.New()
+	for done, i := 0, uint32(0); done < len(out); i++ {
+		binary.BigEndian.PutUint32(counter[:], i)
+		h.Reset()
+		_, _ = h.Write(seed)
+		_, _ = h.Write(counter[:])
+		for _, b := range h.Sum(nil) {
+			if done == len(out) {
+				break
+			}
+			out[done] ^= b
+			done++
+		}
+	}
+}
+
+// emsaPSSEncode returns the encoding EM of the digest mHash with the
+// given salt, as in RFC 8017, Section 9.1.1, for a modulus of p.bits bits.
+func emsaPSSEncode(p *params, mHash, salt []byte) []byte {
+	hLen := p.hash.Size()
+	emBits := p.bits - 1
+	emLen := (emBits + 7) / 8
+
+	// H = Hash(0x00^8 || mHash || salt)
+	h := p.hash.New()
+	_, _ = h.Write(make([]byte, 8))
+	_, _ = h.Write(mHash)
+	_, _ = h.Write(salt)
+	H := h.Sum(nil)
+
+	// EM = maskedDB || H || 0xbc, where DB = PS || 0x01 || salt
+	em := make([]byte, emLen)
+	db := em[:emLen-hLen-1]
+	db[len(db)-len(salt)-1] = 0x01
+	copy(db[len(db)-len(salt):], salt)
+	mgf1XOR(p, db, H)
+	db[0] &= 0xff >> uint(8*emLen-emBits)
+	copy(em[len(db):], H)
+	em[emLen-1] = 0xbc
+	return em
+}
+
+// smallPrimes are the odd primes below 2^12, which sieve the candidates of
+// derivePrime.
+var smallPrimes = func() []uint64 {
+	const bound = 1 << 12
+	var composite [bound]bool
+	var ps []uint64
+	for i := 3; i < bound; i += 2 {
+		if !composite[i] {
+			ps = append(ps, uint64(i))
+			for j := i * i; j < bound; j += i {
+				composite[j] = true
+			}
+		}
+	}
+	return ps
+}()
+
+// derivePrime returns a prime of the given size in bits, whose two most
+// significant bits are set and such that p-1 is coprime with the public
+// exponent.  It searches incrementally from starting points read from r,
+// skipping the candidates with small factors.
+func derivePrime(r io.Reader, bits int) *big.Int {
+	const maxDelta = 1 << 20
+	b := make([]byte, bits/8)
+	p := new(big.Int)
+	t, m := new(big.Int), new(big.Int)
+	mods := make([]uint64, len(smallPrimes))
+	for {
+		if _, err := io.ReadFull(r, b); err != nil {
+			panic(err)
+		}
+		b[0] |= 0xc0
+		b[len(b)-1] |= 1
+		p.SetBytes(b)
+		for i, sp := range smallPrimes {
+			mods[i] = t.Mod(p, m.SetUint64(sp)).Uint64()
+		}
+		modE := t.Mod(p, m.SetUint64(publicExponent)).Uint64()
+
+	next:
+		for delta := uint64(0); delta < maxDelta; delta += 2 {
+			if (modE+delta)%publicExponent == 1 {
+				continue
+			}
+			for i, sp := range smallPrimes {
+				if (mods[i]+delta)%sp == 0 {
+					continue next
+				}
+			}
+			t.SetUint64(delta)
+			t.Add(p, t)
+			if t.BitLen() != bits {
+				break
+			}
+			if t.ProbablyPrime(20) {
+				return t
+			}
+		}
+	}
+}
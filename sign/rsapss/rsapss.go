@@ -0,0 +1,456 @@
+// Package rsapss provides the signature scheme RSASSA-PSS of PKCS #1 as
+// adapters around crypto/rsa, for moduli of 2048, 3072 and 4096 bits.
+//
+// Each modulus size is used with the hash function of matching strength:
+// SHA-256, SHA-384 and SHA-512, respectively.  MGF1 uses the same hash
+// function, and salts are as long as its output, as required by TLS 1.3.
+// The public exponent is always 65537.  Public keys are encoded as their
+// big-endian modulus, and private keys as the concatenation p||q of their
+// big-endian prime factors, each of half the size of the modulus and the
+// largest first, so that keys and signatures have a fixed length.
+//
+// Signing uses crypto/rsa with a fresh random salt.  SignDeterministic
+// derives the salt from the private key and the message instead, which
+// gives reproducible signatures for tests.
+//
+// In X.509, keys and signatures of all schemes are identified by
+// id-RSASSA-PSS, with the RSASSA-PSS-params of the scheme as parameters.
+//
+// References:
+//   - RFC 8017: PKCS #1: RSA Cryptography Specifications Version 2.2.
+//     https://www.rfc-editor.org/rfc/rfc8017
+package rsapss
+
+import (
+	"crypto"
+	"crypto/hmac"
+	cryptoRand "crypto/rand"
+	"crypto/rsa"
+	_ "crypto/sha256" // Registers SHA-256.
+	_ "crypto/sha512" // Registers SHA-384 and SHA-512.
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"errors"
+	"io"
+	"math/big"
+
+	"github.com/cloudflare/circl/sign"
+)
+
+// ID identifies the supported parameter sets of RSA-PSS.
+// Note that the zero value is not a valid identifier.
+type ID byte
+
+const (
+	RSA2048 ID = iota + 1 // RSA-PSS-2048-SHA256
+	RSA3072               // RSA-PSS-3072-SHA384
+	RSA4096               // RSA-PSS-4096-SHA512
+	_MaxParams
+)
+
+// publicExponent is the public exponent of all keys.
+const publicExponent = 65537
+
+var (
+	// ErrParam is returned for an invalid ID, or an RSA key that is not
+	// supported.
+	ErrParam = errors.New("rsapss: invalid parameters")
+
+	// ErrInvalidPublicKey is returned when unpacking a modulus of the
+	// wrong size.
+	ErrInvalidPublicKey = errors.New("rsapss: invalid public key")
+
+	// ErrInvalidPrivateKey is returned when unpacking factors that do
+	// not form a valid private key.
+	ErrInvalidPrivateKey = errors.New("rsapss: invalid private key")
+)
+
+// params contains the constants of a parameter set.
+type params struct {
+	id    ID
+	name  string
+	bits  int // Size of the modulus in bits.
+	hash  crypto.Hash
+	tlsID uint // SignatureScheme rsa_pss_rsae_* of TLS 1.3.
+
+	// OID of the hash function.
+	hashOid asn1.ObjectIdentifier
+}
+
+var supportedParams = [_MaxParams - 1]params{
+	{
+		RSA2048, "RSA-PSS-2048-SHA256", 2048, crypto.SHA256, 0x0804,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1},
+	},
+	{
+		RSA3072, "RSA-PSS-3072-SHA384", 3072, crypto.SHA384, 0x0805,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 2},
+	},
+	{
+		RSA4096, "RSA-PSS-4096-SHA512", 4096, crypto.SHA512, 0x0806,
+		asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 3},
+	},
+}
+
+var (
+	// oidRSASSAPSS is id-RSASSA-PSS of RFC 8017.
+	oidRSASSAPSS = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}
+
+	// oidMGF1 is id-mgf1 of RFC 8017.
+	oidMGF1 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 8}
+)
+
+// pssParameters is RSASSA-PSS-params of RFC 8017, without the trailerField,
+// which takes its default value.
+type pssParameters struct {
+	Hash       pkix.AlgorithmIdentifier `asn1:"explicit,tag:0"`
+	MGF        pkix.AlgorithmIdentifier `asn1:"explicit,tag:1"`
+	SaltLength int                      `asn1:"explicit,tag:2"`
+}
+
+// IsValid returns true if the identifier is supported.
+func (id ID) IsValid() bool { return 0 < id && id < _MaxParams }
+
+func (id ID) String() string {
+	if !id.IsValid() {
+		return ErrParam.Error()
+	}
+	return supportedParams[id-1].name
+}
+
+func (id ID) params() *params {
+	if !id.IsValid() {
+		panic(ErrParam)
+	}
+	return &supportedParams[id-1]
+}
+
+// Bits returns the size of the modulus in bits.
+func (id ID) Bits() int { return id.params().bits }
+
+// Hash returns the hash function applied to messages before signing.
+func (id ID) Hash() crypto.Hash { return id.params().hash }
+
+// Oid returns the object identifier id-RSASSA-PSS, which is shared by all
+// parameter sets.
+func (id ID) Oid() asn1.ObjectIdentifier { return oidRSASSAPSS }
+
+// AlgorithmIdentifier returns id-RSASSA-PSS with the RSASSA-PSS-params of
+// the parameter set, which identifies both its keys and its signatures in
+// X.509.  The hash function is used for MGF1 as well, and the salt is as
+// long as its output.
+func (id ID) AlgorithmIdentifier() pkix.AlgorithmIdentifier {
+	p := id.params()
+	hash := pkix.AlgorithmIdentifier{
+		Algorithm:  p.hashOid,
+		Parameters: asn1.NullRawValue,
+	}
+	mgfHash, _ := asn1.Marshal(hash)
+	params, _ := asn1.Marshal(pssParameters{
+		Hash: hash,
+		MGF: pkix.AlgorithmIdentifier{
+			Algorithm:  oidMGF1,
+			Parameters: asn1.RawValue{FullBytes: mgfHash},
+		},
+		SaltLength: p.hash.Size(),
+	})
+	return pkix.AlgorithmIdentifier{
+		Algorithm:  oidRSASSAPSS,
+		Parameters: asn1.RawValue{FullBytes: params},
+	}
+}
+
+// PublicKeySize returns the size of packed public keys.
+func (id ID) PublicKeySize() int { return id.params().bits / 8 }
+
+// PrivateKeySize returns the size of packed private keys.
+func (id ID) PrivateKeySize() int { return id.params().bits / 8 }
+
+// SignatureSize returns the size of signatures.
+func (id ID) SignatureSize() int { return id.params().bits / 8 }
+
+func (p *params) digest(message []byte) []byte {
+	h := p.hash.New()
+	_, _ = h.Write(message)
+	return h.Sum(nil)
+}
+
+func (p *params) pssOptions() *rsa.PSSOptions {
+	return &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash, Hash: p.hash}
+}
+
+// PublicKey is the type of RSA-PSS public keys.
+type PublicKey struct {
+	id ID
+	pk rsa.PublicKey
+}
+
+// PrivateKey is the type of RSA-PSS private keys.
+type PrivateKey struct {
+	id ID
+	sk rsa.PrivateKey
+}
+
+// NewPublicKey wraps a public key of crypto/rsa, whose modulus must be of
+// a supported size and whose public exponent must be 65537.
+func NewPublicKey(pk *rsa.PublicKey) (*PublicKey, error) {
+	for i := range supportedParams {
+		p := &supportedParams[i]
+		if pk.N.BitLen() == p.bits && pk.E == publicExponent {
+			return &PublicKey{id: p.id, pk: rsa.PublicKey{
+				N: new(big.Int).Set(pk.N),
+				E: publicExponent,
+			}}, nil
+		}
+	}
+	return nil, ErrParam
+}
+
+// NewPrivateKey wraps a private key of crypto/rsa, whose modulus must be
+// of a supported size and whose public exponent must be 65537.  It must
+// have two prime factors of half the size of the modulus, as those
+// generated by crypto/rsa.
+func NewPrivateKey(sk *rsa.PrivateKey) (*PrivateKey, error) {
+	pk, err := NewPublicKey(&sk.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(sk.Primes) != 2 {
+		return nil, ErrParam
+	}
+	return newPrivateKey(pk.id, sk.Primes[0], sk.Primes[1])
+}
+
+// newPrivateKey returns the private key of factors p and q.
+func newPrivateKey(id ID, p, q *big.Int) (*PrivateKey, error) {
+	bits := id.params().bits
+	if p.Cmp(q) < 0 {
+		p, q = q, p
+	}
+	if p.BitLen() > bits/2 || q.Cmp(big.NewInt(1)) <= 0 || p.Cmp(q) == 0 {
+		return nil, ErrInvalidPrivateKey
+	}
+	n := new(big.Int).Mul(p, q)
+	if n.BitLen() != bits {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	// d = e^-1 mod (p-1)(q-1)
+	one := big.NewInt(1)
+	pm1 := new(big.Int).Sub(p, one)
+	qm1 := new(big.Int).Sub(q, one)
+	phi := new(big.Int).Mul(pm1, qm1)
+	d := new(big.Int).ModInverse(big.NewInt(publicExponent), phi)
+	if d == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	sk := &PrivateKey{id: id, sk: rsa.PrivateKey{
+		PublicKey: rsa.PublicKey{N: n, E: publicExponent},
+		D:         d,
+		Primes:    []*big.Int{new(big.Int).Set(p), new(big.Int).Set(q)},
+	}}
+	if err := sk.sk.Validate(); err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	sk.sk.Precompute()
+	return sk, nil
+}
+
+// GenerateKey generates a public/private key pair using entropy from rand.
+// If rand is nil, crypto/rand.Reader will be used.
+func GenerateKey(rand io.Reader, id ID) (*PublicKey, *PrivateKey, error) {
+	if !id.IsValid() {
+		return nil, nil, ErrParam
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	k, err := rsa.GenerateKey(rand, id.params().bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	sk, err := newPrivateKey(id, k.Primes[0], k.Primes[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	return sk.PublicKey(), sk, nil
+}
+
+// RSA returns the public key as a public key of crypto/rsa.
+func (pk *PublicKey) RSA() *rsa.PublicKey {
+	return &rsa.PublicKey{N: new(big.Int).Set(pk.pk.N), E: pk.pk.E}
+}
+
+// RSA returns the private key as a private key of crypto/rsa.
+func (sk *PrivateKey) RSA() *rsa.PrivateKey {
+	k, _ := newPrivateKey(sk.id, sk.sk.Primes[0], sk.sk.Primes[1])
+	return &k.sk
+}
+
+// ID returns the identifier of the parameter set of the key.
+func (pk *PublicKey) ID() ID { return pk.id }
+
+// ID returns the identifier of the parameter set of the key.
+func (sk *PrivateKey) ID() ID { return sk.id }
+
+// PublicKey returns the public key corresponding to this private key.
+func (sk *PrivateKey) PublicKey() *PublicKey {
+	return &PublicKey{id: sk.id, pk: sk.sk.PublicKey}
+}
+
+// Public returns the public key corresponding to this private key.
+//
+// Returns a *PublicKey.  The type crypto.PublicKey is used to make
+// PrivateKey implement the crypto.Signer interface.
+func (sk *PrivateKey) Public() crypto.PublicKey { return sk.PublicKey() }
+
+// SignDigest signs the digest of a message with a salt read from rand, and
+// returns the signature.  If rand is nil, crypto/rand.Reader will be used.
+func SignDigest(rand io.Reader, sk *PrivateKey, digest []byte) ([]byte, error) {
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	p := sk.id.params()
+	return rsa.SignPSS(rand, &sk.sk, p.hash, digest, p.pssOptions())
+}
+
+// Sign hashes and signs the message with a salt read from rand, and
+// returns the signature.  If rand is nil, crypto/rand.Reader will be used.
+func Sign(rand io.Reader, sk *PrivateKey, message []byte) ([]byte, error) {
+	return SignDigest(rand, sk, sk.id.params().digest(message))
+}
+
+// SignDeterministic hashes and signs the message with a salt derived from
+// the private key and the message, and returns the signature.
+//
+// Unlike Sign, it does not run in constant time, so it is intended for
+// tests and reproducible outputs rather than for signing with long-term
+// keys.
+func SignDeterministic(sk *PrivateKey, message []byte) []byte {
+	p := sk.id.params()
+	mHash := p.digest(message)
+	key, _ := sk.MarshalBinary()
+	mac := hmac.New(p.hash.New, key)
+	_, _ = mac.Write(mHash)
+	salt := mac.Sum(nil)
+
+	em := emsaPSSEncode(p, mHash, salt)
+	m := new(big.Int).SetBytes(em)
+	s := m.Exp(m, sk.sk.D, sk.sk.N)
+	return s.FillBytes(make([]byte, p.bits/8))
+}
+
+// VerifyDigest checks whether the signature by pk on the digest of a
+// message is valid.
+func VerifyDigest(pk *PublicKey, digest, signature []byte) bool {
+	p := pk.id.params()
+	return len(signature) == p.bits/8 &&
+		rsa.VerifyPSS(&pk.pk, p.hash, digest, signature, p.pssOptions()) == nil
+}
+
+// Verify checks whether the signature by pk on the message is valid.
+func Verify(pk *PublicKey, message, signature []byte) bool {
+	return VerifyDigest(pk, pk.id.params().digest(message), signature)
+}
+
+// Sign signs the given digest of a message, which must have been computed
+// with opts.HashFunc(), with a salt read from rand.  If opts is a
+// *rsa.PSSOptions, its salt length is used, otherwise that of the scheme.
+// If rand is nil, crypto/rand.Reader will be used.
+//
+// This function is used to make PrivateKey implement the crypto.Signer
+// interface.  Unlike that of *rsa.PrivateKey, it always produces PSS
+// signatures.
+func (sk *PrivateKey) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) (
+	signature []byte, err error) {
+	if opts == nil {
+		return nil, errors.New("rsapss: missing hash function")
+	}
+	if rand == nil {
+		rand = cryptoRand.Reader
+	}
+	pssOpts, ok := opts.(*rsa.PSSOptions)
+	if !ok {
+		pssOpts = &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       opts.HashFunc(),
+		}
+	}
+	return rsa.SignPSS(rand, &sk.sk, opts.HashFunc(), digest, pssOpts)
+}
+
+// Equal returns whether the two private keys are equal.
+func (sk *PrivateKey) Equal(other crypto.PrivateKey) bool {
+	castOther, ok := other.(*PrivateKey)
+	if !ok {
+		return false
+	}
+	return sk.id == castOther.id &&
+		sk.sk.Primes[0].Cmp(castOther.sk.Primes[0]) == 0 &&
+		sk.sk.Primes[1].Cmp(castOther.sk.Primes[1]) == 0
+}
+
+// MarshalBinary returns the private key as the concatenation p||q of its
+// big-endian prime factors, with p > q.
+func (sk *PrivateKey) MarshalBinary() ([]byte, error) {
+	half := sk.id.PrivateKeySize() / 2
+	b := make([]byte, 2*half)
+	sk.sk.Primes[0].FillBytes(b[:half])
+	sk.sk.Primes[1].FillBytes(b[half:])
+	return b, nil
+}
+
+// UnmarshalBinary sets sk to the private key of the parameter set of
+// sk.ID() encoded as the concatenation p||q of its prime factors.
+func (sk *PrivateKey) UnmarshalBinary(data []byte) error {
+	if !sk.id.IsValid() {
+		return ErrParam
+	}
+	if len(data) != sk.id.PrivateKeySize() {
+		return sign.ErrPrivKeySize
+	}
+	half := len(data) / 2
+	p := new(big.Int).SetBytes(data[:half])
+	q := new(big.Int).SetBytes(data[half:])
+	if !p.ProbablyPrime(1) || !q.ProbablyPrime(1) {
+		return ErrInvalidPrivateKey
+	}
+	k, err := newPrivateKey(sk.id, p, q)
+	if err != nil {
+		return err
+	}
+	*sk = *k
+	return nil
+}
+
+// Equal returns whether the two public keys are equal.
+func (pk *PublicKey) Equal(other crypto.PublicKey) bool {
+	castOther, ok := other.(*PublicKey)
+	if !ok {
+		return false
+	}
+	return pk.id == castOther.id && pk.pk.N.Cmp(castOther.pk.N) == 0
+}
+
+// MarshalBinary returns the big-endian modulus of the public key.
+func (pk *PublicKey) MarshalBinary() ([]byte, error) {
+	return pk.pk.N.FillBytes(make([]byte, pk.id.PublicKeySize())), nil
+}
+
+// UnmarshalBinary sets pk to the public key of the parameter set of
+// pk.ID() with the given big-endian modulus.
+func (pk *PublicKey) UnmarshalBinary(data []byte) error {
+	if !pk.id.IsValid() {
+		return ErrParam
+	}
+	if len(data) != pk.id.PublicKeySize() {
+		return sign.ErrPubKeySize
+	}
+	n := new(big.Int).SetBytes(data)
+	if n.BitLen() != pk.id.params().bits || n.Bit(0) == 0 {
+		return ErrInvalidPublicKey
+	}
+	pk.pk = rsa.PublicKey{N: n, E: publicExponent}
+	return nil
+}
@@ -0,0 +1,132 @@
+package rsapss_test
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/hex"
+	"testing"
+
+	"github.com/cloudflare/circl/internal/test"
+	"github.com/cloudflare/circl/pki"
+	"github.com/cloudflare/circl/sign/rsapss"
+)
+
+func TestInterop(t *testing.T) {
+	for _, id := range []rsapss.ID{rsapss.RSA2048, rsapss.RSA3072} {
+		k, err := rsa.GenerateKey(rand.Reader, id.Bits())
+		test.CheckNoErr(t, err, "keygen failed")
+		sk, err := rsapss.NewPrivateKey(k)
+		test.CheckNoErr(t, err, "wrapping private key failed")
+		test.CheckOk(sk.ID() == id, "wrong parameter set", t)
+		pk, err := rsapss.NewPublicKey(&k.PublicKey)
+		test.CheckNoErr(t, err, "wrapping public key failed")
+		test.CheckOk(sk.PublicKey().Equal(pk), "public keys differ", t)
+		k2 := sk.RSA()
+		test.CheckNoErr(t, k2.Validate(), "invalid private key")
+		test.CheckOk(k2.PublicKey.Equal(&k.PublicKey), "private key roundtrip failed", t)
+
+		msg := []byte("message")
+		h := id.Hash().New()
+		_, _ = h.Write(msg)
+		digest := h.Sum(nil)
+		opts := &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash}
+
+		// crypto/rsa verifies our signatures, and conversely.
+		sig := rsapss.SignDeterministic(sk, msg)
+		test.CheckOk(bytes.Equal(sig, rsapss.SignDeterministic(sk, msg)),
+			"signing is not deterministic", t)
+		for _, s := range [][]byte{sig, id.Scheme().Sign(sk, msg, nil)} {
+			test.CheckOk(len(s) == id.SignatureSize(), "wrong signature size", t)
+			err = rsa.VerifyPSS(&k.PublicKey, id.Hash(), digest, s, opts)
+			test.CheckNoErr(t, err, "crypto/rsa rejected")
+		}
+		sig, err = rsa.SignPSS(rand.Reader, k, id.Hash(), digest, opts)
+		test.CheckNoErr(t, err, "sign failed")
+		test.CheckOk(rsapss.Verify(pk, msg, sig), "crypto/rsa signature rejected", t)
+
+		// Other salt lengths are rejected.
+		sig, err = rsa.SignPSS(rand.Reader, k, id.Hash(), digest,
+			&rsa.PSSOptions{SaltLength: 20})
+		test.CheckNoErr(t, err, "sign failed")
+		test.CheckOk(!rsapss.Verify(pk, msg, sig), "wrong salt length accepted", t)
+
+		var signer crypto.Signer = sk
+		sig, err = signer.Sign(nil, digest, id.Hash())
+		test.CheckNoErr(t, err, "sign failed")
+		test.CheckOk(rsapss.VerifyDigest(pk, digest, sig), "verify failed", t)
+	}
+}
+
+func TestInvalidKeys(t *testing.T) {
+	k, err := rsa.GenerateKey(rand.Reader, 1024)
+	test.CheckNoErr(t, err, "keygen failed")
+	_, err = rsapss.NewPrivateKey(k)
+	test.CheckIsErr(t, err, "1024-bit key accepted")
+
+	sch := rsapss.RSA2048.Scheme()
+	_, sk, err := sch.GenerateKey()
+	test.CheckNoErr(t, err, "keygen failed")
+	enc, _ := sk.MarshalBinary()
+
+	// The factors are swapped to the canonical order.
+	half := len(enc) / 2
+	swapped := append(append([]byte{}, enc[half:]...), enc[:half]...)
+	sk2, err := sch.UnmarshalBinaryPrivateKey(swapped)
+	test.CheckNoErr(t, err, "unmarshal failed")
+	test.CheckOk(sk2.Equal(sk), "swapped factors give another key", t)
+
+	bad := append([]byte{}, enc...)
+	bad[len(bad)-1] ^= 2
+	_, err = sch.UnmarshalBinaryPrivateKey(bad)
+	test.CheckIsErr(t, err, "composite factor accepted")
+	_, err = sch.UnmarshalBinaryPrivateKey(enc[1:])
+	test.CheckIsErr(t, err, "short key accepted")
+
+	_, err = sch.UnmarshalBinaryPublicKey(make([]byte, sch.PublicKeySize()))
+	test.CheckIsErr(t, err, "zero modulus accepted")
+}
+
+func BenchmarkRSAPSS(b *testing.B) {
+	_, sk, _ := rsapss.GenerateKey(nil, rsapss.RSA2048)
+	pk := sk.PublicKey()
+	msg := []byte("message")
+	sig := rsapss.SignDeterministic(sk, msg)
+
+	b.Run("Sign", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, _ = rsapss.Sign(nil, sk, msg)
+		}
+	})
+	b.Run("Verify", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_ = rsapss.Verify(pk, msg, sig)
+		}
+	})
+}
+
+// The RSASSA-PSS-params are those that crypto/x509 writes in certificates.
+func TestAlgorithmIdentifier(t *testing.T) {
+	for _, tc := range []struct {
+		id     rsapss.ID
+		params string
+	}{
+		{rsapss.RSA2048, "3034a00f300d06096086480165030402010500a11c301a06092a864886f70d010108300d06096086480165030402010500a203020120"},
+		{rsapss.RSA3072, "3034a00f300d06096086480165030402020500a11c301a06092a864886f70d010108300d06096086480165030402020500a203020130"},
+		{rsapss.RSA4096, "3034a00f300d06096086480165030402030500a11c301a06092a864886f70d010108300d06096086480165030402030500a203020140"},
+	} {
+		ai := tc.id.AlgorithmIdentifier()
+		if !ai.Algorithm.Equal(asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 10}) {
+			t.Fatalf("%v: wrong OID %v", tc.id, ai.Algorithm)
+		}
+		want, _ := hex.DecodeString(tc.params)
+		if !bytes.Equal(ai.Parameters.FullBytes, want) {
+			test.ReportError(t, ai.Parameters.FullBytes, want, tc.id)
+		}
+		if pki.SchemeByAlgorithmIdentifier(ai) != tc.id.Scheme() {
+			t.Fatalf("%v: not found by AlgorithmIdentifier", tc.id)
+		}
+	}
+}
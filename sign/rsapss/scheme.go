@@ -0,0 +1,114 @@
+package rsapss
+
+import (
+	"crypto/rand"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+
+	"github.com/cloudflare/circl/internal/sha3"
+	"github.com/cloudflare/circl/sign"
+)
+
+// Scheme returns a signature interface for the parameter set of the
+// identifier.
+//
+// The scheme implements pki.AlgorithmIdentifierScheme, as the parameter
+// sets only differ by the parameters of id-RSASSA-PSS.
+func (id ID) Scheme() sign.Scheme { return scheme{id.params()} }
+
+type scheme struct{ *params }
+
+func (s scheme) Name() string               { return s.name }
+func (s scheme) PublicKeySize() int         { return s.id.PublicKeySize() }
+func (s scheme) PrivateKeySize() int        { return s.id.PrivateKeySize() }
+func (s scheme) SignatureSize() int         { return s.id.SignatureSize() }
+func (s scheme) SeedSize() int              { return 32 }
+func (s scheme) TLSIdentifier() uint        { return s.tlsID }
+func (s scheme) SupportsContext() bool      { return false }
+func (s scheme) Oid() asn1.ObjectIdentifier { return oidRSASSAPSS }
+
+func (s scheme) KeyAlgorithmIdentifier() pkix.AlgorithmIdentifier {
+	return s.id.AlgorithmIdentifier()
+}
+
+func (s scheme) SignatureAlgorithmIdentifier() pkix.AlgorithmIdentifier {
+	return s.id.AlgorithmIdentifier()
+}
+
+func (s scheme) GenerateKey() (sign.PublicKey, sign.PrivateKey, error) {
+	return GenerateKey(rand.Reader, s.id)
+}
+
+func (s scheme) Sign(
+	sk sign.PrivateKey,
+	message []byte,
+	opts *sign.SignatureOpts,
+) []byte {
+	priv, ok := sk.(*PrivateKey)
+	if !ok || priv.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	sig, err := Sign(rand.Reader, priv, message)
+	if err != nil {
+		panic(err)
+	}
+	return sig
+}
+
+func (s scheme) Verify(
+	pk sign.PublicKey,
+	message, signature []byte,
+	opts *sign.SignatureOpts,
+) bool {
+	pub, ok := pk.(*PublicKey)
+	if !ok || pub.id != s.id {
+		panic(sign.ErrTypeMismatch)
+	}
+	if opts != nil && opts.Context != "" {
+		panic(sign.ErrContextNotSupported)
+	}
+	return Verify(pub, message, signature)
+}
+
+// DeriveKey deterministically derives a pair of keys from a seed.  The
+// prime factors are the first suitable candidates read from the output of
+// SHAKE-256 on the seed, which makes it much slower than deriving keys of
+// elliptic-curve schemes.
+//
+// Panics if seed is not of length SeedSize().
+func (s scheme) DeriveKey(seed []byte) (sign.PublicKey, sign.PrivateKey) {
+	if len(seed) != s.SeedSize() {
+		panic(sign.ErrSeedSize)
+	}
+	h := sha3.NewShake256()
+	_, _ = h.Write(seed)
+	for {
+		p := derivePrime(&h, s.bits/2)
+		q := derivePrime(&h, s.bits/2)
+		if sk, err := newPrivateKey(s.id, p, q); err == nil {
+			return sk.PublicKey(), sk
+		}
+	}
+}
+
+func (s scheme) UnmarshalBinaryPublicKey(buf []byte) (sign.PublicKey, error) {
+	pk := &PublicKey{id: s.id}
+	if err := pk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return pk, nil
+}
+
+func (s scheme) UnmarshalBinaryPrivateKey(buf []byte) (sign.PrivateKey, error) {
+	sk := &PrivateKey{id: s.id}
+	if err := sk.UnmarshalBinary(buf); err != nil {
+		return nil, err
+	}
+	return sk, nil
+}
+
+func (pk *PublicKey) Scheme() sign.Scheme  { return pk.id.Scheme() }
+func (sk *PrivateKey) Scheme() sign.Scheme { return sk.id.Scheme() }
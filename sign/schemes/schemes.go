@@ -5,16 +5,63 @@
 //  Ed448
 //  Ed25519-Dilithium2
 //  Ed448-Dilithium3
+//  ECDSA-secp256k1-SHA256
+//  BIP340
+//  ECDSA-P256-SHA256
+//  ECDSA-P384-SHA384
+//  ECDSA-P521-SHA512
+//  RSA-PSS-2048-SHA256
+//  RSA-PSS-3072-SHA384
+//  RSA-PSS-4096-SHA512
+//  Dilithium2
+//  Dilithium2-AES
+//  Dilithium3
+//  Dilithium3-AES
+//  Dilithium5
+//  Dilithium5-AES
+//  ML-DSA-44
+//  ML-DSA-65
+//  ML-DSA-87
+//  SLH-DSA-SHA2-128s
+//  SLH-DSA-SHA2-128f
+//  SLH-DSA-SHA2-192s
+//  SLH-DSA-SHA2-192f
+//  SLH-DSA-SHA2-256s
+//  SLH-DSA-SHA2-256f
+//  SLH-DSA-SHAKE-128s
+//  SLH-DSA-SHAKE-128f
+//  SLH-DSA-SHAKE-192s
+//  SLH-DSA-SHAKE-192f
+//  SLH-DSA-SHAKE-256s
+//  SLH-DSA-SHAKE-256f
+//  Falcon-512
+//  Falcon-1024
 package schemes
 
 import (
 	"strings"
 
 	"github.com/cloudflare/circl/sign"
+	"github.com/cloudflare/circl/sign/bip340"
+	"github.com/cloudflare/circl/sign/dilithium/mode2"
+	"github.com/cloudflare/circl/sign/dilithium/mode2aes"
+	"github.com/cloudflare/circl/sign/dilithium/mode3"
+	"github.com/cloudflare/circl/sign/dilithium/mode3aes"
+	"github.com/cloudflare/circl/sign/dilithium/mode5"
+	"github.com/cloudflare/circl/sign/dilithium/mode5aes"
+	"github.com/cloudflare/circl/sign/ecdsa"
+	"github.com/cloudflare/circl/sign/ecdsa/secp256k1"
 	"github.com/cloudflare/circl/sign/ed25519"
 	"github.com/cloudflare/circl/sign/ed448"
 	"github.com/cloudflare/circl/sign/eddilithium2"
 	"github.com/cloudflare/circl/sign/eddilithium3"
+	"github.com/cloudflare/circl/sign/falcon/falcon1024"
+	"github.com/cloudflare/circl/sign/falcon/falcon512"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa44"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
+	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
+	"github.com/cloudflare/circl/sign/rsapss"
+	"github.com/cloudflare/circl/sign/slhdsa"
 )
 
 var allSchemes = [...]sign.Scheme{
@@ -22,6 +69,37 @@ var allSchemes = [...]sign.Scheme{
 	ed448.Scheme(),
 	eddilithium2.Scheme(),
 	eddilithium3.Scheme(),
+	secp256k1.Scheme(),
+	bip340.Scheme(),
+	ecdsa.P256.Scheme(),
+	ecdsa.P384.Scheme(),
+	ecdsa.P521.Scheme(),
+	rsapss.RSA2048.Scheme(),
+	rsapss.RSA3072.Scheme(),
+	rsapss.RSA4096.Scheme(),
+	mode2.Scheme(),
+	mode2aes.Scheme(),
+	mode3.Scheme(),
+	mode3aes.Scheme(),
+	mode5.Scheme(),
+	mode5aes.Scheme(),
+	mldsa44.Scheme(),
+	mldsa65.Scheme(),
+	mldsa87.Scheme(),
+	slhdsa.SHA2_128s.Scheme(),
+	slhdsa.SHA2_128f.Scheme(),
+	slhdsa.SHA2_192s.Scheme(),
+	slhdsa.SHA2_192f.Scheme(),
+	slhdsa.SHA2_256s.Scheme(),
+	slhdsa.SHA2_256f.Scheme(),
+	slhdsa.SHAKE_128s.Scheme(),
+	slhdsa.SHAKE_128f.Scheme(),
+	slhdsa.SHAKE_192s.Scheme(),
+	slhdsa.SHAKE_192f.Scheme(),
+	slhdsa.SHAKE_256s.Scheme(),
+	slhdsa.SHAKE_256f.Scheme(),
+	falcon512.Scheme(),
+	falcon1024.Scheme(),
 }
 
 var allSchemeNames map[string]sign.Scheme
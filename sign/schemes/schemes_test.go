@@ -1,9 +1,11 @@
 package schemes_test
 
 import (
+	"encoding/asn1"
 	"fmt"
 	"testing"
 
+	"github.com/cloudflare/circl/pki"
 	"github.com/cloudflare/circl/sign"
 	"github.com/cloudflare/circl/sign/schemes"
 )
@@ -104,10 +106,70 @@ func TestApi(t *testing.T) {
 			if sk.Scheme() != scheme {
 				t.Fatal()
 			}
+
+			seed := make([]byte, scheme.SeedSize())
+			for i := range seed {
+				seed[i] = byte(i)
+			}
+			pk3, sk3 := scheme.DeriveKey(seed)
+			pk4, sk4 := scheme.DeriveKey(seed)
+			if !pk3.Equal(pk4) || !sk3.Equal(sk4) {
+				t.Fatal()
+			}
+
+			if !scheme.SupportsContext() {
+				opts3 := &sign.SignatureOpts{Context: "A context"}
+				func() {
+					defer func() {
+						if recover() != sign.ErrContextNotSupported {
+							t.Fatal()
+						}
+					}()
+					scheme.Sign(sk, msg, opts3)
+				}()
+			}
 		})
 	}
 }
 
+func TestIdentifiers(t *testing.T) {
+	type oidScheme interface {
+		Oid() asn1.ObjectIdentifier
+	}
+	type tlsScheme interface {
+		TLSIdentifier() uint
+	}
+
+	oids := make(map[string]string)
+	tlsIDs := make(map[uint]string)
+	for _, scheme := range schemes.All() {
+		if s, ok := scheme.(pki.AlgorithmIdentifierScheme); ok {
+			// Such schemes may share an OID, but not its parameters.
+			ai := s.SignatureAlgorithmIdentifier()
+			oid := fmt.Sprintf("%v %x", ai.Algorithm, ai.Parameters.FullBytes)
+			if other, ok := oids[oid]; ok {
+				t.Fatalf("%s and %s share AlgorithmIdentifier %s",
+					scheme.Name(), other, oid)
+			}
+			oids[oid] = scheme.Name()
+		} else if s, ok := scheme.(oidScheme); ok {
+			oid := s.Oid().String()
+			if other, ok := oids[oid]; ok {
+				t.Fatalf("%s and %s share OID %s", scheme.Name(), other, oid)
+			}
+			oids[oid] = scheme.Name()
+		}
+		if s, ok := scheme.(tlsScheme); ok {
+			id := s.TLSIdentifier()
+			if other, ok := tlsIDs[id]; ok {
+				t.Fatalf("%s and %s share TLS identifier 0x%04x",
+					scheme.Name(), other, id)
+			}
+			tlsIDs[id] = scheme.Name()
+		}
+	}
+}
+
 func Example() {
 	for _, sch := range schemes.All() {
 		fmt.Println(sch.Name())
@@ -117,6 +179,37 @@ func Example() {
 	// Ed448
 	// Ed25519-Dilithium2
 	// Ed448-Dilithium3
+	// ECDSA-secp256k1-SHA256
+	// BIP340
+	// ECDSA-P256-SHA256
+	// ECDSA-P384-SHA384
+	// ECDSA-P521-SHA512
+	// RSA-PSS-2048-SHA256
+	// RSA-PSS-3072-SHA384
+	// RSA-PSS-4096-SHA512
+	// Dilithium2
+	// Dilithium2-AES
+	// Dilithium3
+	// Dilithium3-AES
+	// Dilithium5
+	// Dilithium5-AES
+	// ML-DSA-44
+	// ML-DSA-65
+	// ML-DSA-87
+	// SLH-DSA-SHA2-128s
+	// SLH-DSA-SHA2-128f
+	// SLH-DSA-SHA2-192s
+	// SLH-DSA-SHA2-192f
+	// SLH-DSA-SHA2-256s
+	// SLH-DSA-SHA2-256f
+	// SLH-DSA-SHAKE-128s
+	// SLH-DSA-SHAKE-128f
+	// SLH-DSA-SHAKE-192s
+	// SLH-DSA-SHAKE-192f
+	// SLH-DSA-SHAKE-256s
+	// SLH-DSA-SHAKE-256f
+	// Falcon-512
+	// Falcon-1024
 }
 
 func BenchmarkGenerateKeyPair(b *testing.B) {
@@ -0,0 +1,116 @@
+package slhdsa
+
+import (
+	"encoding/binary"
+
+	"github.com/cloudflare/circl/simd/keccakf1600"
+)
+
+// shakeX4Available indicates whether the SHAKE parameter sets compute four
+// WOTS+ chains in parallel using the four-way Keccak-f[1600] permutation.
+var shakeX4Available = keccakf1600.IsEnabledX4()
+
+// shake256Rate is the rate in bytes of SHAKE256.
+const shake256Rate = 136
+
+// shakeX4 evaluates the PRF or F function of a SHAKE parameter set on four
+// inputs in parallel. The inputs have the form pkSeed ‖ ADRS ‖ msg, so they
+// always fit in a single block of SHAKE256.
+type shakeX4 struct {
+	perm keccakf1600.StateX4
+	in   [4][]byte
+	addr [4]address
+	msg  [4][]byte
+}
+
+// init copies the input of the given hasher into each of the four lanes.
+func (x *shakeX4) init(p *params, h *baseHasher) {
+	for j := range x.in {
+		x.in[j] = append([]byte{}, h.input...)
+		c := cursor(x.in[j])
+		_ = c.Next(p.n)
+		x.addr[j].fromBytes(p, &c)
+		x.msg[j] = c.Next(p.n)
+	}
+}
+
+func (x *shakeX4) clear() {
+	for j := range x.in {
+		clearSlice(&x.in[j])
+		x.addr[j] = address{}
+		x.msg[j] = nil
+	}
+}
+
+// sum writes the first len(out[j]) bytes of SHAKE256(x.in[j]) to out[j].
+// It is safe for out[j] to alias x.msg[j].
+func (x *shakeX4) sum(out *[4][]byte) {
+	var buf [shake256Rate]byte
+	state := x.perm.Initialize()
+
+	for j := range x.in {
+		n := copy(buf[:], x.in[j])
+		clearBytes(buf[n:])
+		buf[n] = 0x1f
+		buf[shake256Rate-1] |= 0x80
+		for i := 0; i < shake256Rate/8; i++ {
+			state[4*i+j] = binary.LittleEndian.Uint64(buf[8*i:])
+		}
+		for i := shake256Rate / 8; i < 25; i++ {
+			state[4*i+j] = 0
+		}
+	}
+
+	x.perm.Permute()
+
+	for j := range out {
+		for i := 0; i < (len(out[j])+7)/8; i++ {
+			binary.LittleEndian.PutUint64(buf[8*i:], state[4*i+j])
+		}
+		copy(out[j], buf[:])
+	}
+}
+
+// wotsPkGenX4 is wotsPkGen computing four chains at a time.
+// It must only be called for the SHAKE parameter sets.
+func (s *statePriv) wotsPkGenX4(addr address) wotsPublicKey {
+	s.PRF.address.Set(addr)
+	s.PRF.address.SetTypeAndClear(addressWotsPrf)
+	s.PRF.address.SetKeyPairAddress(addr.GetKeyPairAddress())
+
+	s.F.address.Set(addr)
+
+	s.T.address.Set(addr)
+	s.T.address.SetTypeAndClear(addressWotsPk)
+	s.T.address.SetKeyPairAddress(addr.GetKeyPairAddress())
+
+	var prf, f shakeX4
+	prf.init(s.params, &s.PRF.baseHasher)
+	defer prf.clear()
+	f.init(s.params, &s.F.baseHasher)
+	defer f.clear()
+
+	s.T.Reset()
+	wotsLen := s.wotsLen()
+	for i := uint32(0); i < wotsLen; i += 4 {
+		// The lanes past the last chain compute values that are discarded.
+		for j := uint32(0); j < 4; j++ {
+			prf.addr[j].SetChainAddress(i + j)
+			f.addr[j].SetChainAddress(i + j)
+		}
+		prf.sum(&f.msg)
+
+		for k := uint32(0); k < wotsW-1; k++ {
+			for j := range f.addr {
+				f.addr[j].SetHashAddress(k)
+			}
+			f.sum(&f.msg)
+		}
+
+		for j := uint32(0); j < 4 && i+j < wotsLen; j++ {
+			s.T.WriteMessage(f.msg[j])
+		}
+	}
+
+	return s.T.Final()
+}